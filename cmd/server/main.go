@@ -3,25 +3,157 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"mime"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	graphql "github.com/graph-gophers/graphql-go"
-	"github.com/graph-gophers/graphql-go/relay"
 	_ "github.com/lib/pq"
 
 	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	entattachment "github.com/ishidatakuo/graphql-ent-atlas/ent/attachment"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/comment"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/project"
+	_ "github.com/ishidatakuo/graphql-ent-atlas/ent/runtime"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todorevision"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/user"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/attachment"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/dependency"
 	"github.com/ishidatakuo/graphql-ent-atlas/internal/graph"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/persisted"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/pubsub"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/recurrence"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/reminder"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/search"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/softdelete"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/storage"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/subtask"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/transport"
 )
 
 const defaultPort = "8090"
 
+var allowedOrigins = []string{"http://localhost:3000", "http://localhost:8090"}
+
+// checkOrigin allows WebSocket upgrades from the CORS origins and from
+// clients that send no Origin header.
+func checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	return origin == "" || slices.Contains(allowedOrigins, origin)
+}
+
+// jwtAuthenticator configures Bearer token authentication from the
+// environment. It returns nil when no verification key is configured.
+func jwtAuthenticator(client *ent.Client) (*auth.JWT, error) {
+	keys := auth.KeySet{}
+	if path := os.Getenv("JWT_JWKS_FILE"); path != "" {
+		jwks, err := auth.LoadJWKS(path)
+		if err != nil {
+			return nil, err
+		}
+		keys = jwks
+	}
+	if path := os.Getenv("JWT_PUBLIC_KEY_FILE"); path != "" {
+		key, err := auth.LoadRSAPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		keys[""] = key
+	}
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		if _, ok := keys[""]; ok {
+			return nil, errors.New("JWT_SECRET and JWT_PUBLIC_KEY_FILE are mutually exclusive")
+		}
+		keys[""] = []byte(secret)
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	return &auth.JWT{
+		Client:   client,
+		Keys:     keys,
+		Issuer:   os.Getenv("JWT_ISSUER"),
+		Audience: os.Getenv("JWT_AUDIENCE"),
+	}, nil
+}
+
+// blobStore configures the store of the files attached to todos from the
+// environment.
+func blobStore() (storage.BlobStore, error) {
+	switch os.Getenv("BLOB_STORE") {
+	case "", "local":
+		dir := os.Getenv("BLOB_DIR")
+		if dir == "" {
+			dir = "data/blobs"
+		}
+		return storage.Local{Dir: dir}, nil
+	case "s3":
+		s3 := &storage.S3{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			Region:          os.Getenv("S3_REGION"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		}
+		if s3.Endpoint == "" || s3.Bucket == "" {
+			return nil, errors.New("S3_ENDPOINT and S3_BUCKET are required")
+		}
+		if s3.Region == "" {
+			s3.Region = "us-east-1"
+		}
+		return s3, nil
+	default:
+		return nil, fmt.Errorf("unknown BLOB_STORE %q", os.Getenv("BLOB_STORE"))
+	}
+}
+
+// initAuth authenticates WebSocket connections with the "Authorization"
+// entry of their connection_init payload, as browsers cannot set headers on
+// the upgrade request.
+func initAuth(authenticators ...auth.Authenticator) func(context.Context, json.RawMessage) (context.Context, error) {
+	return func(ctx context.Context, payload json.RawMessage) (context.Context, error) {
+		var init struct {
+			Authorization string `json:"Authorization"`
+		}
+		if len(payload) > 0 {
+			if err := json.Unmarshal(payload, &init); err != nil {
+				return nil, err
+			}
+		}
+		return auth.Authenticate(ctx, init.Authorization, authenticators...)
+	}
+}
+
+// envInt returns the integer value of the environment variable name, or def
+// when it is unset.
+func envInt(name string, def int) int {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		log.Fatalf("invalid %s %q", name, v)
+	}
+	return n
+}
+
 func main() {
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -35,10 +167,11 @@ func main() {
 	}
 
 	// Entクライアント初期化
-	client, err := ent.Open("postgres", databaseURL)
+	drv, err := entsql.Open(dialect.Postgres, databaseURL)
 	if err != nil {
 		log.Fatalf("failed opening connection to postgres: %v", err)
 	}
+	client := ent.NewClient(ent.Driver(drv))
 	defer client.Close()
 
 	// スキーマ自動マイグレーション（開発環境用）
@@ -46,21 +179,120 @@ func main() {
 	if err := client.Schema.Create(ctx); err != nil {
 		log.Fatalf("failed creating schema resources: %v", err)
 	}
+	// 全文検索用の列とインデックス（Entスキーマ外のため個別に作成）
+	if err := search.EnsureSchema(ctx, drv.DB(), drv.Dialect()); err != nil {
+		log.Fatalf("failed creating search index: %v", err)
+	}
+	// 所有者のいないTodo（ユーザー導入前に作成されたもの）をCLAIM_ORPHANED_TODOSのメールアドレスのユーザーに割り当てる
+	if email := os.Getenv("CLAIM_ORPHANED_TODOS"); email != "" {
+		n, err := claimOrphanedTodos(ctx, client, email)
+		if err != nil {
+			log.Fatalf("failed claiming orphaned todos: %v", err)
+		}
+		log.Printf("assigned %d orphaned todos to %s", n, email)
+	}
+
+	// 変更通知（サブスクリプション用）
+	// 複数インスタンス構成ではPostgreSQLのLISTEN/NOTIFYで全インスタンスに配信する
+	var broker pubsub.Broker
+	switch os.Getenv("PUBSUB_BROKER") {
+	case "memory":
+		broker = pubsub.NewMemory()
+	case "", "postgres":
+		pg, err := pubsub.NewPostgres(drv.DB(), databaseURL, client)
+		if err != nil {
+			log.Fatalf("failed listening for change notifications: %v", err)
+		}
+		defer pg.Close()
+		broker = pg
+	default:
+		log.Fatalf("unknown PUBSUB_BROKER %q", os.Getenv("PUBSUB_BROKER"))
+	}
+	client.Todo.Use(pubsub.Hook(broker))
+
+	// リマインダー（remindAtを過ぎたTodoを通知する）
+	reminderCtx, stopReminders := context.WithCancel(ctx)
+	defer stopReminders()
+	go (&reminder.Scheduler{
+		Client:   client,
+		Notifier: reminder.LogNotifier{},
+	}).Run(reminderCtx)
+
+	// 添付ファイルの保存先（BLOB_STOREでローカルディスクかS3互換ストレージを選ぶ）
+	blobs, err := blobStore()
+	if err != nil {
+		log.Fatalf("failed configuring blob storage: %v", err)
+	}
+
+	// ゴミ箱（TRASH_RETENTIONより前に削除されたTodoを添付ファイルごと完全に削除する）
+	retention := 30 * 24 * time.Hour
+	if v := os.Getenv("TRASH_RETENTION"); v != "" {
+		retention, err = time.ParseDuration(v)
+		if err != nil || retention <= 0 {
+			log.Fatalf("invalid TRASH_RETENTION %q", v)
+		}
+	}
+	purgeCtx, stopPurge := context.WithCancel(ctx)
+	defer stopPurge()
+	go (&softdelete.Purger{
+		Client:    client,
+		Retention: retention,
+		BeforePurge: func(ctx context.Context, ids []int) error {
+			return attachment.DeleteAll(ctx, client, blobs, ids...)
+		},
+	}).Run(purgeCtx)
+
+	// 認証方式（Basic認証、APIキー、JWT_*が設定されていればBearerトークン）
+	authenticators := []auth.Authenticator{auth.Basic{Client: client}, auth.APIKey{Client: client}}
+	jwtAuth, err := jwtAuthenticator(client)
+	if err != nil {
+		log.Fatalf("failed configuring JWT authentication: %v", err)
+	}
+	if jwtAuth != nil {
+		authenticators = append(authenticators, jwtAuth)
+	}
+
+	// GraphQLの操作の上限（GRAPHQL_MAX_*、0で無制限）
+	limits := graph.Limits{
+		MaxDepth:      envInt("GRAPHQL_MAX_DEPTH", 10),
+		MaxComplexity: envInt("GRAPHQL_MAX_COMPLEXITY", 5000),
+		MaxTokens:     envInt("GRAPHQL_MAX_TOKENS", 5000),
+	}
 
-	// GraphQLスキーマを設定
+	// GraphQLスキーマを設定（データローダーが一覧のTodoのエッジをまとめて読み込めるよう、並列に実行するリゾルバー数を増やす）
 	schema := graphql.MustParseSchema(graph.Schema, &graph.Resolver{
 		Client: client,
-	})
+		Broker: broker,
+		Blobs:  blobs,
+	}, graphql.MaxParallelism(graph.MaxParallelism))
+
+	// 永続化クエリ（APQ）。PERSISTED_QUERIES_FILEのマニフェストを指定すると、そのクエリ以外を拒否する
+	queries := &persisted.Queries{}
+	if path := os.Getenv("PERSISTED_QUERIES_FILE"); path != "" {
+		manifest, err := persisted.LoadManifest(path)
+		if err != nil {
+			log.Fatalf("failed loading persisted queries: %v", err)
+		}
+		for hash, query := range manifest {
+			if errs := schema.Validate(query); len(errs) > 0 {
+				log.Fatalf("invalid persisted query %s: %v", hash, errs[0])
+			}
+		}
+		queries.Allowlist = manifest
+	} else if size := envInt("APQ_CACHE_SIZE", 1000); size > 0 {
+		queries.Cache = persisted.NewCache(size)
+	}
 
 	// Chi routerの設定
 	r := chi.NewRouter()
 
 	// CORS設定
 	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"http://localhost:3000", "http://localhost:8090"},
+		AllowedOrigins:   allowedOrigins,
 		AllowCredentials: true,
 		AllowedHeaders:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		ExposedHeaders:   []string{"ETag", "Content-Disposition"},
 	}))
 
 	// ミドルウェア設定
@@ -69,8 +301,24 @@ func main() {
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Timeout(60 * time.Second))
 
-	// GraphQL endpoint
-	r.Handle("/graphql", &relay.Handler{Schema: schema})
+	// 認証（Authorizationヘッダーがあればユーザーを特定する）
+	r.Use(auth.Middleware(authenticators...))
+
+	// GraphQL endpoint（WebSocketはgraphql-transport-wsプロトコルでサブスクリプションを提供、
+	// リクエストごとのデータローダーでエッジの読み込みをまとめる）
+	// 永続化クエリはハッシュからクエリを解決し、深さ・コスト・トークン数の上限を超える操作は実行前に拒否する
+	r.With(graph.LoaderMiddleware(client)).Handle("/graphql", &transport.WebSocket{
+		Schema: schema,
+		Fallback: &transport.HTTP{
+			Schema:       schema,
+			ResolveQuery: queries.Resolve,
+			Validate:     limits.Check,
+		},
+		CheckOrigin:  checkOrigin,
+		OnInit:       initAuth(authenticators...),
+		ResolveQuery: queries.Resolve,
+		Validate:     limits.Check,
+	})
 
 	// GraphQL Playground
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
@@ -95,12 +343,9 @@ func main() {
 		w.Write([]byte(html))
 	})
 
-	// REST API エンドポイント（互換性のため）
-	r.Get("/todos", getTodos(client))
-	r.Post("/todos", createTodo(client))
-	r.Get("/todos/{id}", getTodo(client))
-	r.Put("/todos/{id}", updateTodo(client))
-	r.Delete("/todos/{id}", deleteTodo(client))
+	// REST API エンドポイント（互換性のため、要認証）
+	// APIキーは付与されたスコープの操作のみ可能
+	r.Group(restRoutes(client, blobs))
 
 	// Health check endpoint
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -117,21 +362,75 @@ func main() {
 
 // REST API handler functions
 
+// restRoutes returns the routes of the REST API. They require an
+// authenticated user, and API keys only reach the routes of their scopes.
+func restRoutes(client *ent.Client, blobs storage.BlobStore) func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Use(auth.Required)
+		r.Group(func(r chi.Router) {
+			r.Use(auth.RequireScope(auth.ScopeTodosRead))
+			r.Get("/todos", getTodos(client))
+			r.Get("/todos/search", searchTodos(client))
+			r.Get("/todos/{id}", getTodo(client))
+			r.Get("/todos/{id}/history", getTodoHistory(client))
+			r.Get("/todos/{id}/comments", getTodoComments(client))
+			r.Get("/trash", getTrash(client))
+			r.Get("/projects", getProjects(client))
+			r.Get("/projects/{id}/todos", getProjectTodos(client))
+			r.Get("/attachments/{id}", getAttachment(client, blobs))
+		})
+		r.Group(func(r chi.Router) {
+			r.Use(auth.RequireScope(auth.ScopeTodosWrite))
+			r.Post("/todos", createTodo(client))
+			r.Post("/todos/batch", batchTodos(client))
+			r.Put("/todos/{id}", updateTodo(client))
+			r.Delete("/todos/{id}", deleteTodo(client))
+			r.Post("/todos/{id}/restore", restoreTodo(client))
+			r.Post("/todos/{id}/comments", createComment(client))
+			r.Put("/todos/{id}/comments/{commentId}", updateComment(client))
+			r.Delete("/todos/{id}/comments/{commentId}", deleteComment(client))
+			r.Post("/projects", createProject(client))
+		})
+	}
+}
+
 // TodoRequest represents the request body for creating/updating todos
 type TodoRequest struct {
-	Title       string  `json:"title"`
-	Description *string `json:"description"`
-	Completed   *bool   `json:"completed"`
+	Title       string     `json:"title"`
+	Description *string    `json:"description"`
+	Completed   *bool      `json:"completed"`
+	Priority    *string    `json:"priority"`
+	ProjectID   *int       `json:"project_id"`
+	ParentID    *int       `json:"parent_id"`
+	Recurrence  *string    `json:"recurrence"`
+	DueAt       *time.Time `json:"due_at"`
+	RemindAt    *time.Time `json:"remind_at"`
+	// The clear flags remove a value on update, unless the request also
+	// sets it, matching updateTodo of the GraphQL API.
+	ClearProject  bool `json:"clear_project"`
+	ClearParent   bool `json:"clear_parent"`
+	ClearDueAt    bool `json:"clear_due_at"`
+	ClearRemindAt bool `json:"clear_remind_at"`
 }
 
 // TodoResponse represents the response for todos
 type TodoResponse struct {
-	ID          int     `json:"id"`
-	Title       string  `json:"title"`
-	Description *string `json:"description"`
-	Completed   bool    `json:"completed"`
-	CreatedAt   string  `json:"created_at"`
-	UpdatedAt   string  `json:"updated_at"`
+	ID                   int     `json:"id"`
+	Title                string  `json:"title"`
+	Description          *string `json:"description"`
+	Completed            bool    `json:"completed"`
+	Priority             string  `json:"priority"`
+	Position             string  `json:"position"`
+	ProjectID            *int    `json:"project_id"`
+	ParentID             *int    `json:"parent_id"`
+	Recurrence           *string `json:"recurrence"`
+	PreviousOccurrenceID *int    `json:"previous_occurrence_id"`
+	DueAt                *string `json:"due_at"`
+	RemindAt             *string `json:"remind_at"`
+	CreatedAt            string  `json:"created_at"`
+	UpdatedAt            string  `json:"updated_at"`
+	DeletedAt            *string `json:"deleted_at,omitempty"`
+	Version              int     `json:"version"`
 }
 
 func entTodoToResponse(todo *ent.Todo) TodoResponse {
@@ -139,26 +438,403 @@ func entTodoToResponse(todo *ent.Todo) TodoResponse {
 	if todo.Description != "" {
 		description = &todo.Description
 	}
+	var projectID *int
+	if todo.ProjectID != 0 {
+		projectID = &todo.ProjectID
+	}
+	var parentID *int
+	if todo.ParentID != 0 {
+		parentID = &todo.ParentID
+	}
+	var recurrence *string
+	if todo.Recurrence != "" {
+		recurrence = &todo.Recurrence
+	}
+	var previousOccurrenceID *int
+	if todo.PreviousOccurrenceID != 0 {
+		previousOccurrenceID = &todo.PreviousOccurrenceID
+	}
 
 	return TodoResponse{
-		ID:          todo.ID,
-		Title:       todo.Title,
-		Description: description,
-		Completed:   todo.Completed,
-		CreatedAt:   todo.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   todo.UpdatedAt.Format(time.RFC3339),
+		ID:                   todo.ID,
+		Title:                todo.Title,
+		Description:          description,
+		Completed:            todo.Completed,
+		Priority:             todo.Priority.String(),
+		Position:             todo.Position,
+		ProjectID:            projectID,
+		ParentID:             parentID,
+		Recurrence:           recurrence,
+		PreviousOccurrenceID: previousOccurrenceID,
+		DueAt:                formatOptionalTime(todo.DueAt),
+		RemindAt:             formatOptionalTime(todo.RemindAt),
+		CreatedAt:            todo.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:            todo.UpdatedAt.Format(time.RFC3339),
+		DeletedAt:            formatOptionalTime(todo.DeletedAt),
+		Version:              todo.Version,
+	}
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
+
+// ProjectRequest represents the request body for creating projects
+type ProjectRequest struct {
+	Name     string  `json:"name"`
+	Color    *string `json:"color"`
+	Position *int    `json:"position"`
+}
+
+// ProjectResponse represents the response for projects
+type ProjectResponse struct {
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	Color     *string `json:"color"`
+	Archived  bool    `json:"archived"`
+	Position  int     `json:"position"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+func entProjectToResponse(project *ent.Project) ProjectResponse {
+	var color *string
+	if project.Color != "" {
+		color = &project.Color
+	}
+
+	return ProjectResponse{
+		ID:        project.ID,
+		Name:      project.Name,
+		Color:     color,
+		Archived:  project.Archived,
+		Position:  project.Position,
+		CreatedAt: project.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: project.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// TodoRevisionResponse represents the response for todo revisions
+type TodoRevisionResponse struct {
+	ID        int            `json:"id"`
+	Operation string         `json:"operation"`
+	ActorID   *int           `json:"actor_id"`
+	Fields    []string       `json:"fields"`
+	OldValues map[string]any `json:"old_values"`
+	NewValues map[string]any `json:"new_values"`
+	CreatedAt string         `json:"created_at"`
+}
+
+func entTodoRevisionToResponse(revision *ent.TodoRevision) TodoRevisionResponse {
+	var actorID *int
+	if revision.ActorID != 0 {
+		actorID = &revision.ActorID
+	}
+
+	return TodoRevisionResponse{
+		ID:        revision.ID,
+		Operation: revision.Operation.String(),
+		ActorID:   actorID,
+		Fields:    revision.Fields,
+		OldValues: revision.OldValues,
+		NewValues: revision.NewValues,
+		CreatedAt: revision.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// CommentRequest represents the request body for creating or editing comments
+type CommentRequest struct {
+	Body string `json:"body"`
+}
+
+// CommentResponse represents the response for comments
+type CommentResponse struct {
+	ID        int     `json:"id"`
+	TodoID    int     `json:"todo_id"`
+	AuthorID  int     `json:"author_id"`
+	Body      string  `json:"body"`
+	CreatedAt string  `json:"created_at"`
+	EditedAt  *string `json:"edited_at"`
+}
+
+func entCommentToResponse(c *ent.Comment) CommentResponse {
+	return CommentResponse{
+		ID:        c.ID,
+		TodoID:    c.TodoID,
+		AuthorID:  c.AuthorID,
+		Body:      c.Body,
+		CreatedAt: c.CreatedAt.Format(time.RFC3339),
+		EditedAt:  formatOptionalTime(c.EditedAt),
+	}
+}
+
+// ownsProject reports whether the project with the given ID belongs to the
+// authenticated user of r.
+func ownsProject(client *ent.Client, r *http.Request, id int) (bool, error) {
+	return client.Project.Query().
+		Where(project.ID(id), project.OwnerID(viewerID(r))).
+		Exist(r.Context())
+}
+
+// todoETag returns the entity tag of the todo, its version.
+func todoETag(t *ent.Todo) string {
+	return `"` + strconv.Itoa(t.Version) + `"`
+}
+
+// ifMatchVersions returns the todo versions listed by the If-Match header of
+// r. conditional is false without the header or for "If-Match: *". Weak and
+// malformed entity tags match no version.
+func ifMatchVersions(r *http.Request) (versions []int, conditional bool) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return nil, false
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		if v, err := strconv.Atoi(tag[1 : len(tag)-1]); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	return versions, true
+}
+
+// ownsTodo reports whether the todo with the given ID belongs to the
+// authenticated user of r.
+func ownsTodo(client *ent.Client, r *http.Request, id int) (bool, error) {
+	return client.Todo.Query().
+		Where(todo.ID(id), todo.OwnerID(viewerID(r))).
+		Exist(r.Context())
+}
+
+// childPolicy returns the subtask policy of the child_policy query parameter
+// of r, or def if it is not set.
+func childPolicy(r *http.Request, def subtask.Policy) (subtask.Policy, error) {
+	v := r.URL.Query().Get("child_policy")
+	if v == "" {
+		return def, nil
+	}
+	return subtask.ParsePolicy(v)
+}
+
+// todoErrorStatus returns the HTTP status of a subtask or dependency error,
+// or 0 if err is not one.
+func todoErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, subtask.ErrCycle):
+		return http.StatusBadRequest
+	case errors.Is(err, subtask.ErrHasChildren),
+		errors.Is(err, subtask.ErrIncompleteChildren),
+		errors.Is(err, dependency.ErrBlocked):
+		return http.StatusConflict
+	}
+	return 0
+}
+
+// requestError is an error responded with its HTTP status.
+type requestError struct {
+	status  int
+	message string
+}
+
+func (e *requestError) Error() string {
+	return e.message
+}
+
+// todoErrorResponse returns the HTTP status and message responding to err,
+// the error of an operation on a todo such as "update".
+func todoErrorResponse(operation string, err error) (int, string) {
+	var reqErr *requestError
+	switch {
+	case errors.As(err, &reqErr):
+		return reqErr.status, reqErr.message
+	case ent.IsNotFound(err):
+		return http.StatusNotFound, "Todo not found"
+	}
+	if status := todoErrorStatus(err); status != 0 {
+		return status, err.Error()
+	}
+	return http.StatusInternalServerError, "Failed to " + operation + " todo: " + err.Error()
+}
+
+// writeTodoError responds with err, the error of an operation on a todo.
+func writeTodoError(w http.ResponseWriter, operation string, err error) {
+	status, message := todoErrorResponse(operation, err)
+	http.Error(w, message, status)
+}
+
+// claimOrphanedTodos gives the todos without an owner, such as those created
+// before users were added, to the user with the given email, trashed todos
+// included. It returns the number of todos claimed.
+func claimOrphanedTodos(ctx context.Context, client *ent.Client, email string) (int, error) {
+	var n int
+	err := withTx(ctx, client, func(client *ent.Client) error {
+		u, err := client.User.Query().Where(user.Email(email)).Only(ctx)
+		if ent.IsNotFound(err) {
+			return fmt.Errorf("no user has the email %q", email)
+		}
+		if err != nil {
+			return err
+		}
+		n, err = client.Todo.Update().
+			Where(todo.OwnerIDIsNil()).
+			SetOwnerID(u.ID).
+			Save(softdelete.Skip(ctx))
+		return err
+	})
+	return n, err
+}
+
+// withTx runs fn in a transaction, committing it if fn succeeds.
+func withTx(ctx context.Context, client *ent.Client, fn func(client *ent.Client) error) error {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx.Client()); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			return fmt.Errorf("%w: rolling back: %v", err, rerr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// viewerID returns the ID of the authenticated user of r. Handlers using it
+// must be mounted behind auth.Required.
+func viewerID(r *http.Request) int {
+	p, _ := auth.FromContext(r.Context())
+	return p.User.ID
+}
+
+// parseTodoFilter builds the filter and order of GET /todos from its query
+// parameters. Filters are combined with AND.
+func parseTodoFilter(r *http.Request) (*graph.TodoWhereInput, *graph.TodoOrder, error) {
+	q := r.URL.Query()
+	where := &graph.TodoWhereInput{}
+
+	if v := q.Get("completed"); v != "" {
+		completed, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid completed: %w", err)
+		}
+		where.Completed = &completed
+	}
+	if v := q.Get("title_contains"); v != "" {
+		where.TitleContains = &v
 	}
+	if v := q.Get("title_prefix"); v != "" {
+		where.TitleHasPrefix = &v
+	}
+	if v := q.Get("tag"); v != "" {
+		where.HasTag = &v
+	}
+	if v := q.Get("priority"); v != "" {
+		v = strings.ToUpper(v)
+		if err := todo.PriorityValidator(todo.Priority(v)); err != nil {
+			return nil, nil, fmt.Errorf("invalid priority: %w", err)
+		}
+		where.Priority = &v
+	}
+	for param, dst := range map[string]**graphql.Time{
+		"created_after":  &where.CreatedAtGTE,
+		"created_before": &where.CreatedAtLTE,
+		"updated_after":  &where.UpdatedAtGTE,
+		"updated_before": &where.UpdatedAtLTE,
+	} {
+		v := q.Get(param)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid %s: %w", param, err)
+		}
+		*dst = &graphql.Time{Time: t}
+	}
+
+	order := &graph.TodoOrder{
+		Field:     strings.ToUpper(q.Get("order_by")),
+		Direction: strings.ToUpper(q.Get("order")),
+	}
+	if order.Field == "" {
+		order.Field = graph.DefaultTodoOrder.Field
+	}
+	if order.Direction == "" {
+		order.Direction = graph.OrderDirectionAsc
+	}
+	if err := order.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return where, order, nil
 }
 
 func getTodos(client *ent.Client) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		todos, err := client.Todo.Query().All(r.Context())
+		writeTodos(w, r, client.Todo.Query().Where(todo.OwnerID(viewerID(r))))
+	}
+}
+
+// writeTodos responds with the todos of q, filtered and ordered by the query
+// parameters of r.
+func writeTodos(w http.ResponseWriter, r *http.Request, q *ent.TodoQuery) {
+	where, order, err := parseTodoFilter(r)
+	if err != nil {
+		http.Error(w, "Invalid query: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query, err := order.Order(where.Filter(q))
+	if err != nil {
+		http.Error(w, "Invalid query: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	todos, err := query.All(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to get todos: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var response []TodoResponse
+	for _, todo := range todos {
+		response = append(response, entTodoToResponse(todo))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func searchTodos(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := 20
+		if v := r.URL.Query().Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 || n > 100 {
+				http.Error(w, "Invalid limit", http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+
+		todos, err := search.Todos(r.Context(), client.Todo.Query().Where(todo.OwnerID(viewerID(r))), r.URL.Query().Get("q"), limit)
 		if err != nil {
-			http.Error(w, "Failed to get todos: "+err.Error(), http.StatusInternalServerError)
+			if errors.Is(err, search.ErrEmptyQuery) {
+				http.Error(w, "Query parameter q is required", http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "Failed to search todos: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		var response []TodoResponse
+		response := make([]TodoResponse, 0, len(todos))
 		for _, todo := range todos {
 			response = append(response, entTodoToResponse(todo))
 		}
@@ -176,28 +852,80 @@ func createTodo(client *ent.Client) http.HandlerFunc {
 			return
 		}
 
-		if req.Title == "" {
-			http.Error(w, "Title is required", http.StatusBadRequest)
-			return
-		}
-
-		builder := client.Todo.Create().SetTitle(req.Title)
-		if req.Description != nil {
-			builder = builder.SetDescription(*req.Description)
-		}
-
-		todo, err := builder.Save(r.Context())
+		todo, err := createTodoFromRequest(r, client, req)
 		if err != nil {
-			http.Error(w, "Failed to create todo: "+err.Error(), http.StatusInternalServerError)
+			writeTodoError(w, "create", err)
 			return
 		}
 
 		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", todoETag(todo))
 		w.WriteHeader(http.StatusCreated)
 		json.NewEncoder(w).Encode(entTodoToResponse(todo))
 	}
 }
 
+// createTodoFromRequest creates the todo described by req for the
+// authenticated user of r.
+func createTodoFromRequest(r *http.Request, client *ent.Client, req TodoRequest) (*ent.Todo, error) {
+	if req.Title == "" {
+		return nil, &requestError{http.StatusBadRequest, "Title is required"}
+	}
+	if req.Priority != nil {
+		priority := strings.ToUpper(*req.Priority)
+		if todo.PriorityValidator(todo.Priority(priority)) != nil {
+			return nil, &requestError{http.StatusBadRequest, "Invalid priority"}
+		}
+		req.Priority = &priority
+	}
+	if req.Recurrence != nil && *req.Recurrence != "" {
+		if err := recurrence.Validate(*req.Recurrence); err != nil {
+			return nil, &requestError{http.StatusBadRequest, err.Error()}
+		}
+	}
+
+	builder := client.Todo.Create().
+		SetTitle(req.Title).
+		SetOwnerID(viewerID(r))
+	if req.Description != nil {
+		builder = builder.SetDescription(*req.Description)
+	}
+	if req.Priority != nil {
+		builder = builder.SetPriority(todo.Priority(*req.Priority))
+	}
+	if req.ProjectID != nil {
+		owned, err := ownsProject(client, r, *req.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if !owned {
+			return nil, &requestError{http.StatusBadRequest, "Project not found"}
+		}
+		builder = builder.SetProjectID(*req.ProjectID)
+	}
+	if req.ParentID != nil {
+		owned, err := ownsTodo(client, r, *req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if !owned {
+			return nil, &requestError{http.StatusBadRequest, "Parent todo not found"}
+		}
+		builder = builder.SetParentID(*req.ParentID)
+	}
+	if req.Recurrence != nil && *req.Recurrence != "" {
+		builder = builder.SetRecurrence(*req.Recurrence)
+	}
+	if req.DueAt != nil {
+		builder = builder.SetDueAt(*req.DueAt)
+	}
+	if req.RemindAt != nil {
+		builder = builder.SetRemindAt(*req.RemindAt)
+	}
+
+	return builder.Save(r.Context())
+}
+
 func getTodo(client *ent.Client) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		idStr := chi.URLParam(r, "id")
@@ -207,7 +935,9 @@ func getTodo(client *ent.Client) http.HandlerFunc {
 			return
 		}
 
-		todo, err := client.Todo.Get(r.Context(), id)
+		todo, err := client.Todo.Query().
+			Where(todo.ID(id), todo.OwnerID(viewerID(r))).
+			Only(r.Context())
 		if err != nil {
 			if ent.IsNotFound(err) {
 				http.Error(w, "Todo not found", http.StatusNotFound)
@@ -218,52 +948,101 @@ func getTodo(client *ent.Client) http.HandlerFunc {
 		}
 
 		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", todoETag(todo))
 		json.NewEncoder(w).Encode(entTodoToResponse(todo))
 	}
 }
 
-func updateTodo(client *ent.Client) http.HandlerFunc {
+func getAttachment(client *ent.Client, blobs storage.BlobStore) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		idStr := chi.URLParam(r, "id")
 		id, err := strconv.Atoi(idStr)
 		if err != nil {
-			http.Error(w, "Invalid todo ID", http.StatusBadRequest)
+			http.Error(w, "Invalid attachment ID", http.StatusBadRequest)
 			return
 		}
 
-		var req TodoRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+		a, err := client.Attachment.Query().
+			Where(
+				entattachment.ID(id),
+				entattachment.HasTodoWith(todo.OwnerID(viewerID(r)), todo.DeletedAtIsNil()),
+			).
+			Only(r.Context())
+		if err != nil {
+			if ent.IsNotFound(err) {
+				http.Error(w, "Attachment not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get attachment: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// 添付ファイルの内容は変わらないため、チェックサムをETagにする
+		etag := `"` + a.Checksum + `"`
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "private")
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		content, err := blobs.Get(r.Context(), a.StorageKey)
+		if err != nil {
+			http.Error(w, "Failed to get attachment: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
+		defer content.Close()
 
-		builder := client.Todo.UpdateOneID(id)
-		if req.Title != "" {
-			builder = builder.SetTitle(req.Title)
+		// ブラウザで開かずにダウンロードさせ、内容からの型の推測もさせない
+		w.Header().Set("Content-Type", a.ContentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(a.Size, 10))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename}))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		if _, err := io.Copy(w, content); err != nil {
+			log.Printf("failed sending attachment %d: %v", a.ID, err)
 		}
-		if req.Description != nil {
-			builder = builder.SetDescription(*req.Description)
+	}
+}
+
+func getTodoHistory(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := chi.URLParam(r, "id")
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			http.Error(w, "Invalid todo ID", http.StatusBadRequest)
+			return
+		}
+
+		owned, err := ownsTodo(client, r, id)
+		if err != nil {
+			http.Error(w, "Failed to get todo: "+err.Error(), http.StatusInternalServerError)
+			return
 		}
-		if req.Completed != nil {
-			builder = builder.SetCompleted(*req.Completed)
+		if !owned {
+			http.Error(w, "Todo not found", http.StatusNotFound)
+			return
 		}
 
-		todo, err := builder.Save(r.Context())
+		revisions, err := client.TodoRevision.Query().
+			Where(todorevision.TodoID(id)).
+			Order(ent.Desc(todorevision.FieldID)).
+			All(r.Context())
 		if err != nil {
-			if ent.IsNotFound(err) {
-				http.Error(w, "Todo not found", http.StatusNotFound)
-				return
-			}
-			http.Error(w, "Failed to update todo: "+err.Error(), http.StatusInternalServerError)
+			http.Error(w, "Failed to get history: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
 
+		response := make([]TodoRevisionResponse, len(revisions))
+		for i, revision := range revisions {
+			response[i] = entTodoRevisionToResponse(revision)
+		}
+
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(entTodoToResponse(todo))
+		json.NewEncoder(w).Encode(response)
 	}
 }
 
-func deleteTodo(client *ent.Client) http.HandlerFunc {
+func getTodoComments(client *ent.Client) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		idStr := chi.URLParam(r, "id")
 		id, err := strconv.Atoi(idStr)
@@ -272,16 +1051,651 @@ func deleteTodo(client *ent.Client) http.HandlerFunc {
 			return
 		}
 
-		err = client.Todo.DeleteOneID(id).Exec(r.Context())
+		owned, err := ownsTodo(client, r, id)
 		if err != nil {
-			if ent.IsNotFound(err) {
-				http.Error(w, "Todo not found", http.StatusNotFound)
-				return
-			}
-			http.Error(w, "Failed to delete todo: "+err.Error(), http.StatusInternalServerError)
+			http.Error(w, "Failed to get todo: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !owned {
+			http.Error(w, "Todo not found", http.StatusNotFound)
 			return
 		}
 
-		w.WriteHeader(http.StatusNoContent)
+		comments, err := client.Comment.Query().
+			Where(comment.TodoID(id)).
+			Order(ent.Asc(comment.FieldID)).
+			All(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to get comments: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		response := make([]CommentResponse, len(comments))
+		for i, c := range comments {
+			response[i] = entCommentToResponse(c)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func createComment(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := chi.URLParam(r, "id")
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			http.Error(w, "Invalid todo ID", http.StatusBadRequest)
+			return
+		}
+
+		var req CommentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		body := strings.TrimSpace(req.Body)
+		if body == "" {
+			http.Error(w, "Body is required", http.StatusBadRequest)
+			return
+		}
+
+		owned, err := ownsTodo(client, r, id)
+		if err != nil {
+			http.Error(w, "Failed to get todo: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !owned {
+			http.Error(w, "Todo not found", http.StatusNotFound)
+			return
+		}
+
+		c, err := client.Comment.Create().
+			SetTodoID(id).
+			SetAuthorID(viewerID(r)).
+			SetBody(body).
+			Save(r.Context())
+		if err != nil {
+			if ent.IsValidationError(err) {
+				http.Error(w, "Invalid comment: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "Failed to create comment: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(entCommentToResponse(c))
+	}
+}
+
+func updateComment(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c, ok := authoredComment(client, w, r)
+		if !ok {
+			return
+		}
+
+		var req CommentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		body := strings.TrimSpace(req.Body)
+		if body == "" {
+			http.Error(w, "Body is required", http.StatusBadRequest)
+			return
+		}
+
+		c, err := c.Update().
+			SetBody(body).
+			SetEditedAt(time.Now()).
+			Save(r.Context())
+		if err != nil {
+			if ent.IsValidationError(err) {
+				http.Error(w, "Invalid comment: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "Failed to update comment: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(entCommentToResponse(c))
+	}
+}
+
+func deleteComment(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c, ok := authoredComment(client, w, r)
+		if !ok {
+			return
+		}
+
+		if err := client.Comment.DeleteOne(c).Exec(r.Context()); err != nil {
+			http.Error(w, "Failed to delete comment: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// authoredComment returns the comment of the commentId URL parameter of r,
+// on the todo of the id parameter. It writes an error response and returns
+// false unless the todo belongs to the authenticated user of r and the
+// comment was written by them. Only the owner of a todo can comment on it, so
+// the comments of the user's todos are all theirs for now.
+func authoredComment(client *ent.Client, w http.ResponseWriter, r *http.Request) (*ent.Comment, bool) {
+	todoID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid todo ID", http.StatusBadRequest)
+		return nil, false
+	}
+	id, err := strconv.Atoi(chi.URLParam(r, "commentId"))
+	if err != nil {
+		http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+		return nil, false
+	}
+
+	c, err := client.Comment.Query().
+		Where(
+			comment.ID(id),
+			comment.TodoID(todoID),
+			comment.AuthorID(viewerID(r)),
+			comment.HasTodoWith(todo.OwnerID(viewerID(r))),
+		).
+		Only(r.Context())
+	if ent.IsNotFound(err) {
+		http.Error(w, "Comment not found", http.StatusNotFound)
+		return nil, false
+	}
+	if err != nil {
+		http.Error(w, "Failed to get comment: "+err.Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+	return c, true
+}
+
+func updateTodo(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := chi.URLParam(r, "id")
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			http.Error(w, "Invalid todo ID", http.StatusBadRequest)
+			return
+		}
+
+		var req TodoRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		var opts todoUpdateOptions
+		// Without child_policy, completing a todo leaves its subtasks unchanged.
+		if opts.policy, err = childPolicy(r, ""); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// A todo blocked by open todos is only completed with force=true.
+		if v := r.URL.Query().Get("force"); v != "" {
+			if opts.force, err = strconv.ParseBool(v); err != nil {
+				http.Error(w, "Invalid force", http.StatusBadRequest)
+				return
+			}
+		}
+		// With If-Match, the todo is only updated if it still has one of the
+		// versions the client read.
+		opts.versions, opts.conditional = ifMatchVersions(r)
+
+		var updated *ent.Todo
+		err = withTx(r.Context(), client, func(client *ent.Client) error {
+			updated, err = updateTodoFromRequest(r, client, id, req, opts)
+			return err
+		})
+		if err != nil {
+			writeTodoError(w, "update", err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", todoETag(updated))
+		json.NewEncoder(w).Encode(entTodoToResponse(updated))
+	}
+}
+
+// todoUpdateOptions are the options of a todo update besides its fields.
+type todoUpdateOptions struct {
+	// policy is applied to the subtasks of a todo the update completes, if
+	// set.
+	policy subtask.Policy
+	// force completes todos blocked by open todos.
+	force bool
+	// versions are the versions the todo must have if conditional is set.
+	versions    []int
+	conditional bool
+}
+
+// updateTodoFromRequest applies req to the todo of the authenticated user of
+// r with the given ID. Run it in a transaction: completing a todo updates its
+// subtasks and creates the next occurrence of recurring todos.
+func updateTodoFromRequest(r *http.Request, client *ent.Client, id int, req TodoRequest, opts todoUpdateOptions) (*ent.Todo, error) {
+	if req.Priority != nil {
+		priority := strings.ToUpper(*req.Priority)
+		if todo.PriorityValidator(todo.Priority(priority)) != nil {
+			return nil, &requestError{http.StatusBadRequest, "Invalid priority"}
+		}
+		req.Priority = &priority
+	}
+	if req.Recurrence != nil && *req.Recurrence != "" {
+		if err := recurrence.Validate(*req.Recurrence); err != nil {
+			return nil, &requestError{http.StatusBadRequest, err.Error()}
+		}
+	}
+	if req.ProjectID != nil {
+		owned, err := ownsProject(client, r, *req.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if !owned {
+			return nil, &requestError{http.StatusBadRequest, "Project not found"}
+		}
+	}
+	if req.ParentID != nil {
+		owned, err := ownsTodo(client, r, *req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if !owned {
+			return nil, &requestError{http.StatusBadRequest, "Parent todo not found"}
+		}
+	}
+
+	builder := client.Todo.UpdateOneID(id).
+		Where(todo.OwnerID(viewerID(r)))
+	if opts.conditional {
+		builder = builder.Where(todo.VersionIn(opts.versions...))
+	}
+	if req.Title != "" {
+		builder = builder.SetTitle(req.Title)
+	}
+	if req.Description != nil {
+		builder = builder.SetDescription(*req.Description)
+	}
+	if req.Completed != nil {
+		builder = builder.SetCompleted(*req.Completed)
+	}
+	if req.Priority != nil {
+		builder = builder.SetPriority(todo.Priority(*req.Priority))
+	}
+	if req.ProjectID != nil {
+		builder = builder.SetProjectID(*req.ProjectID)
+	} else if req.ClearProject {
+		builder = builder.ClearProjectID()
+	}
+	if req.ParentID != nil {
+		builder = builder.SetParentID(*req.ParentID)
+	} else if req.ClearParent {
+		builder = builder.ClearParentID()
+	}
+	if req.Recurrence != nil {
+		if *req.Recurrence == "" {
+			builder = builder.ClearRecurrence()
+		} else {
+			builder = builder.SetRecurrence(*req.Recurrence)
+		}
+	}
+	if req.DueAt != nil {
+		builder = builder.SetDueAt(*req.DueAt)
+	} else if req.ClearDueAt {
+		builder = builder.ClearDueAt()
+	}
+	if req.RemindAt != nil {
+		builder = builder.SetRemindAt(*req.RemindAt).ClearReminderSentAt()
+	} else if req.ClearRemindAt {
+		builder = builder.ClearRemindAt().ClearReminderSentAt()
+	}
+
+	updated, err := builder.Save(r.Context())
+	if ent.IsNotFound(err) && opts.conditional {
+		// The todo may exist with another version.
+		owned, oerr := ownsTodo(client, r, id)
+		if oerr == nil && owned {
+			return nil, &requestError{http.StatusPreconditionFailed, "Todo was updated concurrently"}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if req.Completed == nil || !*req.Completed {
+		return updated, nil
+	}
+	if !opts.force {
+		if err := dependency.CheckComplete(r.Context(), client, id); err != nil {
+			return nil, err
+		}
+	}
+	if opts.policy != "" {
+		if err := subtask.Complete(r.Context(), client, id, opts.policy); err != nil {
+			return nil, err
+		}
+	}
+	// Completing a recurring todo creates its next occurrence.
+	if _, err := recurrence.CreateNext(r.Context(), client, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func deleteTodo(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := chi.URLParam(r, "id")
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			http.Error(w, "Invalid todo ID", http.StatusBadRequest)
+			return
+		}
+		policy, err := childPolicy(r, subtask.Orphan)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = withTx(r.Context(), client, func(client *ent.Client) error {
+			return deleteTodoFromRequest(r, client, id, policy)
+		})
+		if err != nil {
+			writeTodoError(w, "delete", err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// deleteTodoFromRequest moves the todo of the authenticated user of r with
+// the given ID to the trash, applying policy to its subtasks. Run it in a
+// transaction.
+func deleteTodoFromRequest(r *http.Request, client *ent.Client, id int, policy subtask.Policy) error {
+	_, err := client.Todo.Query().
+		Where(todo.ID(id), todo.OwnerID(viewerID(r))).
+		OnlyID(r.Context())
+	if err != nil {
+		return err
+	}
+	return subtask.Delete(r.Context(), client, id, policy)
+}
+
+// maxBatchOperations is the largest number of operations of a batch request.
+const maxBatchOperations = 100
+
+// BatchRequest represents the request body of POST /todos/batch
+type BatchRequest struct {
+	Operations []BatchOperation `json:"operations"`
+}
+
+// BatchOperation represents an operation of a batch request: "create" creates
+// Todo, "update" applies Todo to the todo ID (if it has Version, when set)
+// and "delete" moves the todo ID to the trash.
+type BatchOperation struct {
+	Op          string      `json:"op"`
+	ID          int         `json:"id"`
+	Todo        TodoRequest `json:"todo"`
+	Version     *int        `json:"version"`
+	ChildPolicy string      `json:"child_policy"`
+	Force       bool        `json:"force"`
+}
+
+// BatchResponse represents the response of POST /todos/batch
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
+
+// BatchResult represents the result of a batch operation
+type BatchResult struct {
+	Status int           `json:"status"`
+	Todo   *TodoResponse `json:"todo,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// batchTodos runs the operations of a batch request in order, in one
+// transaction: if an operation fails, none is applied.
+func batchTodos(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req BatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(req.Operations) > maxBatchOperations {
+			http.Error(w, fmt.Sprintf("A batch has at most %d operations", maxBatchOperations), http.StatusBadRequest)
+			return
+		}
+
+		results := make([]BatchResult, len(req.Operations))
+		failed := -1
+		err := withTx(r.Context(), client, func(client *ent.Client) error {
+			for i, op := range req.Operations {
+				status, todo, err := runBatchOperation(r, client, op)
+				if err != nil {
+					failed = i
+					status, message := todoErrorResponse(op.Op, err)
+					results[i] = BatchResult{Status: status, Error: message}
+					return err
+				}
+				results[i] = BatchResult{Status: status}
+				if todo != nil {
+					response := entTodoToResponse(todo)
+					results[i].Todo = &response
+				}
+			}
+			return nil
+		})
+		status := http.StatusOK
+		switch {
+		case failed >= 0:
+			status = results[failed].Status
+			for i := range results {
+				if i != failed {
+					results[i] = BatchResult{
+						Status: http.StatusFailedDependency,
+						Error:  fmt.Sprintf("Not applied: operation %d failed", failed),
+					}
+				}
+			}
+		case err != nil:
+			http.Error(w, "Failed to run batch: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(BatchResponse{Results: results})
+	}
+}
+
+// runBatchOperation runs op and returns the HTTP status of its result along
+// with the created or updated todo.
+func runBatchOperation(r *http.Request, client *ent.Client, op BatchOperation) (int, *ent.Todo, error) {
+	switch op.Op {
+	case "create":
+		todo, err := createTodoFromRequest(r, client, op.Todo)
+		return http.StatusCreated, todo, err
+	case "update":
+		opts := todoUpdateOptions{force: op.Force}
+		if op.ChildPolicy != "" {
+			policy, err := subtask.ParsePolicy(op.ChildPolicy)
+			if err != nil {
+				return 0, nil, &requestError{http.StatusBadRequest, err.Error()}
+			}
+			opts.policy = policy
+		}
+		if op.Version != nil {
+			opts.versions, opts.conditional = []int{*op.Version}, true
+		}
+		todo, err := updateTodoFromRequest(r, client, op.ID, op.Todo, opts)
+		return http.StatusOK, todo, err
+	case "delete":
+		policy := subtask.Orphan
+		if op.ChildPolicy != "" {
+			var err error
+			if policy, err = subtask.ParsePolicy(op.ChildPolicy); err != nil {
+				return 0, nil, &requestError{http.StatusBadRequest, err.Error()}
+			}
+		}
+		return http.StatusNoContent, nil, deleteTodoFromRequest(r, client, op.ID, policy)
+	}
+	return 0, nil, &requestError{http.StatusBadRequest, fmt.Sprintf("Unknown operation %q", op.Op)}
+}
+
+func getTrash(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		todos, err := client.Todo.Query().
+			Where(todo.OwnerID(viewerID(r)), todo.DeletedAtNotNil()).
+			Order(ent.Desc(todo.FieldDeletedAt), ent.Desc(todo.FieldID)).
+			All(softdelete.Skip(r.Context()))
+		if err != nil {
+			http.Error(w, "Failed to get trash: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		response := make([]TodoResponse, len(todos))
+		for i, todo := range todos {
+			response[i] = entTodoToResponse(todo)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func restoreTodo(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := chi.URLParam(r, "id")
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			http.Error(w, "Invalid todo ID", http.StatusBadRequest)
+			return
+		}
+
+		var restored *ent.Todo
+		err = withTx(r.Context(), client, func(client *ent.Client) error {
+			trashed, err := client.Todo.Query().
+				Where(todo.ID(id), todo.OwnerID(viewerID(r)), todo.DeletedAtNotNil()).
+				Only(softdelete.Skip(r.Context()))
+			if err != nil {
+				return err
+			}
+			restored, err = softdelete.Restore(r.Context(), client, trashed)
+			return err
+		})
+		if err != nil {
+			if ent.IsNotFound(err) {
+				http.Error(w, "Todo not found in trash", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to restore todo: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(entTodoToResponse(restored))
+	}
+}
+
+func getProjects(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := client.Project.Query().
+			Where(project.OwnerID(viewerID(r))).
+			Order(ent.Asc(project.FieldPosition), ent.Asc(project.FieldID))
+		includeArchived := false
+		if v := r.URL.Query().Get("include_archived"); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				http.Error(w, "Invalid include_archived", http.StatusBadRequest)
+				return
+			}
+			includeArchived = b
+		}
+		if !includeArchived {
+			query = query.Where(project.Archived(false))
+		}
+
+		projects, err := query.All(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to get projects: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		response := make([]ProjectResponse, 0, len(projects))
+		for _, project := range projects {
+			response = append(response, entProjectToResponse(project))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func createProject(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ProjectRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if req.Name == "" {
+			http.Error(w, "Name is required", http.StatusBadRequest)
+			return
+		}
+
+		builder := client.Project.Create().
+			SetName(req.Name).
+			SetOwnerID(viewerID(r))
+		if req.Color != nil {
+			builder = builder.SetColor(*req.Color)
+		}
+		if req.Position != nil {
+			builder = builder.SetPosition(*req.Position)
+		}
+
+		project, err := builder.Save(r.Context())
+		if err != nil {
+			if ent.IsValidationError(err) {
+				http.Error(w, "Invalid project: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "Failed to create project: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(entProjectToResponse(project))
+	}
+}
+
+func getProjectTodos(client *ent.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := chi.URLParam(r, "id")
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			http.Error(w, "Invalid project ID", http.StatusBadRequest)
+			return
+		}
+
+		owned, err := ownsProject(client, r, id)
+		if err != nil {
+			http.Error(w, "Failed to get project: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !owned {
+			http.Error(w, "Project not found", http.StatusNotFound)
+			return
+		}
+
+		writeTodos(w, r, client.Todo.Query().Where(todo.ProjectID(id), todo.OwnerID(viewerID(r))))
 	}
 }
@@ -0,0 +1,661 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/go-chi/chi/v5"
+	graphql "github.com/graph-gophers/graphql-go"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/enttest"
+	_ "github.com/ishidatakuo/graphql-ent-atlas/ent/runtime"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/graph"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/reminder"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/storage"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/transport"
+)
+
+// testServer serves the REST API and GraphQL over HTTP on an in-memory
+// database.
+type testServer struct {
+	client  *ent.Client
+	handler http.Handler
+	// user is the user requests are authenticated as.
+	user *ent.User
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	drv, err := entsql.Open(dialect.SQLite, "file:ent?mode=memory&_fk=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every connection opens its own in-memory database.
+	drv.DB().SetMaxOpenConns(1)
+	client := enttest.NewClient(t, enttest.WithOptions(ent.Driver(drv)))
+	t.Cleanup(func() { client.Close() })
+
+	s := &testServer{client: client}
+	s.user = s.newUser(t, "alice@example.com")
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), &auth.Principal{User: s.user})))
+		})
+	})
+	blobs := storage.Local{Dir: t.TempDir()}
+	r.Group(restRoutes(client, blobs))
+	r.With(graph.LoaderMiddleware(client)).Handle("/graphql", &transport.HTTP{
+		Schema: graphql.MustParseSchema(graph.Schema, &graph.Resolver{Client: client, Blobs: blobs}, graphql.MaxParallelism(graph.MaxParallelism)),
+	})
+	s.handler = r
+	return s
+}
+
+func (s *testServer) newUser(t *testing.T, email string) *ent.User {
+	t.Helper()
+	return s.client.User.Create().SetEmail(email).SetDisplayName(email).SaveX(context.Background())
+}
+
+// do sends a request with the given JSON body, if any, and header lines,
+// such as "If-Match: \"1\"".
+func (s *testServer) do(method, path, body string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for _, h := range header {
+		name, value, _ := strings.Cut(h, ": ")
+		req.Header.Add(name, value)
+	}
+	w := httptest.NewRecorder()
+	s.handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestTodoETag(t *testing.T) {
+	s := newTestServer(t)
+	if w := s.do("POST", "/todos", `{"title": "Buy milk"}`); w.Code != http.StatusCreated || w.Header().Get("ETag") != `"1"` {
+		t.Fatalf("POST /todos: got %d with ETag %s", w.Code, w.Header().Get("ETag"))
+	}
+
+	steps := []struct {
+		method, path, body, ifMatch string
+		wantStatus                  int
+		// wantETag is the entity tag of the response, if it succeeds.
+		wantETag string
+	}{
+		{method: "GET", path: "/todos/1", wantStatus: http.StatusOK, wantETag: `"1"`},
+		{method: "PUT", path: "/todos/1", body: `{"title": "a"}`, ifMatch: `"1"`, wantStatus: http.StatusOK, wantETag: `"2"`},
+		{method: "PUT", path: "/todos/1", body: `{"title": "b"}`, ifMatch: `"1"`, wantStatus: http.StatusPreconditionFailed},
+		{method: "PUT", path: "/todos/1", body: `{"title": "b"}`, ifMatch: `W/"2"`, wantStatus: http.StatusPreconditionFailed},
+		{method: "PUT", path: "/todos/1", body: `{"title": "b"}`, ifMatch: `garbage`, wantStatus: http.StatusPreconditionFailed},
+		{method: "PUT", path: "/todos/1", body: `{"title": "b"}`, ifMatch: `"7", "2"`, wantStatus: http.StatusOK, wantETag: `"3"`},
+		{method: "PUT", path: "/todos/1", body: `{"title": "c"}`, ifMatch: `*`, wantStatus: http.StatusOK, wantETag: `"4"`},
+		{method: "PUT", path: "/todos/1", body: `{"title": "d"}`, wantStatus: http.StatusOK, wantETag: `"5"`},
+		{method: "PUT", path: "/todos/2", body: `{"title": "d"}`, ifMatch: `"1"`, wantStatus: http.StatusNotFound},
+		{method: "GET", path: "/todos/1", wantStatus: http.StatusOK, wantETag: `"5"`},
+	}
+	for _, step := range steps {
+		var header []string
+		if step.ifMatch != "" {
+			header = append(header, "If-Match: "+step.ifMatch)
+		}
+		w := s.do(step.method, step.path, step.body, header...)
+		if w.Code != step.wantStatus {
+			t.Fatalf("%s %s with If-Match %s: got %d %s, want %d", step.method, step.path, step.ifMatch, w.Code, w.Body, step.wantStatus)
+		}
+		if got := w.Header().Get("ETag"); step.wantETag != "" && got != step.wantETag {
+			t.Errorf("%s %s with If-Match %s: got ETag %s, want %s", step.method, step.path, step.ifMatch, got, step.wantETag)
+		}
+	}
+}
+
+// nopNotifier drops the reminders it is given.
+type nopNotifier struct{}
+
+func (nopNotifier) Notify(context.Context, *ent.Todo) error { return nil }
+
+// TestTodoETagAfterReminder checks that sending the reminder of a todo, which
+// does not modify it, keeps its entity tag.
+func TestTodoETagAfterReminder(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	s.client.Todo.Create().
+		SetTitle("Call the dentist").
+		SetOwnerID(s.user.ID).
+		SetRemindAt(time.Now().Add(-time.Minute)).
+		SaveX(ctx)
+
+	scheduler := &reminder.Scheduler{Client: s.client, Notifier: nopNotifier{}}
+	if err := scheduler.SendDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if s.client.Todo.GetX(ctx, 1).ReminderSentAt == nil {
+		t.Fatal("the reminder was not sent")
+	}
+
+	if w := s.do("GET", "/todos/1", ""); w.Header().Get("ETag") != `"1"` {
+		t.Errorf("got ETag %s, want \"1\"", w.Header().Get("ETag"))
+	}
+	if w := s.do("PUT", "/todos/1", `{"completed": true}`, `If-Match: "1"`); w.Code != http.StatusOK {
+		t.Errorf("PUT with If-Match \"1\": got %d %s", w.Code, w.Body)
+	}
+}
+
+func TestComments(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.user
+	bob := s.newUser(t, "bob@example.com")
+	s.do("POST", "/todos", `{"title": "Plan the trip"}`)
+
+	steps := []struct {
+		user               *ent.User
+		method, path, body string
+		wantStatus         int
+		// wantBody is a part of the response.
+		wantBody string
+	}{
+		{method: "POST", path: "/todos/1/comments", body: `{"body": " Book the **train** "}`, wantStatus: http.StatusCreated, wantBody: `"body":"Book the **train**","created_at":`},
+		{method: "POST", path: "/todos/1/comments", body: `{"body": "Pack"}`, wantStatus: http.StatusCreated, wantBody: `"edited_at":null`},
+		{method: "POST", path: "/todos/1/comments", body: `{"body": "  "}`, wantStatus: http.StatusBadRequest},
+		{method: "POST", path: "/todos/2/comments", body: `{"body": "hi"}`, wantStatus: http.StatusNotFound},
+		{user: bob, method: "POST", path: "/todos/1/comments", body: `{"body": "hi"}`, wantStatus: http.StatusNotFound},
+		{user: bob, method: "GET", path: "/todos/1/comments", wantStatus: http.StatusNotFound},
+		{method: "PUT", path: "/todos/1/comments/1", body: `{"body": "Book the plane"}`, wantStatus: http.StatusOK, wantBody: `"body":"Book the plane"`},
+		{method: "PUT", path: "/todos/1/comments/1", body: `{"body": ""}`, wantStatus: http.StatusBadRequest},
+		{user: bob, method: "PUT", path: "/todos/1/comments/1", body: `{"body": "hi"}`, wantStatus: http.StatusNotFound},
+		{user: bob, method: "DELETE", path: "/todos/1/comments/1", wantStatus: http.StatusNotFound},
+		{method: "DELETE", path: "/todos/2/comments/2", wantStatus: http.StatusNotFound},
+		{method: "DELETE", path: "/todos/1/comments/2", wantStatus: http.StatusNoContent},
+		{method: "DELETE", path: "/todos/1/comments/2", wantStatus: http.StatusNotFound},
+		{method: "GET", path: "/todos/1/comments", wantStatus: http.StatusOK, wantBody: `[{"id":1,"todo_id":1,"author_id":1,"body":"Book the plane"`},
+	}
+	for _, step := range steps {
+		s.user = alice
+		if step.user != nil {
+			s.user = step.user
+		}
+		w := s.do(step.method, step.path, step.body)
+		if w.Code != step.wantStatus || !strings.Contains(w.Body.String(), step.wantBody) {
+			t.Errorf("%s %s as %s: got %d %s, want %d with %s", step.method, step.path, s.user.Email, w.Code, w.Body, step.wantStatus, step.wantBody)
+		}
+	}
+
+	c := s.client.Comment.GetX(context.Background(), 1)
+	if c.EditedAt == nil {
+		t.Error("editing the comment did not set its edit time")
+	}
+}
+
+func TestRestoreTodo(t *testing.T) {
+	s := newTestServer(t)
+	s.do("POST", "/todos", `{"title": "Plan the trip"}`)
+	s.do("POST", "/todos", `{"title": "Book the train", "parent_id": 1}`)
+	s.do("POST", "/todos", `{"title": "Compare fares", "parent_id": 2}`)
+
+	steps := []struct {
+		method, path string
+		wantStatus   int
+		// wantBody is a part of the response.
+		wantBody string
+	}{
+		{method: "DELETE", path: "/todos/1?child_policy=cascade", wantStatus: http.StatusNoContent},
+		{method: "POST", path: "/todos/2/restore", wantStatus: http.StatusOK, wantBody: `"parent_id":null`},
+		{method: "GET", path: "/trash", wantStatus: http.StatusOK, wantBody: `[{"id":1,`},
+		{method: "POST", path: "/todos/2/restore", wantStatus: http.StatusNotFound},
+		{method: "POST", path: "/todos/4/restore", wantStatus: http.StatusNotFound},
+		{method: "DELETE", path: "/todos/2?child_policy=cascade", wantStatus: http.StatusNoContent},
+		{method: "POST", path: "/todos/2/restore", wantStatus: http.StatusOK},
+		{method: "GET", path: "/todos/3", wantStatus: http.StatusOK, wantBody: `"parent_id":2`},
+	}
+	for _, step := range steps {
+		w := s.do(step.method, step.path, "")
+		if w.Code != step.wantStatus || !strings.Contains(w.Body.String(), step.wantBody) {
+			t.Errorf("%s %s: got %d %s, want %d with %s", step.method, step.path, w.Code, w.Body, step.wantStatus, step.wantBody)
+		}
+	}
+}
+
+func TestGetTodosFilter(t *testing.T) {
+	s := newTestServer(t)
+	s.do("POST", "/todos", `{"title": "Buy milk", "priority": "HIGH"}`)
+	s.do("POST", "/todos", `{"title": "Buy bread"}`)
+	s.do("POST", "/todos", `{"title": "Call mom"}`)
+	s.do("PUT", "/todos/1", `{"completed": true}`)
+
+	tests := []struct {
+		query      string
+		wantStatus int
+		// wantTitles lists the titles of the todos in the response.
+		wantTitles []string
+	}{
+		{query: "", wantStatus: http.StatusOK, wantTitles: []string{"Buy milk", "Buy bread", "Call mom"}},
+		{query: "completed=false", wantStatus: http.StatusOK, wantTitles: []string{"Buy bread", "Call mom"}},
+		{query: "title_contains=buy&order_by=title", wantStatus: http.StatusOK, wantTitles: []string{"Buy bread", "Buy milk"}},
+		{query: "title_prefix=Call", wantStatus: http.StatusOK, wantTitles: []string{"Call mom"}},
+		{query: "title_prefix=call", wantStatus: http.StatusOK, wantTitles: []string{"Call mom"}},
+		{query: "priority=high", wantStatus: http.StatusOK, wantTitles: []string{"Buy milk"}},
+		{query: "order_by=title&order=desc", wantStatus: http.StatusOK, wantTitles: []string{"Call mom", "Buy milk", "Buy bread"}},
+		{query: "created_after=2100-01-01T00:00:00Z", wantStatus: http.StatusOK},
+		{query: "completed=maybe", wantStatus: http.StatusBadRequest},
+		{query: "priority=whenever", wantStatus: http.StatusBadRequest},
+		{query: "created_after=yesterday", wantStatus: http.StatusBadRequest},
+		{query: "order_by=due_at", wantStatus: http.StatusBadRequest},
+		{query: "order=sideways", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		w := s.do("GET", "/todos?"+tt.query, "")
+		if w.Code != tt.wantStatus {
+			t.Errorf("GET /todos?%s: got %d %s, want %d", tt.query, w.Code, w.Body, tt.wantStatus)
+			continue
+		}
+		if w.Code != http.StatusOK {
+			continue
+		}
+		var todos []TodoResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &todos); err != nil {
+			t.Fatal(err)
+		}
+		var titles []string
+		for _, todo := range todos {
+			titles = append(titles, todo.Title)
+		}
+		if !slices.Equal(titles, tt.wantTitles) {
+			t.Errorf("GET /todos?%s: got %v, want %v", tt.query, titles, tt.wantTitles)
+		}
+	}
+}
+
+func TestBatchTodos(t *testing.T) {
+	tests := []struct {
+		name, body string
+		wantStatus int
+		// wantResults lists the status of the result of every operation.
+		wantResults []int
+		// wantTitles lists the titles of the todos once the batch is done.
+		wantTitles []string
+	}{
+		{
+			name: "applied",
+			body: `{"operations": [
+				{"op": "create", "todo": {"title": "Buy bread"}},
+				{"op": "update", "id": 1, "todo": {"title": "Buy oat milk"}, "version": 1},
+				{"op": "delete", "id": 2}
+			]}`,
+			wantStatus:  http.StatusOK,
+			wantResults: []int{http.StatusCreated, http.StatusOK, http.StatusNoContent},
+			wantTitles:  []string{"Buy oat milk", "Buy bread"},
+		},
+		{
+			name: "failed update",
+			body: `{"operations": [
+				{"op": "create", "todo": {"title": "Buy bread"}},
+				{"op": "update", "id": 1, "todo": {"title": "Buy oat milk"}, "version": 7},
+				{"op": "delete", "id": 2}
+			]}`,
+			wantStatus:  http.StatusPreconditionFailed,
+			wantResults: []int{http.StatusFailedDependency, http.StatusPreconditionFailed, http.StatusFailedDependency},
+			wantTitles:  []string{"Buy milk", "Call mom"},
+		},
+		{
+			name: "missing todo",
+			body: `{"operations": [
+				{"op": "update", "id": 1, "todo": {"completed": true}},
+				{"op": "delete", "id": 9}
+			]}`,
+			wantStatus:  http.StatusNotFound,
+			wantResults: []int{http.StatusFailedDependency, http.StatusNotFound},
+			wantTitles:  []string{"Buy milk", "Call mom"},
+		},
+		{
+			name:        "unknown operation",
+			body:        `{"operations": [{"op": "create", "todo": {"title": "Buy bread"}}, {"op": "archive", "id": 1}]}`,
+			wantStatus:  http.StatusBadRequest,
+			wantResults: []int{http.StatusFailedDependency, http.StatusBadRequest},
+			wantTitles:  []string{"Buy milk", "Call mom"},
+		},
+		{
+			name:       "too many operations",
+			body:       `{"operations": [` + strings.Repeat(`{"op": "delete", "id": 1}, `, 100) + `{"op": "delete", "id": 1}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantTitles: []string{"Buy milk", "Call mom"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+			s.do("POST", "/todos", `{"title": "Buy milk"}`)
+			s.do("POST", "/todos", `{"title": "Call mom"}`)
+
+			w := s.do("POST", "/todos/batch", tt.body)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("got %d %s, want %d", w.Code, w.Body, tt.wantStatus)
+			}
+			if tt.wantResults != nil {
+				var resp BatchResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				var statuses []int
+				for _, r := range resp.Results {
+					statuses = append(statuses, r.Status)
+				}
+				if !slices.Equal(statuses, tt.wantResults) {
+					t.Errorf("got results %+v, want statuses %v", resp.Results, tt.wantResults)
+				}
+			}
+
+			var todos []TodoResponse
+			json.Unmarshal(s.do("GET", "/todos", "").Body.Bytes(), &todos)
+			var titles []string
+			for _, todo := range todos {
+				titles = append(titles, todo.Title)
+			}
+			if !slices.Equal(titles, tt.wantTitles) {
+				t.Errorf("got todos %v, want %v", titles, tt.wantTitles)
+			}
+		})
+	}
+}
+
+func TestAttachFile(t *testing.T) {
+	s := newTestServer(t)
+	s.do("POST", "/todos", `{"title": "File the taxes"}`)
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	mw.WriteField("operations", `{"query": "mutation($file: Upload!) { attachFile(todoId: 1, file: $file) { id filename contentType size } }", "variables": {"file": null}}`)
+	mw.WriteField("map", `{"0": ["variables.file"]}`)
+	part, err := mw.CreateFormFile("0", "reports/receipt.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write([]byte("Total: 42"))
+	mw.Close()
+	req := httptest.NewRequest("POST", "/graphql", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	s.handler.ServeHTTP(w, req)
+
+	var resp struct {
+		Data struct {
+			AttachFile struct {
+				ID, Filename, ContentType string
+				Size                      int
+			}
+		}
+		Errors []struct{ Message string }
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.Errors) > 0 {
+		t.Fatalf("attachFile: got %d %s", w.Code, w.Body)
+	}
+	a := resp.Data.AttachFile
+	if a.Filename != "receipt.txt" || a.ContentType != "text/plain; charset=utf-8" || a.Size != 9 {
+		t.Errorf("got attachment %+v", a)
+	}
+
+	w = s.do("GET", "/attachments/"+a.ID, "")
+	if w.Code != http.StatusOK || w.Body.String() != "Total: 42" {
+		t.Fatalf("got %d %q, want the file", w.Code, w.Body)
+	}
+	for name, want := range map[string]string{
+		"Content-Type":           "text/plain; charset=utf-8",
+		"Content-Length":         "9",
+		"Content-Disposition":    `attachment; filename=receipt.txt`,
+		"X-Content-Type-Options": "nosniff",
+	} {
+		if got := w.Header().Get(name); got != want {
+			t.Errorf("got %s %q, want %q", name, got, want)
+		}
+	}
+	etag := w.Header().Get("ETag")
+	if w := s.do("GET", "/attachments/"+a.ID, "", "If-None-Match: "+etag); w.Code != http.StatusNotModified {
+		t.Errorf("got %d for the ETag %s, want 304", w.Code, etag)
+	}
+
+	// Attachments of todos in the trash or of other users are not served.
+	alice := s.user
+	s.user = s.newUser(t, "bob@example.com")
+	if w := s.do("GET", "/attachments/"+a.ID, ""); w.Code != http.StatusNotFound {
+		t.Errorf("got %d for the attachment of another user, want 404", w.Code)
+	}
+	s.user = alice
+	s.do("DELETE", "/todos/1", "")
+	if w := s.do("GET", "/attachments/"+a.ID, ""); w.Code != http.StatusNotFound {
+		t.Errorf("got %d for the attachment of a trashed todo, want 404", w.Code)
+	}
+}
+
+func TestUpdateTodoClear(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "nothing", body: `{}`, want: "project 1, parent 1, due, remind"},
+		{name: "nulls", body: `{"project_id": null, "parent_id": null, "due_at": null, "remind_at": null}`, want: "project 1, parent 1, due, remind"},
+		{name: "project", body: `{"clear_project": true}`, want: "parent 1, due, remind"},
+		{name: "parent", body: `{"clear_parent": true}`, want: "project 1, due, remind"},
+		{name: "due date", body: `{"clear_due_at": true}`, want: "project 1, parent 1, remind"},
+		{name: "reminder", body: `{"clear_remind_at": true}`, want: "project 1, parent 1, due"},
+		{
+			name: "everything",
+			body: `{"clear_project": true, "clear_parent": true, "clear_due_at": true, "clear_remind_at": true}`,
+			want: "",
+		},
+		{
+			name: "ignored for set values",
+			body: `{"project_id": 1, "clear_project": true, "due_at": "2030-01-01T00:00:00Z", "clear_due_at": true}`,
+			want: "project 1, parent 1, due, remind",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+			s.do("POST", "/projects", `{"name": "Home"}`)
+			s.do("POST", "/todos", `{"title": "Move house"}`)
+			w := s.do("POST", "/todos", `{"title": "Book the movers", "project_id": 1, "parent_id": 1, "due_at": "2030-01-01T00:00:00Z", "remind_at": "2029-12-31T09:00:00Z"}`)
+			if w.Code != http.StatusCreated {
+				t.Fatalf("got %d %s creating the todo", w.Code, w.Body)
+			}
+
+			w = s.do("PUT", "/todos/2", tt.body)
+			if w.Code != http.StatusOK {
+				t.Fatalf("got %d %s", w.Code, w.Body)
+			}
+			var todo TodoResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &todo); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			if todo.ProjectID != nil {
+				got = append(got, fmt.Sprintf("project %d", *todo.ProjectID))
+			}
+			if todo.ParentID != nil {
+				got = append(got, fmt.Sprintf("parent %d", *todo.ParentID))
+			}
+			if todo.DueAt != nil {
+				got = append(got, "due")
+			}
+			if todo.RemindAt != nil {
+				got = append(got, "remind")
+			}
+			if strings.Join(got, ", ") != tt.want {
+				t.Errorf("got %q, want %q", strings.Join(got, ", "), tt.want)
+			}
+		})
+	}
+}
+
+func TestClaimOrphanedTodos(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	bob := s.newUser(t, "bob@example.com")
+	s.client.Todo.Create().SetTitle("Water plants").SaveX(ctx)
+	s.client.Todo.Create().SetTitle("Old errand").SetDeletedAt(time.Now()).SaveX(ctx)
+	s.client.Todo.Create().SetTitle("Bob's todo").SetOwner(bob).SaveX(ctx)
+
+	if _, err := claimOrphanedTodos(ctx, s.client, "carol@example.com"); err == nil || err.Error() != `no user has the email "carol@example.com"` {
+		t.Errorf("got error %v for an unknown email", err)
+	}
+	n, err := claimOrphanedTodos(ctx, s.client, "alice@example.com")
+	if err != nil || n != 2 {
+		t.Fatalf("got %d, %v, want 2 todos claimed", n, err)
+	}
+
+	var todos []TodoResponse
+	json.Unmarshal(s.do("GET", "/todos", "").Body.Bytes(), &todos)
+	if len(todos) != 1 || todos[0].Title != "Water plants" {
+		t.Errorf("got todos %+v, want the orphaned todo", todos)
+	}
+	var trash []TodoResponse
+	json.Unmarshal(s.do("GET", "/trash", "").Body.Bytes(), &trash)
+	if len(trash) != 1 || trash[0].Title != "Old errand" {
+		t.Errorf("got trash %+v, want the orphaned trashed todo", trash)
+	}
+	if n, err := claimOrphanedTodos(ctx, s.client, "alice@example.com"); err != nil || n != 0 {
+		t.Errorf("claiming again: got %d, %v, want none", n, err)
+	}
+}
+
+func TestTodosOfOtherUsers(t *testing.T) {
+	s := newTestServer(t)
+	s.do("POST", "/todos", `{"title": "Buy milk"}`)
+	alice := s.user
+	s.user = s.newUser(t, "bob@example.com")
+
+	for _, req := range []struct{ method, body string }{
+		{"GET", ""},
+		{"PUT", `{"title": "Mine"}`},
+		{"DELETE", ""},
+	} {
+		if w := s.do(req.method, "/todos/1", req.body); w.Code != http.StatusNotFound {
+			t.Errorf("%s /todos/1: got %d, want 404", req.method, w.Code)
+		}
+	}
+	var todos []TodoResponse
+	json.Unmarshal(s.do("GET", "/todos", "").Body.Bytes(), &todos)
+	if len(todos) != 0 {
+		t.Errorf("got todos %+v of another user", todos)
+	}
+
+	s.user = alice
+	w := s.do("GET", "/todos/1", "")
+	var todo TodoResponse
+	json.Unmarshal(w.Body.Bytes(), &todo)
+	if w.Code != http.StatusOK || todo.Title != "Buy milk" {
+		t.Errorf("got %d %s, want the todo unchanged", w.Code, w.Body)
+	}
+}
+
+func TestProjects(t *testing.T) {
+	s := newTestServer(t)
+	for _, body := range []string{
+		`{"name": "Home", "color": "#00ff00", "position": 2}`,
+		`{"name": "Work", "position": 1}`,
+		`{"name": "Trip", "position": 3}`,
+	} {
+		if w := s.do("POST", "/projects", body); w.Code != http.StatusCreated {
+			t.Fatalf("POST /projects %s: got %d %s", body, w.Code, w.Body)
+		}
+	}
+	if w := s.do("POST", "/projects", `{"color": "#00ff00"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("got %d creating a project without a name, want 400", w.Code)
+	}
+	s.client.Project.UpdateOneID(3).SetArchived(true).ExecX(context.Background())
+
+	names := func(path string) []string {
+		t.Helper()
+		w := s.do("GET", path, "")
+		var projects []ProjectResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &projects); err != nil {
+			t.Fatalf("GET %s: got %d %s", path, w.Code, w.Body)
+		}
+		names := []string{}
+		for _, p := range projects {
+			names = append(names, p.Name)
+		}
+		return names
+	}
+	if got, want := names("/projects"), []string{"Work", "Home"}; !slices.Equal(got, want) {
+		t.Errorf("got projects %v, want %v", got, want)
+	}
+	if got, want := names("/projects?include_archived=true"), []string{"Work", "Home", "Trip"}; !slices.Equal(got, want) {
+		t.Errorf("got projects %v including archived ones, want %v", got, want)
+	}
+	if w := s.do("GET", "/projects?include_archived=maybe", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("got %d for an invalid include_archived, want 400", w.Code)
+	}
+
+	s.do("POST", "/todos", `{"title": "Fix the sink", "project_id": 1}`)
+	s.do("POST", "/todos", `{"title": "Buy milk"}`)
+	var todos []TodoResponse
+	json.Unmarshal(s.do("GET", "/projects/1/todos", "").Body.Bytes(), &todos)
+	if len(todos) != 1 || todos[0].Title != "Fix the sink" {
+		t.Errorf("got todos %+v of project 1, want Fix the sink", todos)
+	}
+
+	// Projects of other users are not found.
+	s.user = s.newUser(t, "bob@example.com")
+	if got := names("/projects?include_archived=true"); len(got) != 0 {
+		t.Errorf("got projects %v of another user", got)
+	}
+	if w := s.do("GET", "/projects/1/todos", ""); w.Code != http.StatusNotFound {
+		t.Errorf("GET /projects/1/todos: got %d, want 404", w.Code)
+	}
+	if w := s.do("POST", "/todos", `{"title": "Mine", "project_id": 1}`); w.Code != http.StatusBadRequest {
+		t.Errorf("got %d creating a todo in another user's project, want 400", w.Code)
+	}
+	s.do("POST", "/todos", `{"title": "Mine"}`)
+	if w := s.do("PUT", "/todos/3", `{"project_id": 1}`); w.Code != http.StatusBadRequest {
+		t.Errorf("got %d moving a todo to another user's project, want 400", w.Code)
+	}
+}
+
+func TestTodoPriority(t *testing.T) {
+	s := newTestServer(t)
+	tests := []struct {
+		method, path, body string
+		wantStatus         int
+		wantPriority       string
+	}{
+		{method: "POST", path: "/todos", body: `{"title": "Buy milk"}`, wantStatus: http.StatusCreated, wantPriority: "MEDIUM"},
+		{method: "POST", path: "/todos", body: `{"title": "Call mum", "priority": "high"}`, wantStatus: http.StatusCreated, wantPriority: "HIGH"},
+		{method: "PUT", path: "/todos/1", body: `{"priority": "Urgent"}`, wantStatus: http.StatusOK, wantPriority: "URGENT"},
+		{method: "PUT", path: "/todos/1", body: `{"priority": "LOW"}`, wantStatus: http.StatusOK, wantPriority: "LOW"},
+		{method: "POST", path: "/todos", body: `{"title": "Water plants", "priority": "whenever"}`, wantStatus: http.StatusBadRequest},
+		{method: "PUT", path: "/todos/1", body: `{"priority": "soon"}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		w := s.do(tt.method, tt.path, tt.body)
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s %s %s: got %d %s, want %d", tt.method, tt.path, tt.body, w.Code, w.Body, tt.wantStatus)
+			continue
+		}
+		var todo TodoResponse
+		json.Unmarshal(w.Body.Bytes(), &todo)
+		if tt.wantPriority != "" && todo.Priority != tt.wantPriority {
+			t.Errorf("%s %s %s: got priority %q, want %q", tt.method, tt.path, tt.body, todo.Priority, tt.wantPriority)
+		}
+	}
+	var todo TodoResponse
+	json.Unmarshal(s.do("GET", "/todos/1", "").Body.Bytes(), &todo)
+	if todo.Priority != "LOW" {
+		t.Errorf("got priority %q after invalid updates, want LOW", todo.Priority)
+	}
+}
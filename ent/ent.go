@@ -12,7 +12,14 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/apikey"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/attachment"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/comment"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/project"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/tag"
 	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todorevision"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/user"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -73,7 +80,14 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			todo.Table: todo.ValidColumn,
+			apikey.Table:       apikey.ValidColumn,
+			attachment.Table:   attachment.ValidColumn,
+			comment.Table:      comment.ValidColumn,
+			project.Table:      project.ValidColumn,
+			tag.Table:          tag.ValidColumn,
+			todo.Table:         todo.ValidColumn,
+			todorevision.Table: todorevision.ValidColumn,
+			user.Table:         user.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
@@ -2,35 +2,4 @@
 
 package ent
 
-import (
-	"time"
-
-	"github.com/ishidatakuo/graphql-ent-atlas/ent/schema"
-	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
-)
-
-// The init function reads all schema descriptors with runtime code
-// (default values, validators, hooks and policies) and stitches it
-// to their package variables.
-func init() {
-	todoFields := schema.Todo{}.Fields()
-	_ = todoFields
-	// todoDescTitle is the schema descriptor for title field.
-	todoDescTitle := todoFields[0].Descriptor()
-	// todo.TitleValidator is a validator for the "title" field. It is called by the builders before save.
-	todo.TitleValidator = todoDescTitle.Validators[0].(func(string) error)
-	// todoDescCompleted is the schema descriptor for completed field.
-	todoDescCompleted := todoFields[2].Descriptor()
-	// todo.DefaultCompleted holds the default value on creation for the completed field.
-	todo.DefaultCompleted = todoDescCompleted.Default.(bool)
-	// todoDescCreatedAt is the schema descriptor for createdAt field.
-	todoDescCreatedAt := todoFields[3].Descriptor()
-	// todo.DefaultCreatedAt holds the default value on creation for the createdAt field.
-	todo.DefaultCreatedAt = todoDescCreatedAt.Default.(func() time.Time)
-	// todoDescUpdatedAt is the schema descriptor for updatedAt field.
-	todoDescUpdatedAt := todoFields[4].Descriptor()
-	// todo.DefaultUpdatedAt holds the default value on creation for the updatedAt field.
-	todo.DefaultUpdatedAt = todoDescUpdatedAt.Default.(func() time.Time)
-	// todo.UpdateDefaultUpdatedAt holds the default value on update for the updatedAt field.
-	todo.UpdateDefaultUpdatedAt = todoDescUpdatedAt.UpdateDefault.(func() time.Time)
-}
+// The schema-stitching logic is generated in github.com/ishidatakuo/graphql-ent-atlas/ent/runtime/runtime.go
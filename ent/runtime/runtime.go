@@ -2,7 +2,199 @@
 
 package runtime
 
-// The schema-stitching logic is generated in github.com/ishidatakuo/graphql-ent-atlas/ent/runtime.go
+import (
+	"time"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/apikey"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/attachment"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/comment"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/project"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/schema"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/tag"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todorevision"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/user"
+)
+
+// The init function reads all schema descriptors with runtime code
+// (default values, validators, hooks and policies) and stitches it
+// to their package variables.
+func init() {
+	apikeyFields := schema.APIKey{}.Fields()
+	_ = apikeyFields
+	// apikeyDescName is the schema descriptor for name field.
+	apikeyDescName := apikeyFields[0].Descriptor()
+	// apikey.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	apikey.NameValidator = apikeyDescName.Validators[0].(func(string) error)
+	// apikeyDescPrefix is the schema descriptor for prefix field.
+	apikeyDescPrefix := apikeyFields[1].Descriptor()
+	// apikey.PrefixValidator is a validator for the "prefix" field. It is called by the builders before save.
+	apikey.PrefixValidator = apikeyDescPrefix.Validators[0].(func(string) error)
+	// apikeyDescSecretHash is the schema descriptor for secretHash field.
+	apikeyDescSecretHash := apikeyFields[2].Descriptor()
+	// apikey.SecretHashValidator is a validator for the "secretHash" field. It is called by the builders before save.
+	apikey.SecretHashValidator = apikeyDescSecretHash.Validators[0].(func(string) error)
+	// apikeyDescCreatedAt is the schema descriptor for createdAt field.
+	apikeyDescCreatedAt := apikeyFields[7].Descriptor()
+	// apikey.DefaultCreatedAt holds the default value on creation for the createdAt field.
+	apikey.DefaultCreatedAt = apikeyDescCreatedAt.Default.(func() time.Time)
+	attachmentFields := schema.Attachment{}.Fields()
+	_ = attachmentFields
+	// attachmentDescFilename is the schema descriptor for filename field.
+	attachmentDescFilename := attachmentFields[1].Descriptor()
+	// attachment.FilenameValidator is a validator for the "filename" field. It is called by the builders before save.
+	attachment.FilenameValidator = func() func(string) error {
+		validators := attachmentDescFilename.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(filename string) error {
+			for _, fn := range fns {
+				if err := fn(filename); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// attachmentDescSize is the schema descriptor for size field.
+	attachmentDescSize := attachmentFields[3].Descriptor()
+	// attachment.SizeValidator is a validator for the "size" field. It is called by the builders before save.
+	attachment.SizeValidator = attachmentDescSize.Validators[0].(func(int64) error)
+	// attachmentDescCreatedAt is the schema descriptor for createdAt field.
+	attachmentDescCreatedAt := attachmentFields[6].Descriptor()
+	// attachment.DefaultCreatedAt holds the default value on creation for the createdAt field.
+	attachment.DefaultCreatedAt = attachmentDescCreatedAt.Default.(func() time.Time)
+	commentFields := schema.Comment{}.Fields()
+	_ = commentFields
+	// commentDescBody is the schema descriptor for body field.
+	commentDescBody := commentFields[2].Descriptor()
+	// comment.BodyValidator is a validator for the "body" field. It is called by the builders before save.
+	comment.BodyValidator = func() func(string) error {
+		validators := commentDescBody.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(body string) error {
+			for _, fn := range fns {
+				if err := fn(body); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// commentDescCreatedAt is the schema descriptor for createdAt field.
+	commentDescCreatedAt := commentFields[3].Descriptor()
+	// comment.DefaultCreatedAt holds the default value on creation for the createdAt field.
+	comment.DefaultCreatedAt = commentDescCreatedAt.Default.(func() time.Time)
+	projectFields := schema.Project{}.Fields()
+	_ = projectFields
+	// projectDescName is the schema descriptor for name field.
+	projectDescName := projectFields[0].Descriptor()
+	// project.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	project.NameValidator = projectDescName.Validators[0].(func(string) error)
+	// projectDescColor is the schema descriptor for color field.
+	projectDescColor := projectFields[1].Descriptor()
+	// project.ColorValidator is a validator for the "color" field. It is called by the builders before save.
+	project.ColorValidator = projectDescColor.Validators[0].(func(string) error)
+	// projectDescArchived is the schema descriptor for archived field.
+	projectDescArchived := projectFields[2].Descriptor()
+	// project.DefaultArchived holds the default value on creation for the archived field.
+	project.DefaultArchived = projectDescArchived.Default.(bool)
+	// projectDescPosition is the schema descriptor for position field.
+	projectDescPosition := projectFields[3].Descriptor()
+	// project.DefaultPosition holds the default value on creation for the position field.
+	project.DefaultPosition = projectDescPosition.Default.(int)
+	// projectDescCreatedAt is the schema descriptor for createdAt field.
+	projectDescCreatedAt := projectFields[4].Descriptor()
+	// project.DefaultCreatedAt holds the default value on creation for the createdAt field.
+	project.DefaultCreatedAt = projectDescCreatedAt.Default.(func() time.Time)
+	// projectDescUpdatedAt is the schema descriptor for updatedAt field.
+	projectDescUpdatedAt := projectFields[5].Descriptor()
+	// project.DefaultUpdatedAt holds the default value on creation for the updatedAt field.
+	project.DefaultUpdatedAt = projectDescUpdatedAt.Default.(func() time.Time)
+	// project.UpdateDefaultUpdatedAt holds the default value on update for the updatedAt field.
+	project.UpdateDefaultUpdatedAt = projectDescUpdatedAt.UpdateDefault.(func() time.Time)
+	tagFields := schema.Tag{}.Fields()
+	_ = tagFields
+	// tagDescName is the schema descriptor for name field.
+	tagDescName := tagFields[0].Descriptor()
+	// tag.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	tag.NameValidator = tagDescName.Validators[0].(func(string) error)
+	// tagDescCreatedAt is the schema descriptor for createdAt field.
+	tagDescCreatedAt := tagFields[1].Descriptor()
+	// tag.DefaultCreatedAt holds the default value on creation for the createdAt field.
+	tag.DefaultCreatedAt = tagDescCreatedAt.Default.(func() time.Time)
+	todoHooks := schema.Todo{}.Hooks()
+	todo.Hooks[0] = todoHooks[0]
+	todo.Hooks[1] = todoHooks[1]
+	todo.Hooks[2] = todoHooks[2]
+	todo.Hooks[3] = todoHooks[3]
+	todo.Hooks[4] = todoHooks[4]
+	todoInters := schema.Todo{}.Interceptors()
+	todo.Interceptors[0] = todoInters[0]
+	todoFields := schema.Todo{}.Fields()
+	_ = todoFields
+	// todoDescTitle is the schema descriptor for title field.
+	todoDescTitle := todoFields[0].Descriptor()
+	// todo.TitleValidator is a validator for the "title" field. It is called by the builders before save.
+	todo.TitleValidator = todoDescTitle.Validators[0].(func(string) error)
+	// todoDescCompleted is the schema descriptor for completed field.
+	todoDescCompleted := todoFields[2].Descriptor()
+	// todo.DefaultCompleted holds the default value on creation for the completed field.
+	todo.DefaultCompleted = todoDescCompleted.Default.(bool)
+	// todoDescPosition is the schema descriptor for position field.
+	todoDescPosition := todoFields[4].Descriptor()
+	// todo.PositionValidator is a validator for the "position" field. It is called by the builders before save.
+	todo.PositionValidator = todoDescPosition.Validators[0].(func(string) error)
+	// todoDescCreatedAt is the schema descriptor for createdAt field.
+	todoDescCreatedAt := todoFields[5].Descriptor()
+	// todo.DefaultCreatedAt holds the default value on creation for the createdAt field.
+	todo.DefaultCreatedAt = todoDescCreatedAt.Default.(func() time.Time)
+	// todoDescUpdatedAt is the schema descriptor for updatedAt field.
+	todoDescUpdatedAt := todoFields[6].Descriptor()
+	// todo.DefaultUpdatedAt holds the default value on creation for the updatedAt field.
+	todo.DefaultUpdatedAt = todoDescUpdatedAt.Default.(func() time.Time)
+	// todo.UpdateDefaultUpdatedAt holds the default value on update for the updatedAt field.
+	todo.UpdateDefaultUpdatedAt = todoDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// todoDescRecurrence is the schema descriptor for recurrence field.
+	todoDescRecurrence := todoFields[13].Descriptor()
+	// todo.RecurrenceValidator is a validator for the "recurrence" field. It is called by the builders before save.
+	todo.RecurrenceValidator = todoDescRecurrence.Validators[0].(func(string) error)
+	// todoDescVersion is the schema descriptor for version field.
+	todoDescVersion := todoFields[16].Descriptor()
+	// todo.DefaultVersion holds the default value on creation for the version field.
+	todo.DefaultVersion = todoDescVersion.Default.(int)
+	todorevisionFields := schema.TodoRevision{}.Fields()
+	_ = todorevisionFields
+	// todorevisionDescCreatedAt is the schema descriptor for createdAt field.
+	todorevisionDescCreatedAt := todorevisionFields[6].Descriptor()
+	// todorevision.DefaultCreatedAt holds the default value on creation for the createdAt field.
+	todorevision.DefaultCreatedAt = todorevisionDescCreatedAt.Default.(func() time.Time)
+	userFields := schema.User{}.Fields()
+	_ = userFields
+	// userDescEmail is the schema descriptor for email field.
+	userDescEmail := userFields[0].Descriptor()
+	// user.EmailValidator is a validator for the "email" field. It is called by the builders before save.
+	user.EmailValidator = userDescEmail.Validators[0].(func(string) error)
+	// userDescDisplayName is the schema descriptor for displayName field.
+	userDescDisplayName := userFields[1].Descriptor()
+	// user.DisplayNameValidator is a validator for the "displayName" field. It is called by the builders before save.
+	user.DisplayNameValidator = userDescDisplayName.Validators[0].(func(string) error)
+	// userDescCreatedAt is the schema descriptor for createdAt field.
+	userDescCreatedAt := userFields[4].Descriptor()
+	// user.DefaultCreatedAt holds the default value on creation for the createdAt field.
+	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
+	// userDescUpdatedAt is the schema descriptor for updatedAt field.
+	userDescUpdatedAt := userFields[5].Descriptor()
+	// user.DefaultUpdatedAt holds the default value on creation for the updatedAt field.
+	user.DefaultUpdatedAt = userDescUpdatedAt.Default.(func() time.Time)
+	// user.UpdateDefaultUpdatedAt holds the default value on update for the updatedAt field.
+	user.UpdateDefaultUpdatedAt = userDescUpdatedAt.UpdateDefault.(func() time.Time)
+}
 
 const (
 	Version = "v0.14.5"                                         // Version of ent codegen.
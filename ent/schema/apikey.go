@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// APIKey holds the schema definition for the APIKey entity.
+type APIKey struct {
+	ent.Schema
+}
+
+// Fields of the APIKey.
+func (APIKey) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty().
+			Comment("Name describing what the key is used for"),
+		field.String("prefix").
+			NotEmpty().
+			Unique().
+			Immutable().
+			Comment("Public part of the key, used to look it up"),
+		field.String("secretHash").
+			NotEmpty().
+			Sensitive().
+			Immutable().
+			Comment("SHA-256 hash of the key"),
+		field.Strings("scopes").
+			Comment("Scopes granted to the key"),
+		field.Time("lastUsedAt").
+			Optional().
+			Nillable().
+			Comment("When the key last authenticated a request"),
+		field.Time("expiresAt").
+			Optional().
+			Nillable().
+			Comment("When the key expires, if ever"),
+		field.Time("revokedAt").
+			Optional().
+			Nillable().
+			Comment("When the key was revoked"),
+		field.Time("createdAt").
+			Default(time.Now).
+			Immutable().
+			Comment("When the key was created"),
+		field.Int("ownerID").
+			Comment("ID of the user the key authenticates as"),
+	}
+}
+
+// Edges of the APIKey.
+func (APIKey) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("owner", User.Type).
+			Ref("apiKeys").
+			Field("ownerID").
+			Unique().
+			Required().
+			Comment("User the key authenticates as"),
+	}
+}
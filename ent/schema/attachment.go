@@ -0,0 +1,67 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Attachment holds the schema definition for the Attachment entity.
+type Attachment struct {
+	ent.Schema
+}
+
+// Fields of the Attachment.
+func (Attachment) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("todoID").
+			Immutable().
+			Comment("ID of the todo the file is attached to"),
+		field.String("filename").
+			NotEmpty().
+			MaxLen(255).
+			Immutable().
+			Comment("Name of the file, as uploaded"),
+		field.String("contentType").
+			Immutable().
+			Comment("Media type of the file"),
+		field.Int64("size").
+			NonNegative().
+			Immutable().
+			Comment("Size of the file in bytes"),
+		field.String("checksum").
+			Immutable().
+			Comment("Hex-encoded SHA-256 hash of the file"),
+		field.String("storageKey").
+			Unique().
+			Immutable().
+			Comment("Key of the file in the blob store"),
+		field.Time("createdAt").
+			Default(time.Now).
+			Immutable().
+			Comment("When the file was attached"),
+	}
+}
+
+// Edges of the Attachment.
+func (Attachment) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("todo", Todo.Type).
+			Ref("attachments").
+			Field("todoID").
+			Unique().
+			Required().
+			Immutable().
+			Comment("Todo the file is attached to"),
+	}
+}
+
+// Indexes of the Attachment.
+func (Attachment) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("todoID"),
+	}
+}
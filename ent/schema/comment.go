@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Comment holds the schema definition for the Comment entity.
+type Comment struct {
+	ent.Schema
+}
+
+// Fields of the Comment.
+func (Comment) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("todoID").
+			Immutable().
+			Comment("ID of the todo the comment is on"),
+		field.Int("authorID").
+			Immutable().
+			Comment("ID of the user who wrote the comment"),
+		field.Text("body").
+			NotEmpty().
+			MaxLen(10000).
+			Comment("Content of the comment, in Markdown"),
+		field.Time("createdAt").
+			Default(time.Now).
+			Immutable().
+			Comment("When the comment was written"),
+		field.Time("editedAt").
+			Optional().
+			Nillable().
+			Comment("When the comment was last edited, unset if it never was"),
+	}
+}
+
+// Edges of the Comment.
+func (Comment) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("todo", Todo.Type).
+			Ref("comments").
+			Field("todoID").
+			Unique().
+			Required().
+			Immutable().
+			Comment("Todo the comment is on"),
+		edge.From("author", User.Type).
+			Ref("comments").
+			Field("authorID").
+			Unique().
+			Required().
+			Immutable().
+			Comment("User who wrote the comment"),
+	}
+}
+
+// Indexes of the Comment.
+func (Comment) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("todoID"),
+	}
+}
@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"regexp"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Project holds the schema definition for the Project entity.
+type Project struct {
+	ent.Schema
+}
+
+// Fields of the Project.
+func (Project) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty().
+			Comment("Project name"),
+		field.String("color").
+			Optional().
+			Match(regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)).
+			Comment("Color shown for the project, as #RRGGBB"),
+		field.Bool("archived").
+			Default(false).
+			Comment("Whether the project is archived"),
+		field.Int("position").
+			Default(0).
+			Comment("Sort position of the project among its owner's projects"),
+		field.Time("createdAt").
+			Default(time.Now).
+			Comment("When the project was created"),
+		field.Time("updatedAt").
+			Default(time.Now).
+			UpdateDefault(time.Now).
+			Comment("When the project was last updated"),
+		field.Int("ownerID").
+			Comment("ID of the user owning the project"),
+	}
+}
+
+// Edges of the Project.
+func (Project) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("owner", User.Type).
+			Ref("projects").
+			Field("ownerID").
+			Unique().
+			Required().
+			Comment("User owning the project"),
+		edge.To("todos", Todo.Type).
+			Comment("Todos in the project"),
+	}
+}
+
+// Indexes of the Project.
+func (Project) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("ownerID", "position"),
+	}
+}
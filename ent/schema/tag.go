@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Tag holds the schema definition for the Tag entity.
+type Tag struct {
+	ent.Schema
+}
+
+// Fields of the Tag.
+func (Tag) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty().
+			Comment("Tag name, unique per owner"),
+		field.Time("createdAt").
+			Default(time.Now).
+			Immutable().
+			Comment("When the tag was created"),
+		field.Int("ownerID").
+			Comment("ID of the user owning the tag"),
+	}
+}
+
+// Edges of the Tag.
+func (Tag) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("owner", User.Type).
+			Ref("tags").
+			Field("ownerID").
+			Unique().
+			Required().
+			Comment("User owning the tag"),
+		edge.To("todos", Todo.Type).
+			Comment("Todos labelled with the tag"),
+	}
+}
+
+// Indexes of the Tag.
+func (Tag) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("ownerID", "name").
+			Unique(),
+	}
+}
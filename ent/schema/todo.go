@@ -1,10 +1,24 @@
 package schema
 
 import (
+	"context"
+	"slices"
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+
+	gen "github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/hook"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/audit"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/position"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/recurrence"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/softdelete"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/subtask"
 )
 
 // Todo holds the schema definition for the Todo entity.
@@ -24,6 +38,16 @@ func (Todo) Fields() []ent.Field {
 		field.Bool("completed").
 			Default(false).
 			Comment("Whether the todo is completed"),
+		field.Enum("priority").
+			Values("LOW", "MEDIUM", "HIGH", "URGENT").
+			Default("MEDIUM").
+			Comment("Priority of the todo"),
+		// No Go default: the create hook appends todos created without a
+		// position to the end of their owner's list.
+		field.String("position").
+			NotEmpty().
+			Annotations(entsql.Default(position.First)).
+			Comment("Fractional-index key ordering the todos of an owner"),
 		field.Time("createdAt").
 			Default(time.Now).
 			Comment("When the todo was created"),
@@ -31,10 +55,183 @@ func (Todo) Fields() []ent.Field {
 			Default(time.Now).
 			UpdateDefault(time.Now).
 			Comment("When the todo was last updated"),
+		field.Time("dueAt").
+			Optional().
+			Nillable().
+			Comment("When the todo is due"),
+		field.Time("remindAt").
+			Optional().
+			Nillable().
+			Comment("When to remind the owner of the todo"),
+		field.Time("reminderSentAt").
+			Optional().
+			Nillable().
+			Comment("When the reminder for remindAt was sent"),
+		field.Int("ownerID").
+			Optional().
+			Comment("ID of the user owning the todo"),
+		field.Int("projectID").
+			Optional().
+			Comment("ID of the project the todo belongs to"),
+		field.Int("parentID").
+			Optional().
+			Comment("ID of the todo this todo is a subtask of"),
+		field.String("recurrence").
+			Optional().
+			Validate(recurrence.Validate).
+			Comment("RFC 5545 recurrence rule (RRULE) of the todo"),
+		field.Int("previousOccurrenceID").
+			Optional().
+			Comment("ID of the occurrence of the recurring todo this todo follows"),
+		field.Time("deletedAt").
+			Optional().
+			Nillable().
+			Comment("When the todo was moved to the trash"),
+		field.Int("version").
+			Default(1).
+			Comment("Incremented on every update, for optimistic concurrency control"),
 	}
 }
 
 // Edges of the Todo.
 func (Todo) Edges() []ent.Edge {
-	return nil
+	return []ent.Edge{
+		edge.From("owner", User.Type).
+			Ref("todos").
+			Field("ownerID").
+			Unique().
+			Comment("User owning the todo"),
+		edge.From("project", Project.Type).
+			Ref("todos").
+			Field("projectID").
+			Unique().
+			Comment("Project the todo belongs to"),
+		edge.From("tags", Tag.Type).
+			Ref("todos").
+			Comment("Tags the todo is labelled with"),
+		edge.To("children", Todo.Type).
+			From("parent").
+			Field("parentID").
+			Unique().
+			Comment("Subtasks of the todo, and the todo they are a subtask of"),
+		edge.To("blocks", Todo.Type).
+			StorageKey(edge.Table("todo_dependencies"), edge.Columns("blocker_id", "blocked_id")).
+			Comment("Todos that cannot start before the todo is done"),
+		edge.From("blockedBy", Todo.Type).
+			Ref("blocks").
+			Comment("Todos that must be done before the todo can start"),
+		edge.To("nextOccurrence", Todo.Type).
+			Unique().
+			From("previousOccurrence").
+			Field("previousOccurrenceID").
+			Unique().
+			Comment("Next occurrence of the recurring todo, created when it is completed, and the occurrence it follows"),
+		edge.To("revisions", TodoRevision.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)).
+			Comment("Changes made to the todo"),
+		edge.To("attachments", Attachment.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)).
+			Comment("Files attached to the todo"),
+		edge.To("comments", Comment.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)).
+			Comment("Discussion of the todo"),
+	}
+}
+
+// Indexes of the Todo.
+func (Todo) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("ownerID"),
+		index.Fields("projectID"),
+		index.Fields("dueAt"),
+		index.Fields("remindAt"),
+		index.Fields("ownerID", "position"),
+		index.Fields("parentID"),
+		index.Fields("deletedAt"),
+	}
+}
+
+// Hooks of the Todo.
+func (Todo) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(appendPosition, ent.OpCreate),
+		hook.On(incrementVersion, ent.OpUpdate|ent.OpUpdateOne),
+		hook.On(subtask.PreventCycles, ent.OpUpdate|ent.OpUpdateOne),
+		hook.On(softdelete.Hook, ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne),
+		hook.On(audit.Hook, ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne),
+	}
+}
+
+// Interceptors of the Todo.
+func (Todo) Interceptors() []ent.Interceptor {
+	return []ent.Interceptor{
+		softdelete.Interceptor(),
+	}
+}
+
+// appendPosition places a todo created without a position after the last
+// todo of its owner.
+func appendPosition(next ent.Mutator) ent.Mutator {
+	return hook.TodoFunc(func(ctx context.Context, m *gen.TodoMutation) (gen.Value, error) {
+		if _, ok := m.Position(); ok {
+			return next.Mutate(ctx, m)
+		}
+
+		q := m.Client().Todo.Query()
+		if ownerID, ok := m.OwnerID(); ok {
+			q = q.Where(todo.OwnerID(ownerID))
+		} else {
+			q = q.Where(todo.OwnerIDIsNil())
+		}
+		var last string
+		t, err := q.Order(gen.Desc(todo.FieldPosition)).First(ctx)
+		switch {
+		case err == nil:
+			last = t.Position
+		case !gen.IsNotFound(err):
+			return nil, err
+		}
+
+		pos, err := position.After(last)
+		if err != nil {
+			return nil, err
+		}
+		m.SetPosition(pos)
+		return next.Mutate(ctx, m)
+	})
+}
+
+// bookkeepingFields are the todo fields whose updates do not modify the
+// todo: the claim of its reminder by the reminder scheduler, its position,
+// which only orders it among the other todos, and updatedAt, which every
+// update sets.
+var bookkeepingFields = []string{
+	todo.FieldReminderSentAt,
+	todo.FieldPosition,
+	todo.FieldUpdatedAt,
+}
+
+// incrementVersion increments the version of the updated todos, so that
+// updates made against an older version can be detected. Updates of the
+// bookkeeping fields alone keep the version.
+func incrementVersion(next ent.Mutator) ent.Mutator {
+	return hook.TodoFunc(func(ctx context.Context, m *gen.TodoMutation) (gen.Value, error) {
+		if modifiesTodo(m) {
+			m.AddVersion(1)
+		}
+		return next.Mutate(ctx, m)
+	})
+}
+
+// modifiesTodo reports whether m changes more than the bookkeeping fields of
+// the todos.
+func modifiesTodo(m *gen.TodoMutation) bool {
+	fields := append(m.Fields(), m.AddedFields()...)
+	fields = append(fields, m.ClearedFields()...)
+	for _, f := range fields {
+		if !slices.Contains(bookkeepingFields, f) {
+			return true
+		}
+	}
+	return len(m.AddedEdges()) > 0 || len(m.RemovedEdges()) > 0 || len(m.ClearedEdges()) > 0
 }
@@ -0,0 +1,73 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// TodoRevision holds the schema definition for the TodoRevision entity.
+type TodoRevision struct {
+	ent.Schema
+}
+
+// Fields of the TodoRevision.
+func (TodoRevision) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("todoID").
+			Immutable().
+			Comment("ID of the changed todo"),
+		field.Int("actorID").
+			Optional().
+			Immutable().
+			Comment("ID of the user who made the change, unset for changes made by the server"),
+		field.Enum("operation").
+			Values("CREATE", "UPDATE", "DELETE", "RESTORE").
+			Immutable().
+			Comment("Kind of change"),
+		field.Strings("fields").
+			Immutable().
+			Comment("Names of the fields the change set"),
+		field.JSON("oldValues", map[string]any{}).
+			Optional().
+			Immutable().
+			Comment("Values of the changed fields before the change"),
+		field.JSON("newValues", map[string]any{}).
+			Optional().
+			Immutable().
+			Comment("Values of the changed fields after the change"),
+		field.Time("createdAt").
+			Default(time.Now).
+			Immutable().
+			Comment("When the change was made"),
+	}
+}
+
+// Edges of the TodoRevision.
+func (TodoRevision) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("todo", Todo.Type).
+			Ref("revisions").
+			Field("todoID").
+			Unique().
+			Required().
+			Immutable().
+			Comment("Changed todo"),
+		edge.From("actor", User.Type).
+			Ref("todoRevisions").
+			Field("actorID").
+			Unique().
+			Immutable().
+			Comment("User who made the change"),
+	}
+}
+
+// Indexes of the TodoRevision.
+func (TodoRevision) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("todoID"),
+	}
+}
@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// User holds the schema definition for the User entity.
+type User struct {
+	ent.Schema
+}
+
+// Fields of the User.
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("email").
+			NotEmpty().
+			Unique().
+			Comment("Email address, used to log in"),
+		field.String("displayName").
+			NotEmpty().
+			Comment("Name shown for the user"),
+		field.String("passwordHash").
+			Optional().
+			Sensitive().
+			Comment("bcrypt hash of the password, empty for users without password login"),
+		field.String("subject").
+			Optional().
+			Nillable().
+			Unique().
+			Comment("Subject identifier at an external identity provider"),
+		field.Time("createdAt").
+			Default(time.Now).
+			Comment("When the user was created"),
+		field.Time("updatedAt").
+			Default(time.Now).
+			UpdateDefault(time.Now).
+			Comment("When the user was last updated"),
+	}
+}
+
+// Edges of the User.
+func (User) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("todos", Todo.Type).
+			Comment("Todos owned by the user"),
+		edge.To("apiKeys", APIKey.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)).
+			Comment("API keys of the user"),
+		edge.To("projects", Project.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)).
+			Comment("Projects owned by the user"),
+		edge.To("tags", Tag.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)).
+			Comment("Tags owned by the user"),
+		edge.To("todoRevisions", TodoRevision.Type).
+			Comment("Changes the user made to todos"),
+		edge.To("comments", Comment.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)).
+			Comment("Comments the user wrote on todos"),
+	}
+}
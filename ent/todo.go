@@ -9,7 +9,9 @@ import (
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/project"
 	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/user"
 )
 
 // Todo is the model entity for the Todo schema.
@@ -23,13 +25,189 @@ type Todo struct {
 	Description string `json:"description,omitempty"`
 	// Whether the todo is completed
 	Completed bool `json:"completed,omitempty"`
+	// Priority of the todo
+	Priority todo.Priority `json:"priority,omitempty"`
+	// Fractional-index key ordering the todos of an owner
+	Position string `json:"position,omitempty"`
 	// When the todo was created
 	CreatedAt time.Time `json:"createdAt,omitempty"`
 	// When the todo was last updated
-	UpdatedAt    time.Time `json:"updatedAt,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+	// When the todo is due
+	DueAt *time.Time `json:"dueAt,omitempty"`
+	// When to remind the owner of the todo
+	RemindAt *time.Time `json:"remindAt,omitempty"`
+	// When the reminder for remindAt was sent
+	ReminderSentAt *time.Time `json:"reminderSentAt,omitempty"`
+	// ID of the user owning the todo
+	OwnerID int `json:"ownerID,omitempty"`
+	// ID of the project the todo belongs to
+	ProjectID int `json:"projectID,omitempty"`
+	// ID of the todo this todo is a subtask of
+	ParentID int `json:"parentID,omitempty"`
+	// RFC 5545 recurrence rule (RRULE) of the todo
+	Recurrence string `json:"recurrence,omitempty"`
+	// ID of the occurrence of the recurring todo this todo follows
+	PreviousOccurrenceID int `json:"previousOccurrenceID,omitempty"`
+	// When the todo was moved to the trash
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	// Incremented on every update, for optimistic concurrency control
+	Version int `json:"version,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the TodoQuery when eager-loading is set.
+	Edges        TodoEdges `json:"edges"`
 	selectValues sql.SelectValues
 }
 
+// TodoEdges holds the relations/edges for other nodes in the graph.
+type TodoEdges struct {
+	// User owning the todo
+	Owner *User `json:"owner,omitempty"`
+	// Project the todo belongs to
+	Project *Project `json:"project,omitempty"`
+	// Tags the todo is labelled with
+	Tags []*Tag `json:"tags,omitempty"`
+	// Subtasks of the todo, and the todo they are a subtask of
+	Parent *Todo `json:"parent,omitempty"`
+	// Children holds the value of the children edge.
+	Children []*Todo `json:"children,omitempty"`
+	// Todos that cannot start before the todo is done
+	Blocks []*Todo `json:"blocks,omitempty"`
+	// Todos that must be done before the todo can start
+	BlockedBy []*Todo `json:"blockedBy,omitempty"`
+	// Next occurrence of the recurring todo, created when it is completed, and the occurrence it follows
+	PreviousOccurrence *Todo `json:"previousOccurrence,omitempty"`
+	// NextOccurrence holds the value of the nextOccurrence edge.
+	NextOccurrence *Todo `json:"nextOccurrence,omitempty"`
+	// Changes made to the todo
+	Revisions []*TodoRevision `json:"revisions,omitempty"`
+	// Files attached to the todo
+	Attachments []*Attachment `json:"attachments,omitempty"`
+	// Discussion of the todo
+	Comments []*Comment `json:"comments,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [12]bool
+}
+
+// OwnerOrErr returns the Owner value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e TodoEdges) OwnerOrErr() (*User, error) {
+	if e.Owner != nil {
+		return e.Owner, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "owner"}
+}
+
+// ProjectOrErr returns the Project value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e TodoEdges) ProjectOrErr() (*Project, error) {
+	if e.Project != nil {
+		return e.Project, nil
+	} else if e.loadedTypes[1] {
+		return nil, &NotFoundError{label: project.Label}
+	}
+	return nil, &NotLoadedError{edge: "project"}
+}
+
+// TagsOrErr returns the Tags value or an error if the edge
+// was not loaded in eager-loading.
+func (e TodoEdges) TagsOrErr() ([]*Tag, error) {
+	if e.loadedTypes[2] {
+		return e.Tags, nil
+	}
+	return nil, &NotLoadedError{edge: "tags"}
+}
+
+// ParentOrErr returns the Parent value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e TodoEdges) ParentOrErr() (*Todo, error) {
+	if e.Parent != nil {
+		return e.Parent, nil
+	} else if e.loadedTypes[3] {
+		return nil, &NotFoundError{label: todo.Label}
+	}
+	return nil, &NotLoadedError{edge: "parent"}
+}
+
+// ChildrenOrErr returns the Children value or an error if the edge
+// was not loaded in eager-loading.
+func (e TodoEdges) ChildrenOrErr() ([]*Todo, error) {
+	if e.loadedTypes[4] {
+		return e.Children, nil
+	}
+	return nil, &NotLoadedError{edge: "children"}
+}
+
+// BlocksOrErr returns the Blocks value or an error if the edge
+// was not loaded in eager-loading.
+func (e TodoEdges) BlocksOrErr() ([]*Todo, error) {
+	if e.loadedTypes[5] {
+		return e.Blocks, nil
+	}
+	return nil, &NotLoadedError{edge: "blocks"}
+}
+
+// BlockedByOrErr returns the BlockedBy value or an error if the edge
+// was not loaded in eager-loading.
+func (e TodoEdges) BlockedByOrErr() ([]*Todo, error) {
+	if e.loadedTypes[6] {
+		return e.BlockedBy, nil
+	}
+	return nil, &NotLoadedError{edge: "blockedBy"}
+}
+
+// PreviousOccurrenceOrErr returns the PreviousOccurrence value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e TodoEdges) PreviousOccurrenceOrErr() (*Todo, error) {
+	if e.PreviousOccurrence != nil {
+		return e.PreviousOccurrence, nil
+	} else if e.loadedTypes[7] {
+		return nil, &NotFoundError{label: todo.Label}
+	}
+	return nil, &NotLoadedError{edge: "previousOccurrence"}
+}
+
+// NextOccurrenceOrErr returns the NextOccurrence value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e TodoEdges) NextOccurrenceOrErr() (*Todo, error) {
+	if e.NextOccurrence != nil {
+		return e.NextOccurrence, nil
+	} else if e.loadedTypes[8] {
+		return nil, &NotFoundError{label: todo.Label}
+	}
+	return nil, &NotLoadedError{edge: "nextOccurrence"}
+}
+
+// RevisionsOrErr returns the Revisions value or an error if the edge
+// was not loaded in eager-loading.
+func (e TodoEdges) RevisionsOrErr() ([]*TodoRevision, error) {
+	if e.loadedTypes[9] {
+		return e.Revisions, nil
+	}
+	return nil, &NotLoadedError{edge: "revisions"}
+}
+
+// AttachmentsOrErr returns the Attachments value or an error if the edge
+// was not loaded in eager-loading.
+func (e TodoEdges) AttachmentsOrErr() ([]*Attachment, error) {
+	if e.loadedTypes[10] {
+		return e.Attachments, nil
+	}
+	return nil, &NotLoadedError{edge: "attachments"}
+}
+
+// CommentsOrErr returns the Comments value or an error if the edge
+// was not loaded in eager-loading.
+func (e TodoEdges) CommentsOrErr() ([]*Comment, error) {
+	if e.loadedTypes[11] {
+		return e.Comments, nil
+	}
+	return nil, &NotLoadedError{edge: "comments"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Todo) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
@@ -37,11 +215,11 @@ func (*Todo) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case todo.FieldCompleted:
 			values[i] = new(sql.NullBool)
-		case todo.FieldID:
+		case todo.FieldID, todo.FieldOwnerID, todo.FieldProjectID, todo.FieldParentID, todo.FieldPreviousOccurrenceID, todo.FieldVersion:
 			values[i] = new(sql.NullInt64)
-		case todo.FieldTitle, todo.FieldDescription:
+		case todo.FieldTitle, todo.FieldDescription, todo.FieldPriority, todo.FieldPosition, todo.FieldRecurrence:
 			values[i] = new(sql.NullString)
-		case todo.FieldCreatedAt, todo.FieldUpdatedAt:
+		case todo.FieldCreatedAt, todo.FieldUpdatedAt, todo.FieldDueAt, todo.FieldRemindAt, todo.FieldReminderSentAt, todo.FieldDeletedAt:
 			values[i] = new(sql.NullTime)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -82,6 +260,18 @@ func (_m *Todo) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Completed = value.Bool
 			}
+		case todo.FieldPriority:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field priority", values[i])
+			} else if value.Valid {
+				_m.Priority = todo.Priority(value.String)
+			}
+		case todo.FieldPosition:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field position", values[i])
+			} else if value.Valid {
+				_m.Position = value.String
+			}
 		case todo.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field createdAt", values[i])
@@ -94,6 +284,70 @@ func (_m *Todo) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.UpdatedAt = value.Time
 			}
+		case todo.FieldDueAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field dueAt", values[i])
+			} else if value.Valid {
+				_m.DueAt = new(time.Time)
+				*_m.DueAt = value.Time
+			}
+		case todo.FieldRemindAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field remindAt", values[i])
+			} else if value.Valid {
+				_m.RemindAt = new(time.Time)
+				*_m.RemindAt = value.Time
+			}
+		case todo.FieldReminderSentAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field reminderSentAt", values[i])
+			} else if value.Valid {
+				_m.ReminderSentAt = new(time.Time)
+				*_m.ReminderSentAt = value.Time
+			}
+		case todo.FieldOwnerID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field ownerID", values[i])
+			} else if value.Valid {
+				_m.OwnerID = int(value.Int64)
+			}
+		case todo.FieldProjectID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field projectID", values[i])
+			} else if value.Valid {
+				_m.ProjectID = int(value.Int64)
+			}
+		case todo.FieldParentID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field parentID", values[i])
+			} else if value.Valid {
+				_m.ParentID = int(value.Int64)
+			}
+		case todo.FieldRecurrence:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field recurrence", values[i])
+			} else if value.Valid {
+				_m.Recurrence = value.String
+			}
+		case todo.FieldPreviousOccurrenceID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field previousOccurrenceID", values[i])
+			} else if value.Valid {
+				_m.PreviousOccurrenceID = int(value.Int64)
+			}
+		case todo.FieldDeletedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field deletedAt", values[i])
+			} else if value.Valid {
+				_m.DeletedAt = new(time.Time)
+				*_m.DeletedAt = value.Time
+			}
+		case todo.FieldVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field version", values[i])
+			} else if value.Valid {
+				_m.Version = int(value.Int64)
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -107,6 +361,66 @@ func (_m *Todo) Value(name string) (ent.Value, error) {
 	return _m.selectValues.Get(name)
 }
 
+// QueryOwner queries the "owner" edge of the Todo entity.
+func (_m *Todo) QueryOwner() *UserQuery {
+	return NewTodoClient(_m.config).QueryOwner(_m)
+}
+
+// QueryProject queries the "project" edge of the Todo entity.
+func (_m *Todo) QueryProject() *ProjectQuery {
+	return NewTodoClient(_m.config).QueryProject(_m)
+}
+
+// QueryTags queries the "tags" edge of the Todo entity.
+func (_m *Todo) QueryTags() *TagQuery {
+	return NewTodoClient(_m.config).QueryTags(_m)
+}
+
+// QueryParent queries the "parent" edge of the Todo entity.
+func (_m *Todo) QueryParent() *TodoQuery {
+	return NewTodoClient(_m.config).QueryParent(_m)
+}
+
+// QueryChildren queries the "children" edge of the Todo entity.
+func (_m *Todo) QueryChildren() *TodoQuery {
+	return NewTodoClient(_m.config).QueryChildren(_m)
+}
+
+// QueryBlocks queries the "blocks" edge of the Todo entity.
+func (_m *Todo) QueryBlocks() *TodoQuery {
+	return NewTodoClient(_m.config).QueryBlocks(_m)
+}
+
+// QueryBlockedBy queries the "blockedBy" edge of the Todo entity.
+func (_m *Todo) QueryBlockedBy() *TodoQuery {
+	return NewTodoClient(_m.config).QueryBlockedBy(_m)
+}
+
+// QueryPreviousOccurrence queries the "previousOccurrence" edge of the Todo entity.
+func (_m *Todo) QueryPreviousOccurrence() *TodoQuery {
+	return NewTodoClient(_m.config).QueryPreviousOccurrence(_m)
+}
+
+// QueryNextOccurrence queries the "nextOccurrence" edge of the Todo entity.
+func (_m *Todo) QueryNextOccurrence() *TodoQuery {
+	return NewTodoClient(_m.config).QueryNextOccurrence(_m)
+}
+
+// QueryRevisions queries the "revisions" edge of the Todo entity.
+func (_m *Todo) QueryRevisions() *TodoRevisionQuery {
+	return NewTodoClient(_m.config).QueryRevisions(_m)
+}
+
+// QueryAttachments queries the "attachments" edge of the Todo entity.
+func (_m *Todo) QueryAttachments() *AttachmentQuery {
+	return NewTodoClient(_m.config).QueryAttachments(_m)
+}
+
+// QueryComments queries the "comments" edge of the Todo entity.
+func (_m *Todo) QueryComments() *CommentQuery {
+	return NewTodoClient(_m.config).QueryComments(_m)
+}
+
 // Update returns a builder for updating this Todo.
 // Note that you need to call Todo.Unwrap() before calling this method if this Todo
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -139,11 +453,55 @@ func (_m *Todo) String() string {
 	builder.WriteString("completed=")
 	builder.WriteString(fmt.Sprintf("%v", _m.Completed))
 	builder.WriteString(", ")
+	builder.WriteString("priority=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Priority))
+	builder.WriteString(", ")
+	builder.WriteString("position=")
+	builder.WriteString(_m.Position)
+	builder.WriteString(", ")
 	builder.WriteString("createdAt=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
 	builder.WriteString("updatedAt=")
 	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := _m.DueAt; v != nil {
+		builder.WriteString("dueAt=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.RemindAt; v != nil {
+		builder.WriteString("remindAt=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.ReminderSentAt; v != nil {
+		builder.WriteString("reminderSentAt=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("ownerID=")
+	builder.WriteString(fmt.Sprintf("%v", _m.OwnerID))
+	builder.WriteString(", ")
+	builder.WriteString("projectID=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ProjectID))
+	builder.WriteString(", ")
+	builder.WriteString("parentID=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ParentID))
+	builder.WriteString(", ")
+	builder.WriteString("recurrence=")
+	builder.WriteString(_m.Recurrence)
+	builder.WriteString(", ")
+	builder.WriteString("previousOccurrenceID=")
+	builder.WriteString(fmt.Sprintf("%v", _m.PreviousOccurrenceID))
+	builder.WriteString(", ")
+	if v := _m.DeletedAt; v != nil {
+		builder.WriteString("deletedAt=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("version=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Version))
 	builder.WriteByte(')')
 	return builder.String()
 }
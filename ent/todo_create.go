@@ -10,7 +10,13 @@ import (
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/attachment"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/comment"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/project"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/tag"
 	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todorevision"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/user"
 )
 
 // TodoCreate is the builder for creating a Todo entity.
@@ -54,6 +60,26 @@ func (_c *TodoCreate) SetNillableCompleted(v *bool) *TodoCreate {
 	return _c
 }
 
+// SetPriority sets the "priority" field.
+func (_c *TodoCreate) SetPriority(v todo.Priority) *TodoCreate {
+	_c.mutation.SetPriority(v)
+	return _c
+}
+
+// SetNillablePriority sets the "priority" field if the given value is not nil.
+func (_c *TodoCreate) SetNillablePriority(v *todo.Priority) *TodoCreate {
+	if v != nil {
+		_c.SetPriority(*v)
+	}
+	return _c
+}
+
+// SetPosition sets the "position" field.
+func (_c *TodoCreate) SetPosition(v string) *TodoCreate {
+	_c.mutation.SetPosition(v)
+	return _c
+}
+
 // SetCreatedAt sets the "createdAt" field.
 func (_c *TodoCreate) SetCreatedAt(v time.Time) *TodoCreate {
 	_c.mutation.SetCreatedAt(v)
@@ -82,6 +108,290 @@ func (_c *TodoCreate) SetNillableUpdatedAt(v *time.Time) *TodoCreate {
 	return _c
 }
 
+// SetDueAt sets the "dueAt" field.
+func (_c *TodoCreate) SetDueAt(v time.Time) *TodoCreate {
+	_c.mutation.SetDueAt(v)
+	return _c
+}
+
+// SetNillableDueAt sets the "dueAt" field if the given value is not nil.
+func (_c *TodoCreate) SetNillableDueAt(v *time.Time) *TodoCreate {
+	if v != nil {
+		_c.SetDueAt(*v)
+	}
+	return _c
+}
+
+// SetRemindAt sets the "remindAt" field.
+func (_c *TodoCreate) SetRemindAt(v time.Time) *TodoCreate {
+	_c.mutation.SetRemindAt(v)
+	return _c
+}
+
+// SetNillableRemindAt sets the "remindAt" field if the given value is not nil.
+func (_c *TodoCreate) SetNillableRemindAt(v *time.Time) *TodoCreate {
+	if v != nil {
+		_c.SetRemindAt(*v)
+	}
+	return _c
+}
+
+// SetReminderSentAt sets the "reminderSentAt" field.
+func (_c *TodoCreate) SetReminderSentAt(v time.Time) *TodoCreate {
+	_c.mutation.SetReminderSentAt(v)
+	return _c
+}
+
+// SetNillableReminderSentAt sets the "reminderSentAt" field if the given value is not nil.
+func (_c *TodoCreate) SetNillableReminderSentAt(v *time.Time) *TodoCreate {
+	if v != nil {
+		_c.SetReminderSentAt(*v)
+	}
+	return _c
+}
+
+// SetOwnerID sets the "ownerID" field.
+func (_c *TodoCreate) SetOwnerID(v int) *TodoCreate {
+	_c.mutation.SetOwnerID(v)
+	return _c
+}
+
+// SetNillableOwnerID sets the "ownerID" field if the given value is not nil.
+func (_c *TodoCreate) SetNillableOwnerID(v *int) *TodoCreate {
+	if v != nil {
+		_c.SetOwnerID(*v)
+	}
+	return _c
+}
+
+// SetProjectID sets the "projectID" field.
+func (_c *TodoCreate) SetProjectID(v int) *TodoCreate {
+	_c.mutation.SetProjectID(v)
+	return _c
+}
+
+// SetNillableProjectID sets the "projectID" field if the given value is not nil.
+func (_c *TodoCreate) SetNillableProjectID(v *int) *TodoCreate {
+	if v != nil {
+		_c.SetProjectID(*v)
+	}
+	return _c
+}
+
+// SetParentID sets the "parentID" field.
+func (_c *TodoCreate) SetParentID(v int) *TodoCreate {
+	_c.mutation.SetParentID(v)
+	return _c
+}
+
+// SetNillableParentID sets the "parentID" field if the given value is not nil.
+func (_c *TodoCreate) SetNillableParentID(v *int) *TodoCreate {
+	if v != nil {
+		_c.SetParentID(*v)
+	}
+	return _c
+}
+
+// SetRecurrence sets the "recurrence" field.
+func (_c *TodoCreate) SetRecurrence(v string) *TodoCreate {
+	_c.mutation.SetRecurrence(v)
+	return _c
+}
+
+// SetNillableRecurrence sets the "recurrence" field if the given value is not nil.
+func (_c *TodoCreate) SetNillableRecurrence(v *string) *TodoCreate {
+	if v != nil {
+		_c.SetRecurrence(*v)
+	}
+	return _c
+}
+
+// SetPreviousOccurrenceID sets the "previousOccurrenceID" field.
+func (_c *TodoCreate) SetPreviousOccurrenceID(v int) *TodoCreate {
+	_c.mutation.SetPreviousOccurrenceID(v)
+	return _c
+}
+
+// SetNillablePreviousOccurrenceID sets the "previousOccurrenceID" field if the given value is not nil.
+func (_c *TodoCreate) SetNillablePreviousOccurrenceID(v *int) *TodoCreate {
+	if v != nil {
+		_c.SetPreviousOccurrenceID(*v)
+	}
+	return _c
+}
+
+// SetDeletedAt sets the "deletedAt" field.
+func (_c *TodoCreate) SetDeletedAt(v time.Time) *TodoCreate {
+	_c.mutation.SetDeletedAt(v)
+	return _c
+}
+
+// SetNillableDeletedAt sets the "deletedAt" field if the given value is not nil.
+func (_c *TodoCreate) SetNillableDeletedAt(v *time.Time) *TodoCreate {
+	if v != nil {
+		_c.SetDeletedAt(*v)
+	}
+	return _c
+}
+
+// SetVersion sets the "version" field.
+func (_c *TodoCreate) SetVersion(v int) *TodoCreate {
+	_c.mutation.SetVersion(v)
+	return _c
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (_c *TodoCreate) SetNillableVersion(v *int) *TodoCreate {
+	if v != nil {
+		_c.SetVersion(*v)
+	}
+	return _c
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_c *TodoCreate) SetOwner(v *User) *TodoCreate {
+	return _c.SetOwnerID(v.ID)
+}
+
+// SetProject sets the "project" edge to the Project entity.
+func (_c *TodoCreate) SetProject(v *Project) *TodoCreate {
+	return _c.SetProjectID(v.ID)
+}
+
+// AddTagIDs adds the "tags" edge to the Tag entity by IDs.
+func (_c *TodoCreate) AddTagIDs(ids ...int) *TodoCreate {
+	_c.mutation.AddTagIDs(ids...)
+	return _c
+}
+
+// AddTags adds the "tags" edges to the Tag entity.
+func (_c *TodoCreate) AddTags(v ...*Tag) *TodoCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddTagIDs(ids...)
+}
+
+// SetParent sets the "parent" edge to the Todo entity.
+func (_c *TodoCreate) SetParent(v *Todo) *TodoCreate {
+	return _c.SetParentID(v.ID)
+}
+
+// AddChildIDs adds the "children" edge to the Todo entity by IDs.
+func (_c *TodoCreate) AddChildIDs(ids ...int) *TodoCreate {
+	_c.mutation.AddChildIDs(ids...)
+	return _c
+}
+
+// AddChildren adds the "children" edges to the Todo entity.
+func (_c *TodoCreate) AddChildren(v ...*Todo) *TodoCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddChildIDs(ids...)
+}
+
+// AddBlockIDs adds the "blocks" edge to the Todo entity by IDs.
+func (_c *TodoCreate) AddBlockIDs(ids ...int) *TodoCreate {
+	_c.mutation.AddBlockIDs(ids...)
+	return _c
+}
+
+// AddBlocks adds the "blocks" edges to the Todo entity.
+func (_c *TodoCreate) AddBlocks(v ...*Todo) *TodoCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddBlockIDs(ids...)
+}
+
+// AddBlockedByIDs adds the "blockedBy" edge to the Todo entity by IDs.
+func (_c *TodoCreate) AddBlockedByIDs(ids ...int) *TodoCreate {
+	_c.mutation.AddBlockedByIDs(ids...)
+	return _c
+}
+
+// AddBlockedBy adds the "blockedBy" edges to the Todo entity.
+func (_c *TodoCreate) AddBlockedBy(v ...*Todo) *TodoCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddBlockedByIDs(ids...)
+}
+
+// SetPreviousOccurrence sets the "previousOccurrence" edge to the Todo entity.
+func (_c *TodoCreate) SetPreviousOccurrence(v *Todo) *TodoCreate {
+	return _c.SetPreviousOccurrenceID(v.ID)
+}
+
+// SetNextOccurrenceID sets the "nextOccurrence" edge to the Todo entity by ID.
+func (_c *TodoCreate) SetNextOccurrenceID(id int) *TodoCreate {
+	_c.mutation.SetNextOccurrenceID(id)
+	return _c
+}
+
+// SetNillableNextOccurrenceID sets the "nextOccurrence" edge to the Todo entity by ID if the given value is not nil.
+func (_c *TodoCreate) SetNillableNextOccurrenceID(id *int) *TodoCreate {
+	if id != nil {
+		_c = _c.SetNextOccurrenceID(*id)
+	}
+	return _c
+}
+
+// SetNextOccurrence sets the "nextOccurrence" edge to the Todo entity.
+func (_c *TodoCreate) SetNextOccurrence(v *Todo) *TodoCreate {
+	return _c.SetNextOccurrenceID(v.ID)
+}
+
+// AddRevisionIDs adds the "revisions" edge to the TodoRevision entity by IDs.
+func (_c *TodoCreate) AddRevisionIDs(ids ...int) *TodoCreate {
+	_c.mutation.AddRevisionIDs(ids...)
+	return _c
+}
+
+// AddRevisions adds the "revisions" edges to the TodoRevision entity.
+func (_c *TodoCreate) AddRevisions(v ...*TodoRevision) *TodoCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddRevisionIDs(ids...)
+}
+
+// AddAttachmentIDs adds the "attachments" edge to the Attachment entity by IDs.
+func (_c *TodoCreate) AddAttachmentIDs(ids ...int) *TodoCreate {
+	_c.mutation.AddAttachmentIDs(ids...)
+	return _c
+}
+
+// AddAttachments adds the "attachments" edges to the Attachment entity.
+func (_c *TodoCreate) AddAttachments(v ...*Attachment) *TodoCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddAttachmentIDs(ids...)
+}
+
+// AddCommentIDs adds the "comments" edge to the Comment entity by IDs.
+func (_c *TodoCreate) AddCommentIDs(ids ...int) *TodoCreate {
+	_c.mutation.AddCommentIDs(ids...)
+	return _c
+}
+
+// AddComments adds the "comments" edges to the Comment entity.
+func (_c *TodoCreate) AddComments(v ...*Comment) *TodoCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddCommentIDs(ids...)
+}
+
 // Mutation returns the TodoMutation object of the builder.
 func (_c *TodoCreate) Mutation() *TodoMutation {
 	return _c.mutation
@@ -89,7 +399,9 @@ func (_c *TodoCreate) Mutation() *TodoMutation {
 
 // Save creates the Todo in the database.
 func (_c *TodoCreate) Save(ctx context.Context) (*Todo, error) {
-	_c.defaults()
+	if err := _c.defaults(); err != nil {
+		return nil, err
+	}
 	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
 }
 
@@ -116,19 +428,34 @@ func (_c *TodoCreate) ExecX(ctx context.Context) {
 }
 
 // defaults sets the default values of the builder before save.
-func (_c *TodoCreate) defaults() {
+func (_c *TodoCreate) defaults() error {
 	if _, ok := _c.mutation.Completed(); !ok {
 		v := todo.DefaultCompleted
 		_c.mutation.SetCompleted(v)
 	}
+	if _, ok := _c.mutation.Priority(); !ok {
+		v := todo.DefaultPriority
+		_c.mutation.SetPriority(v)
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
+		if todo.DefaultCreatedAt == nil {
+			return fmt.Errorf("ent: uninitialized todo.DefaultCreatedAt (forgotten import ent/runtime?)")
+		}
 		v := todo.DefaultCreatedAt()
 		_c.mutation.SetCreatedAt(v)
 	}
 	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		if todo.DefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized todo.DefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
 		v := todo.DefaultUpdatedAt()
 		_c.mutation.SetUpdatedAt(v)
 	}
+	if _, ok := _c.mutation.Version(); !ok {
+		v := todo.DefaultVersion
+		_c.mutation.SetVersion(v)
+	}
+	return nil
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -144,12 +471,36 @@ func (_c *TodoCreate) check() error {
 	if _, ok := _c.mutation.Completed(); !ok {
 		return &ValidationError{Name: "completed", err: errors.New(`ent: missing required field "Todo.completed"`)}
 	}
+	if _, ok := _c.mutation.Priority(); !ok {
+		return &ValidationError{Name: "priority", err: errors.New(`ent: missing required field "Todo.priority"`)}
+	}
+	if v, ok := _c.mutation.Priority(); ok {
+		if err := todo.PriorityValidator(v); err != nil {
+			return &ValidationError{Name: "priority", err: fmt.Errorf(`ent: validator failed for field "Todo.priority": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Position(); !ok {
+		return &ValidationError{Name: "position", err: errors.New(`ent: missing required field "Todo.position"`)}
+	}
+	if v, ok := _c.mutation.Position(); ok {
+		if err := todo.PositionValidator(v); err != nil {
+			return &ValidationError{Name: "position", err: fmt.Errorf(`ent: validator failed for field "Todo.position": %w`, err)}
+		}
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "createdAt", err: errors.New(`ent: missing required field "Todo.createdAt"`)}
 	}
 	if _, ok := _c.mutation.UpdatedAt(); !ok {
 		return &ValidationError{Name: "updatedAt", err: errors.New(`ent: missing required field "Todo.updatedAt"`)}
 	}
+	if v, ok := _c.mutation.Recurrence(); ok {
+		if err := todo.RecurrenceValidator(v); err != nil {
+			return &ValidationError{Name: "recurrence", err: fmt.Errorf(`ent: validator failed for field "Todo.recurrence": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New(`ent: missing required field "Todo.version"`)}
+	}
 	return nil
 }
 
@@ -188,6 +539,14 @@ func (_c *TodoCreate) createSpec() (*Todo, *sqlgraph.CreateSpec) {
 		_spec.SetField(todo.FieldCompleted, field.TypeBool, value)
 		_node.Completed = value
 	}
+	if value, ok := _c.mutation.Priority(); ok {
+		_spec.SetField(todo.FieldPriority, field.TypeEnum, value)
+		_node.Priority = value
+	}
+	if value, ok := _c.mutation.Position(); ok {
+		_spec.SetField(todo.FieldPosition, field.TypeString, value)
+		_node.Position = value
+	}
 	if value, ok := _c.mutation.CreatedAt(); ok {
 		_spec.SetField(todo.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -196,6 +555,226 @@ func (_c *TodoCreate) createSpec() (*Todo, *sqlgraph.CreateSpec) {
 		_spec.SetField(todo.FieldUpdatedAt, field.TypeTime, value)
 		_node.UpdatedAt = value
 	}
+	if value, ok := _c.mutation.DueAt(); ok {
+		_spec.SetField(todo.FieldDueAt, field.TypeTime, value)
+		_node.DueAt = &value
+	}
+	if value, ok := _c.mutation.RemindAt(); ok {
+		_spec.SetField(todo.FieldRemindAt, field.TypeTime, value)
+		_node.RemindAt = &value
+	}
+	if value, ok := _c.mutation.ReminderSentAt(); ok {
+		_spec.SetField(todo.FieldReminderSentAt, field.TypeTime, value)
+		_node.ReminderSentAt = &value
+	}
+	if value, ok := _c.mutation.Recurrence(); ok {
+		_spec.SetField(todo.FieldRecurrence, field.TypeString, value)
+		_node.Recurrence = value
+	}
+	if value, ok := _c.mutation.DeletedAt(); ok {
+		_spec.SetField(todo.FieldDeletedAt, field.TypeTime, value)
+		_node.DeletedAt = &value
+	}
+	if value, ok := _c.mutation.Version(); ok {
+		_spec.SetField(todo.FieldVersion, field.TypeInt, value)
+		_node.Version = value
+	}
+	if nodes := _c.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.OwnerTable,
+			Columns: []string{todo.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.OwnerID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.ProjectIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ProjectTable,
+			Columns: []string{todo.ProjectColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(project.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.ProjectID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.TagsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.TagsTable,
+			Columns: todo.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.ParentIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ParentTable,
+			Columns: []string{todo.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.ParentID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.ChildrenIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.ChildrenTable,
+			Columns: []string{todo.ChildrenColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.BlocksIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.BlocksTable,
+			Columns: todo.BlocksPrimaryKey,
+			Bidi:    true,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.BlockedByIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.BlockedByTable,
+			Columns: todo.BlockedByPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.PreviousOccurrenceIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   todo.PreviousOccurrenceTable,
+			Columns: []string{todo.PreviousOccurrenceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.PreviousOccurrenceID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.NextOccurrenceIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: false,
+			Table:   todo.NextOccurrenceTable,
+			Columns: []string{todo.NextOccurrenceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.RevisionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.RevisionsTable,
+			Columns: []string{todo.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todorevision.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.AttachmentsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.AttachmentsTable,
+			Columns: []string{todo.AttachmentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attachment.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.CommentsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.CommentsTable,
+			Columns: []string{todo.CommentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(comment.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -11,8 +11,14 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/attachment"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/comment"
 	"github.com/ishidatakuo/graphql-ent-atlas/ent/predicate"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/project"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/tag"
 	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todorevision"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/user"
 )
 
 // TodoUpdate is the builder for updating Todo entities.
@@ -76,198 +82,1821 @@ func (_u *TodoUpdate) SetNillableCompleted(v *bool) *TodoUpdate {
 	return _u
 }
 
+// SetPriority sets the "priority" field.
+func (_u *TodoUpdate) SetPriority(v todo.Priority) *TodoUpdate {
+	_u.mutation.SetPriority(v)
+	return _u
+}
+
+// SetNillablePriority sets the "priority" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillablePriority(v *todo.Priority) *TodoUpdate {
+	if v != nil {
+		_u.SetPriority(*v)
+	}
+	return _u
+}
+
+// SetPosition sets the "position" field.
+func (_u *TodoUpdate) SetPosition(v string) *TodoUpdate {
+	_u.mutation.SetPosition(v)
+	return _u
+}
+
+// SetNillablePosition sets the "position" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillablePosition(v *string) *TodoUpdate {
+	if v != nil {
+		_u.SetPosition(*v)
+	}
+	return _u
+}
+
 // SetCreatedAt sets the "createdAt" field.
 func (_u *TodoUpdate) SetCreatedAt(v time.Time) *TodoUpdate {
 	_u.mutation.SetCreatedAt(v)
 	return _u
 }
 
-// SetNillableCreatedAt sets the "createdAt" field if the given value is not nil.
-func (_u *TodoUpdate) SetNillableCreatedAt(v *time.Time) *TodoUpdate {
-	if v != nil {
-		_u.SetCreatedAt(*v)
+// SetNillableCreatedAt sets the "createdAt" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillableCreatedAt(v *time.Time) *TodoUpdate {
+	if v != nil {
+		_u.SetCreatedAt(*v)
+	}
+	return _u
+}
+
+// SetUpdatedAt sets the "updatedAt" field.
+func (_u *TodoUpdate) SetUpdatedAt(v time.Time) *TodoUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetDueAt sets the "dueAt" field.
+func (_u *TodoUpdate) SetDueAt(v time.Time) *TodoUpdate {
+	_u.mutation.SetDueAt(v)
+	return _u
+}
+
+// SetNillableDueAt sets the "dueAt" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillableDueAt(v *time.Time) *TodoUpdate {
+	if v != nil {
+		_u.SetDueAt(*v)
+	}
+	return _u
+}
+
+// ClearDueAt clears the value of the "dueAt" field.
+func (_u *TodoUpdate) ClearDueAt() *TodoUpdate {
+	_u.mutation.ClearDueAt()
+	return _u
+}
+
+// SetRemindAt sets the "remindAt" field.
+func (_u *TodoUpdate) SetRemindAt(v time.Time) *TodoUpdate {
+	_u.mutation.SetRemindAt(v)
+	return _u
+}
+
+// SetNillableRemindAt sets the "remindAt" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillableRemindAt(v *time.Time) *TodoUpdate {
+	if v != nil {
+		_u.SetRemindAt(*v)
+	}
+	return _u
+}
+
+// ClearRemindAt clears the value of the "remindAt" field.
+func (_u *TodoUpdate) ClearRemindAt() *TodoUpdate {
+	_u.mutation.ClearRemindAt()
+	return _u
+}
+
+// SetReminderSentAt sets the "reminderSentAt" field.
+func (_u *TodoUpdate) SetReminderSentAt(v time.Time) *TodoUpdate {
+	_u.mutation.SetReminderSentAt(v)
+	return _u
+}
+
+// SetNillableReminderSentAt sets the "reminderSentAt" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillableReminderSentAt(v *time.Time) *TodoUpdate {
+	if v != nil {
+		_u.SetReminderSentAt(*v)
+	}
+	return _u
+}
+
+// ClearReminderSentAt clears the value of the "reminderSentAt" field.
+func (_u *TodoUpdate) ClearReminderSentAt() *TodoUpdate {
+	_u.mutation.ClearReminderSentAt()
+	return _u
+}
+
+// SetOwnerID sets the "ownerID" field.
+func (_u *TodoUpdate) SetOwnerID(v int) *TodoUpdate {
+	_u.mutation.SetOwnerID(v)
+	return _u
+}
+
+// SetNillableOwnerID sets the "ownerID" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillableOwnerID(v *int) *TodoUpdate {
+	if v != nil {
+		_u.SetOwnerID(*v)
+	}
+	return _u
+}
+
+// ClearOwnerID clears the value of the "ownerID" field.
+func (_u *TodoUpdate) ClearOwnerID() *TodoUpdate {
+	_u.mutation.ClearOwnerID()
+	return _u
+}
+
+// SetProjectID sets the "projectID" field.
+func (_u *TodoUpdate) SetProjectID(v int) *TodoUpdate {
+	_u.mutation.SetProjectID(v)
+	return _u
+}
+
+// SetNillableProjectID sets the "projectID" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillableProjectID(v *int) *TodoUpdate {
+	if v != nil {
+		_u.SetProjectID(*v)
+	}
+	return _u
+}
+
+// ClearProjectID clears the value of the "projectID" field.
+func (_u *TodoUpdate) ClearProjectID() *TodoUpdate {
+	_u.mutation.ClearProjectID()
+	return _u
+}
+
+// SetParentID sets the "parentID" field.
+func (_u *TodoUpdate) SetParentID(v int) *TodoUpdate {
+	_u.mutation.SetParentID(v)
+	return _u
+}
+
+// SetNillableParentID sets the "parentID" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillableParentID(v *int) *TodoUpdate {
+	if v != nil {
+		_u.SetParentID(*v)
+	}
+	return _u
+}
+
+// ClearParentID clears the value of the "parentID" field.
+func (_u *TodoUpdate) ClearParentID() *TodoUpdate {
+	_u.mutation.ClearParentID()
+	return _u
+}
+
+// SetRecurrence sets the "recurrence" field.
+func (_u *TodoUpdate) SetRecurrence(v string) *TodoUpdate {
+	_u.mutation.SetRecurrence(v)
+	return _u
+}
+
+// SetNillableRecurrence sets the "recurrence" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillableRecurrence(v *string) *TodoUpdate {
+	if v != nil {
+		_u.SetRecurrence(*v)
+	}
+	return _u
+}
+
+// ClearRecurrence clears the value of the "recurrence" field.
+func (_u *TodoUpdate) ClearRecurrence() *TodoUpdate {
+	_u.mutation.ClearRecurrence()
+	return _u
+}
+
+// SetPreviousOccurrenceID sets the "previousOccurrenceID" field.
+func (_u *TodoUpdate) SetPreviousOccurrenceID(v int) *TodoUpdate {
+	_u.mutation.SetPreviousOccurrenceID(v)
+	return _u
+}
+
+// SetNillablePreviousOccurrenceID sets the "previousOccurrenceID" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillablePreviousOccurrenceID(v *int) *TodoUpdate {
+	if v != nil {
+		_u.SetPreviousOccurrenceID(*v)
+	}
+	return _u
+}
+
+// ClearPreviousOccurrenceID clears the value of the "previousOccurrenceID" field.
+func (_u *TodoUpdate) ClearPreviousOccurrenceID() *TodoUpdate {
+	_u.mutation.ClearPreviousOccurrenceID()
+	return _u
+}
+
+// SetDeletedAt sets the "deletedAt" field.
+func (_u *TodoUpdate) SetDeletedAt(v time.Time) *TodoUpdate {
+	_u.mutation.SetDeletedAt(v)
+	return _u
+}
+
+// SetNillableDeletedAt sets the "deletedAt" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillableDeletedAt(v *time.Time) *TodoUpdate {
+	if v != nil {
+		_u.SetDeletedAt(*v)
+	}
+	return _u
+}
+
+// ClearDeletedAt clears the value of the "deletedAt" field.
+func (_u *TodoUpdate) ClearDeletedAt() *TodoUpdate {
+	_u.mutation.ClearDeletedAt()
+	return _u
+}
+
+// SetVersion sets the "version" field.
+func (_u *TodoUpdate) SetVersion(v int) *TodoUpdate {
+	_u.mutation.ResetVersion()
+	_u.mutation.SetVersion(v)
+	return _u
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (_u *TodoUpdate) SetNillableVersion(v *int) *TodoUpdate {
+	if v != nil {
+		_u.SetVersion(*v)
+	}
+	return _u
+}
+
+// AddVersion adds value to the "version" field.
+func (_u *TodoUpdate) AddVersion(v int) *TodoUpdate {
+	_u.mutation.AddVersion(v)
+	return _u
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_u *TodoUpdate) SetOwner(v *User) *TodoUpdate {
+	return _u.SetOwnerID(v.ID)
+}
+
+// SetProject sets the "project" edge to the Project entity.
+func (_u *TodoUpdate) SetProject(v *Project) *TodoUpdate {
+	return _u.SetProjectID(v.ID)
+}
+
+// AddTagIDs adds the "tags" edge to the Tag entity by IDs.
+func (_u *TodoUpdate) AddTagIDs(ids ...int) *TodoUpdate {
+	_u.mutation.AddTagIDs(ids...)
+	return _u
+}
+
+// AddTags adds the "tags" edges to the Tag entity.
+func (_u *TodoUpdate) AddTags(v ...*Tag) *TodoUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddTagIDs(ids...)
+}
+
+// SetParent sets the "parent" edge to the Todo entity.
+func (_u *TodoUpdate) SetParent(v *Todo) *TodoUpdate {
+	return _u.SetParentID(v.ID)
+}
+
+// AddChildIDs adds the "children" edge to the Todo entity by IDs.
+func (_u *TodoUpdate) AddChildIDs(ids ...int) *TodoUpdate {
+	_u.mutation.AddChildIDs(ids...)
+	return _u
+}
+
+// AddChildren adds the "children" edges to the Todo entity.
+func (_u *TodoUpdate) AddChildren(v ...*Todo) *TodoUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddChildIDs(ids...)
+}
+
+// AddBlockIDs adds the "blocks" edge to the Todo entity by IDs.
+func (_u *TodoUpdate) AddBlockIDs(ids ...int) *TodoUpdate {
+	_u.mutation.AddBlockIDs(ids...)
+	return _u
+}
+
+// AddBlocks adds the "blocks" edges to the Todo entity.
+func (_u *TodoUpdate) AddBlocks(v ...*Todo) *TodoUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddBlockIDs(ids...)
+}
+
+// AddBlockedByIDs adds the "blockedBy" edge to the Todo entity by IDs.
+func (_u *TodoUpdate) AddBlockedByIDs(ids ...int) *TodoUpdate {
+	_u.mutation.AddBlockedByIDs(ids...)
+	return _u
+}
+
+// AddBlockedBy adds the "blockedBy" edges to the Todo entity.
+func (_u *TodoUpdate) AddBlockedBy(v ...*Todo) *TodoUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddBlockedByIDs(ids...)
+}
+
+// SetPreviousOccurrence sets the "previousOccurrence" edge to the Todo entity.
+func (_u *TodoUpdate) SetPreviousOccurrence(v *Todo) *TodoUpdate {
+	return _u.SetPreviousOccurrenceID(v.ID)
+}
+
+// SetNextOccurrenceID sets the "nextOccurrence" edge to the Todo entity by ID.
+func (_u *TodoUpdate) SetNextOccurrenceID(id int) *TodoUpdate {
+	_u.mutation.SetNextOccurrenceID(id)
+	return _u
+}
+
+// SetNillableNextOccurrenceID sets the "nextOccurrence" edge to the Todo entity by ID if the given value is not nil.
+func (_u *TodoUpdate) SetNillableNextOccurrenceID(id *int) *TodoUpdate {
+	if id != nil {
+		_u = _u.SetNextOccurrenceID(*id)
+	}
+	return _u
+}
+
+// SetNextOccurrence sets the "nextOccurrence" edge to the Todo entity.
+func (_u *TodoUpdate) SetNextOccurrence(v *Todo) *TodoUpdate {
+	return _u.SetNextOccurrenceID(v.ID)
+}
+
+// AddRevisionIDs adds the "revisions" edge to the TodoRevision entity by IDs.
+func (_u *TodoUpdate) AddRevisionIDs(ids ...int) *TodoUpdate {
+	_u.mutation.AddRevisionIDs(ids...)
+	return _u
+}
+
+// AddRevisions adds the "revisions" edges to the TodoRevision entity.
+func (_u *TodoUpdate) AddRevisions(v ...*TodoRevision) *TodoUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddRevisionIDs(ids...)
+}
+
+// AddAttachmentIDs adds the "attachments" edge to the Attachment entity by IDs.
+func (_u *TodoUpdate) AddAttachmentIDs(ids ...int) *TodoUpdate {
+	_u.mutation.AddAttachmentIDs(ids...)
+	return _u
+}
+
+// AddAttachments adds the "attachments" edges to the Attachment entity.
+func (_u *TodoUpdate) AddAttachments(v ...*Attachment) *TodoUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddAttachmentIDs(ids...)
+}
+
+// AddCommentIDs adds the "comments" edge to the Comment entity by IDs.
+func (_u *TodoUpdate) AddCommentIDs(ids ...int) *TodoUpdate {
+	_u.mutation.AddCommentIDs(ids...)
+	return _u
+}
+
+// AddComments adds the "comments" edges to the Comment entity.
+func (_u *TodoUpdate) AddComments(v ...*Comment) *TodoUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddCommentIDs(ids...)
+}
+
+// Mutation returns the TodoMutation object of the builder.
+func (_u *TodoUpdate) Mutation() *TodoMutation {
+	return _u.mutation
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (_u *TodoUpdate) ClearOwner() *TodoUpdate {
+	_u.mutation.ClearOwner()
+	return _u
+}
+
+// ClearProject clears the "project" edge to the Project entity.
+func (_u *TodoUpdate) ClearProject() *TodoUpdate {
+	_u.mutation.ClearProject()
+	return _u
+}
+
+// ClearTags clears all "tags" edges to the Tag entity.
+func (_u *TodoUpdate) ClearTags() *TodoUpdate {
+	_u.mutation.ClearTags()
+	return _u
+}
+
+// RemoveTagIDs removes the "tags" edge to Tag entities by IDs.
+func (_u *TodoUpdate) RemoveTagIDs(ids ...int) *TodoUpdate {
+	_u.mutation.RemoveTagIDs(ids...)
+	return _u
+}
+
+// RemoveTags removes "tags" edges to Tag entities.
+func (_u *TodoUpdate) RemoveTags(v ...*Tag) *TodoUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveTagIDs(ids...)
+}
+
+// ClearParent clears the "parent" edge to the Todo entity.
+func (_u *TodoUpdate) ClearParent() *TodoUpdate {
+	_u.mutation.ClearParent()
+	return _u
+}
+
+// ClearChildren clears all "children" edges to the Todo entity.
+func (_u *TodoUpdate) ClearChildren() *TodoUpdate {
+	_u.mutation.ClearChildren()
+	return _u
+}
+
+// RemoveChildIDs removes the "children" edge to Todo entities by IDs.
+func (_u *TodoUpdate) RemoveChildIDs(ids ...int) *TodoUpdate {
+	_u.mutation.RemoveChildIDs(ids...)
+	return _u
+}
+
+// RemoveChildren removes "children" edges to Todo entities.
+func (_u *TodoUpdate) RemoveChildren(v ...*Todo) *TodoUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveChildIDs(ids...)
+}
+
+// ClearBlocks clears all "blocks" edges to the Todo entity.
+func (_u *TodoUpdate) ClearBlocks() *TodoUpdate {
+	_u.mutation.ClearBlocks()
+	return _u
+}
+
+// RemoveBlockIDs removes the "blocks" edge to Todo entities by IDs.
+func (_u *TodoUpdate) RemoveBlockIDs(ids ...int) *TodoUpdate {
+	_u.mutation.RemoveBlockIDs(ids...)
+	return _u
+}
+
+// RemoveBlocks removes "blocks" edges to Todo entities.
+func (_u *TodoUpdate) RemoveBlocks(v ...*Todo) *TodoUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveBlockIDs(ids...)
+}
+
+// ClearBlockedBy clears all "blockedBy" edges to the Todo entity.
+func (_u *TodoUpdate) ClearBlockedBy() *TodoUpdate {
+	_u.mutation.ClearBlockedBy()
+	return _u
+}
+
+// RemoveBlockedByIDs removes the "blockedBy" edge to Todo entities by IDs.
+func (_u *TodoUpdate) RemoveBlockedByIDs(ids ...int) *TodoUpdate {
+	_u.mutation.RemoveBlockedByIDs(ids...)
+	return _u
+}
+
+// RemoveBlockedBy removes "blockedBy" edges to Todo entities.
+func (_u *TodoUpdate) RemoveBlockedBy(v ...*Todo) *TodoUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveBlockedByIDs(ids...)
+}
+
+// ClearPreviousOccurrence clears the "previousOccurrence" edge to the Todo entity.
+func (_u *TodoUpdate) ClearPreviousOccurrence() *TodoUpdate {
+	_u.mutation.ClearPreviousOccurrence()
+	return _u
+}
+
+// ClearNextOccurrence clears the "nextOccurrence" edge to the Todo entity.
+func (_u *TodoUpdate) ClearNextOccurrence() *TodoUpdate {
+	_u.mutation.ClearNextOccurrence()
+	return _u
+}
+
+// ClearRevisions clears all "revisions" edges to the TodoRevision entity.
+func (_u *TodoUpdate) ClearRevisions() *TodoUpdate {
+	_u.mutation.ClearRevisions()
+	return _u
+}
+
+// RemoveRevisionIDs removes the "revisions" edge to TodoRevision entities by IDs.
+func (_u *TodoUpdate) RemoveRevisionIDs(ids ...int) *TodoUpdate {
+	_u.mutation.RemoveRevisionIDs(ids...)
+	return _u
+}
+
+// RemoveRevisions removes "revisions" edges to TodoRevision entities.
+func (_u *TodoUpdate) RemoveRevisions(v ...*TodoRevision) *TodoUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveRevisionIDs(ids...)
+}
+
+// ClearAttachments clears all "attachments" edges to the Attachment entity.
+func (_u *TodoUpdate) ClearAttachments() *TodoUpdate {
+	_u.mutation.ClearAttachments()
+	return _u
+}
+
+// RemoveAttachmentIDs removes the "attachments" edge to Attachment entities by IDs.
+func (_u *TodoUpdate) RemoveAttachmentIDs(ids ...int) *TodoUpdate {
+	_u.mutation.RemoveAttachmentIDs(ids...)
+	return _u
+}
+
+// RemoveAttachments removes "attachments" edges to Attachment entities.
+func (_u *TodoUpdate) RemoveAttachments(v ...*Attachment) *TodoUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveAttachmentIDs(ids...)
+}
+
+// ClearComments clears all "comments" edges to the Comment entity.
+func (_u *TodoUpdate) ClearComments() *TodoUpdate {
+	_u.mutation.ClearComments()
+	return _u
+}
+
+// RemoveCommentIDs removes the "comments" edge to Comment entities by IDs.
+func (_u *TodoUpdate) RemoveCommentIDs(ids ...int) *TodoUpdate {
+	_u.mutation.RemoveCommentIDs(ids...)
+	return _u
+}
+
+// RemoveComments removes "comments" edges to Comment entities.
+func (_u *TodoUpdate) RemoveComments(v ...*Comment) *TodoUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveCommentIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *TodoUpdate) Save(ctx context.Context) (int, error) {
+	if err := _u.defaults(); err != nil {
+		return 0, err
+	}
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *TodoUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *TodoUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *TodoUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *TodoUpdate) defaults() error {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		if todo.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized todo.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := todo.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *TodoUpdate) check() error {
+	if v, ok := _u.mutation.Title(); ok {
+		if err := todo.TitleValidator(v); err != nil {
+			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Todo.title": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Priority(); ok {
+		if err := todo.PriorityValidator(v); err != nil {
+			return &ValidationError{Name: "priority", err: fmt.Errorf(`ent: validator failed for field "Todo.priority": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Position(); ok {
+		if err := todo.PositionValidator(v); err != nil {
+			return &ValidationError{Name: "position", err: fmt.Errorf(`ent: validator failed for field "Todo.position": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Recurrence(); ok {
+		if err := todo.RecurrenceValidator(v); err != nil {
+			return &ValidationError{Name: "recurrence", err: fmt.Errorf(`ent: validator failed for field "Todo.recurrence": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *TodoUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(todo.Table, todo.Columns, sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Title(); ok {
+		_spec.SetField(todo.FieldTitle, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Description(); ok {
+		_spec.SetField(todo.FieldDescription, field.TypeString, value)
+	}
+	if _u.mutation.DescriptionCleared() {
+		_spec.ClearField(todo.FieldDescription, field.TypeString)
+	}
+	if value, ok := _u.mutation.Completed(); ok {
+		_spec.SetField(todo.FieldCompleted, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.Priority(); ok {
+		_spec.SetField(todo.FieldPriority, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.Position(); ok {
+		_spec.SetField(todo.FieldPosition, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.CreatedAt(); ok {
+		_spec.SetField(todo.FieldCreatedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(todo.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.DueAt(); ok {
+		_spec.SetField(todo.FieldDueAt, field.TypeTime, value)
+	}
+	if _u.mutation.DueAtCleared() {
+		_spec.ClearField(todo.FieldDueAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.RemindAt(); ok {
+		_spec.SetField(todo.FieldRemindAt, field.TypeTime, value)
+	}
+	if _u.mutation.RemindAtCleared() {
+		_spec.ClearField(todo.FieldRemindAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.ReminderSentAt(); ok {
+		_spec.SetField(todo.FieldReminderSentAt, field.TypeTime, value)
+	}
+	if _u.mutation.ReminderSentAtCleared() {
+		_spec.ClearField(todo.FieldReminderSentAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Recurrence(); ok {
+		_spec.SetField(todo.FieldRecurrence, field.TypeString, value)
+	}
+	if _u.mutation.RecurrenceCleared() {
+		_spec.ClearField(todo.FieldRecurrence, field.TypeString)
+	}
+	if value, ok := _u.mutation.DeletedAt(); ok {
+		_spec.SetField(todo.FieldDeletedAt, field.TypeTime, value)
+	}
+	if _u.mutation.DeletedAtCleared() {
+		_spec.ClearField(todo.FieldDeletedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Version(); ok {
+		_spec.SetField(todo.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedVersion(); ok {
+		_spec.AddField(todo.FieldVersion, field.TypeInt, value)
+	}
+	if _u.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.OwnerTable,
+			Columns: []string{todo.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.OwnerTable,
+			Columns: []string{todo.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ProjectCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ProjectTable,
+			Columns: []string{todo.ProjectColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(project.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ProjectIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ProjectTable,
+			Columns: []string{todo.ProjectColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(project.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.TagsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.TagsTable,
+			Columns: todo.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedTagsIDs(); len(nodes) > 0 && !_u.mutation.TagsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.TagsTable,
+			Columns: todo.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.TagsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.TagsTable,
+			Columns: todo.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ParentCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ParentTable,
+			Columns: []string{todo.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ParentIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ParentTable,
+			Columns: []string{todo.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ChildrenCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.ChildrenTable,
+			Columns: []string{todo.ChildrenColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedChildrenIDs(); len(nodes) > 0 && !_u.mutation.ChildrenCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.ChildrenTable,
+			Columns: []string{todo.ChildrenColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ChildrenIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.ChildrenTable,
+			Columns: []string{todo.ChildrenColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.BlocksCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.BlocksTable,
+			Columns: todo.BlocksPrimaryKey,
+			Bidi:    true,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedBlocksIDs(); len(nodes) > 0 && !_u.mutation.BlocksCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.BlocksTable,
+			Columns: todo.BlocksPrimaryKey,
+			Bidi:    true,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.BlocksIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.BlocksTable,
+			Columns: todo.BlocksPrimaryKey,
+			Bidi:    true,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.BlockedByCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.BlockedByTable,
+			Columns: todo.BlockedByPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedBlockedByIDs(); len(nodes) > 0 && !_u.mutation.BlockedByCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.BlockedByTable,
+			Columns: todo.BlockedByPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.BlockedByIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.BlockedByTable,
+			Columns: todo.BlockedByPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.PreviousOccurrenceCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   todo.PreviousOccurrenceTable,
+			Columns: []string{todo.PreviousOccurrenceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PreviousOccurrenceIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   todo.PreviousOccurrenceTable,
+			Columns: []string{todo.PreviousOccurrenceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.NextOccurrenceCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: false,
+			Table:   todo.NextOccurrenceTable,
+			Columns: []string{todo.NextOccurrenceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.NextOccurrenceIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: false,
+			Table:   todo.NextOccurrenceTable,
+			Columns: []string{todo.NextOccurrenceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.RevisionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.RevisionsTable,
+			Columns: []string{todo.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todorevision.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedRevisionsIDs(); len(nodes) > 0 && !_u.mutation.RevisionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.RevisionsTable,
+			Columns: []string{todo.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todorevision.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RevisionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.RevisionsTable,
+			Columns: []string{todo.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todorevision.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.AttachmentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.AttachmentsTable,
+			Columns: []string{todo.AttachmentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attachment.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedAttachmentsIDs(); len(nodes) > 0 && !_u.mutation.AttachmentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.AttachmentsTable,
+			Columns: []string{todo.AttachmentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attachment.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.AttachmentsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.AttachmentsTable,
+			Columns: []string{todo.AttachmentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attachment.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.CommentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.CommentsTable,
+			Columns: []string{todo.CommentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(comment.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedCommentsIDs(); len(nodes) > 0 && !_u.mutation.CommentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.CommentsTable,
+			Columns: []string{todo.CommentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(comment.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.CommentsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.CommentsTable,
+			Columns: []string{todo.CommentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(comment.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{todo.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// TodoUpdateOne is the builder for updating a single Todo entity.
+type TodoUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *TodoMutation
+}
+
+// SetTitle sets the "title" field.
+func (_u *TodoUpdateOne) SetTitle(v string) *TodoUpdateOne {
+	_u.mutation.SetTitle(v)
+	return _u
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableTitle(v *string) *TodoUpdateOne {
+	if v != nil {
+		_u.SetTitle(*v)
+	}
+	return _u
+}
+
+// SetDescription sets the "description" field.
+func (_u *TodoUpdateOne) SetDescription(v string) *TodoUpdateOne {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableDescription(v *string) *TodoUpdateOne {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// ClearDescription clears the value of the "description" field.
+func (_u *TodoUpdateOne) ClearDescription() *TodoUpdateOne {
+	_u.mutation.ClearDescription()
+	return _u
+}
+
+// SetCompleted sets the "completed" field.
+func (_u *TodoUpdateOne) SetCompleted(v bool) *TodoUpdateOne {
+	_u.mutation.SetCompleted(v)
+	return _u
+}
+
+// SetNillableCompleted sets the "completed" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableCompleted(v *bool) *TodoUpdateOne {
+	if v != nil {
+		_u.SetCompleted(*v)
+	}
+	return _u
+}
+
+// SetPriority sets the "priority" field.
+func (_u *TodoUpdateOne) SetPriority(v todo.Priority) *TodoUpdateOne {
+	_u.mutation.SetPriority(v)
+	return _u
+}
+
+// SetNillablePriority sets the "priority" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillablePriority(v *todo.Priority) *TodoUpdateOne {
+	if v != nil {
+		_u.SetPriority(*v)
+	}
+	return _u
+}
+
+// SetPosition sets the "position" field.
+func (_u *TodoUpdateOne) SetPosition(v string) *TodoUpdateOne {
+	_u.mutation.SetPosition(v)
+	return _u
+}
+
+// SetNillablePosition sets the "position" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillablePosition(v *string) *TodoUpdateOne {
+	if v != nil {
+		_u.SetPosition(*v)
+	}
+	return _u
+}
+
+// SetCreatedAt sets the "createdAt" field.
+func (_u *TodoUpdateOne) SetCreatedAt(v time.Time) *TodoUpdateOne {
+	_u.mutation.SetCreatedAt(v)
+	return _u
+}
+
+// SetNillableCreatedAt sets the "createdAt" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableCreatedAt(v *time.Time) *TodoUpdateOne {
+	if v != nil {
+		_u.SetCreatedAt(*v)
+	}
+	return _u
+}
+
+// SetUpdatedAt sets the "updatedAt" field.
+func (_u *TodoUpdateOne) SetUpdatedAt(v time.Time) *TodoUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetDueAt sets the "dueAt" field.
+func (_u *TodoUpdateOne) SetDueAt(v time.Time) *TodoUpdateOne {
+	_u.mutation.SetDueAt(v)
+	return _u
+}
+
+// SetNillableDueAt sets the "dueAt" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableDueAt(v *time.Time) *TodoUpdateOne {
+	if v != nil {
+		_u.SetDueAt(*v)
+	}
+	return _u
+}
+
+// ClearDueAt clears the value of the "dueAt" field.
+func (_u *TodoUpdateOne) ClearDueAt() *TodoUpdateOne {
+	_u.mutation.ClearDueAt()
+	return _u
+}
+
+// SetRemindAt sets the "remindAt" field.
+func (_u *TodoUpdateOne) SetRemindAt(v time.Time) *TodoUpdateOne {
+	_u.mutation.SetRemindAt(v)
+	return _u
+}
+
+// SetNillableRemindAt sets the "remindAt" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableRemindAt(v *time.Time) *TodoUpdateOne {
+	if v != nil {
+		_u.SetRemindAt(*v)
+	}
+	return _u
+}
+
+// ClearRemindAt clears the value of the "remindAt" field.
+func (_u *TodoUpdateOne) ClearRemindAt() *TodoUpdateOne {
+	_u.mutation.ClearRemindAt()
+	return _u
+}
+
+// SetReminderSentAt sets the "reminderSentAt" field.
+func (_u *TodoUpdateOne) SetReminderSentAt(v time.Time) *TodoUpdateOne {
+	_u.mutation.SetReminderSentAt(v)
+	return _u
+}
+
+// SetNillableReminderSentAt sets the "reminderSentAt" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableReminderSentAt(v *time.Time) *TodoUpdateOne {
+	if v != nil {
+		_u.SetReminderSentAt(*v)
+	}
+	return _u
+}
+
+// ClearReminderSentAt clears the value of the "reminderSentAt" field.
+func (_u *TodoUpdateOne) ClearReminderSentAt() *TodoUpdateOne {
+	_u.mutation.ClearReminderSentAt()
+	return _u
+}
+
+// SetOwnerID sets the "ownerID" field.
+func (_u *TodoUpdateOne) SetOwnerID(v int) *TodoUpdateOne {
+	_u.mutation.SetOwnerID(v)
+	return _u
+}
+
+// SetNillableOwnerID sets the "ownerID" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableOwnerID(v *int) *TodoUpdateOne {
+	if v != nil {
+		_u.SetOwnerID(*v)
+	}
+	return _u
+}
+
+// ClearOwnerID clears the value of the "ownerID" field.
+func (_u *TodoUpdateOne) ClearOwnerID() *TodoUpdateOne {
+	_u.mutation.ClearOwnerID()
+	return _u
+}
+
+// SetProjectID sets the "projectID" field.
+func (_u *TodoUpdateOne) SetProjectID(v int) *TodoUpdateOne {
+	_u.mutation.SetProjectID(v)
+	return _u
+}
+
+// SetNillableProjectID sets the "projectID" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableProjectID(v *int) *TodoUpdateOne {
+	if v != nil {
+		_u.SetProjectID(*v)
+	}
+	return _u
+}
+
+// ClearProjectID clears the value of the "projectID" field.
+func (_u *TodoUpdateOne) ClearProjectID() *TodoUpdateOne {
+	_u.mutation.ClearProjectID()
+	return _u
+}
+
+// SetParentID sets the "parentID" field.
+func (_u *TodoUpdateOne) SetParentID(v int) *TodoUpdateOne {
+	_u.mutation.SetParentID(v)
+	return _u
+}
+
+// SetNillableParentID sets the "parentID" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableParentID(v *int) *TodoUpdateOne {
+	if v != nil {
+		_u.SetParentID(*v)
+	}
+	return _u
+}
+
+// ClearParentID clears the value of the "parentID" field.
+func (_u *TodoUpdateOne) ClearParentID() *TodoUpdateOne {
+	_u.mutation.ClearParentID()
+	return _u
+}
+
+// SetRecurrence sets the "recurrence" field.
+func (_u *TodoUpdateOne) SetRecurrence(v string) *TodoUpdateOne {
+	_u.mutation.SetRecurrence(v)
+	return _u
+}
+
+// SetNillableRecurrence sets the "recurrence" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableRecurrence(v *string) *TodoUpdateOne {
+	if v != nil {
+		_u.SetRecurrence(*v)
+	}
+	return _u
+}
+
+// ClearRecurrence clears the value of the "recurrence" field.
+func (_u *TodoUpdateOne) ClearRecurrence() *TodoUpdateOne {
+	_u.mutation.ClearRecurrence()
+	return _u
+}
+
+// SetPreviousOccurrenceID sets the "previousOccurrenceID" field.
+func (_u *TodoUpdateOne) SetPreviousOccurrenceID(v int) *TodoUpdateOne {
+	_u.mutation.SetPreviousOccurrenceID(v)
+	return _u
+}
+
+// SetNillablePreviousOccurrenceID sets the "previousOccurrenceID" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillablePreviousOccurrenceID(v *int) *TodoUpdateOne {
+	if v != nil {
+		_u.SetPreviousOccurrenceID(*v)
+	}
+	return _u
+}
+
+// ClearPreviousOccurrenceID clears the value of the "previousOccurrenceID" field.
+func (_u *TodoUpdateOne) ClearPreviousOccurrenceID() *TodoUpdateOne {
+	_u.mutation.ClearPreviousOccurrenceID()
+	return _u
+}
+
+// SetDeletedAt sets the "deletedAt" field.
+func (_u *TodoUpdateOne) SetDeletedAt(v time.Time) *TodoUpdateOne {
+	_u.mutation.SetDeletedAt(v)
+	return _u
+}
+
+// SetNillableDeletedAt sets the "deletedAt" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableDeletedAt(v *time.Time) *TodoUpdateOne {
+	if v != nil {
+		_u.SetDeletedAt(*v)
+	}
+	return _u
+}
+
+// ClearDeletedAt clears the value of the "deletedAt" field.
+func (_u *TodoUpdateOne) ClearDeletedAt() *TodoUpdateOne {
+	_u.mutation.ClearDeletedAt()
+	return _u
+}
+
+// SetVersion sets the "version" field.
+func (_u *TodoUpdateOne) SetVersion(v int) *TodoUpdateOne {
+	_u.mutation.ResetVersion()
+	_u.mutation.SetVersion(v)
+	return _u
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableVersion(v *int) *TodoUpdateOne {
+	if v != nil {
+		_u.SetVersion(*v)
+	}
+	return _u
+}
+
+// AddVersion adds value to the "version" field.
+func (_u *TodoUpdateOne) AddVersion(v int) *TodoUpdateOne {
+	_u.mutation.AddVersion(v)
+	return _u
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_u *TodoUpdateOne) SetOwner(v *User) *TodoUpdateOne {
+	return _u.SetOwnerID(v.ID)
+}
+
+// SetProject sets the "project" edge to the Project entity.
+func (_u *TodoUpdateOne) SetProject(v *Project) *TodoUpdateOne {
+	return _u.SetProjectID(v.ID)
+}
+
+// AddTagIDs adds the "tags" edge to the Tag entity by IDs.
+func (_u *TodoUpdateOne) AddTagIDs(ids ...int) *TodoUpdateOne {
+	_u.mutation.AddTagIDs(ids...)
+	return _u
+}
+
+// AddTags adds the "tags" edges to the Tag entity.
+func (_u *TodoUpdateOne) AddTags(v ...*Tag) *TodoUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddTagIDs(ids...)
+}
+
+// SetParent sets the "parent" edge to the Todo entity.
+func (_u *TodoUpdateOne) SetParent(v *Todo) *TodoUpdateOne {
+	return _u.SetParentID(v.ID)
+}
+
+// AddChildIDs adds the "children" edge to the Todo entity by IDs.
+func (_u *TodoUpdateOne) AddChildIDs(ids ...int) *TodoUpdateOne {
+	_u.mutation.AddChildIDs(ids...)
+	return _u
+}
+
+// AddChildren adds the "children" edges to the Todo entity.
+func (_u *TodoUpdateOne) AddChildren(v ...*Todo) *TodoUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddChildIDs(ids...)
+}
+
+// AddBlockIDs adds the "blocks" edge to the Todo entity by IDs.
+func (_u *TodoUpdateOne) AddBlockIDs(ids ...int) *TodoUpdateOne {
+	_u.mutation.AddBlockIDs(ids...)
+	return _u
+}
+
+// AddBlocks adds the "blocks" edges to the Todo entity.
+func (_u *TodoUpdateOne) AddBlocks(v ...*Todo) *TodoUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddBlockIDs(ids...)
+}
+
+// AddBlockedByIDs adds the "blockedBy" edge to the Todo entity by IDs.
+func (_u *TodoUpdateOne) AddBlockedByIDs(ids ...int) *TodoUpdateOne {
+	_u.mutation.AddBlockedByIDs(ids...)
+	return _u
+}
+
+// AddBlockedBy adds the "blockedBy" edges to the Todo entity.
+func (_u *TodoUpdateOne) AddBlockedBy(v ...*Todo) *TodoUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddBlockedByIDs(ids...)
+}
+
+// SetPreviousOccurrence sets the "previousOccurrence" edge to the Todo entity.
+func (_u *TodoUpdateOne) SetPreviousOccurrence(v *Todo) *TodoUpdateOne {
+	return _u.SetPreviousOccurrenceID(v.ID)
+}
+
+// SetNextOccurrenceID sets the "nextOccurrence" edge to the Todo entity by ID.
+func (_u *TodoUpdateOne) SetNextOccurrenceID(id int) *TodoUpdateOne {
+	_u.mutation.SetNextOccurrenceID(id)
+	return _u
+}
+
+// SetNillableNextOccurrenceID sets the "nextOccurrence" edge to the Todo entity by ID if the given value is not nil.
+func (_u *TodoUpdateOne) SetNillableNextOccurrenceID(id *int) *TodoUpdateOne {
+	if id != nil {
+		_u = _u.SetNextOccurrenceID(*id)
+	}
+	return _u
+}
+
+// SetNextOccurrence sets the "nextOccurrence" edge to the Todo entity.
+func (_u *TodoUpdateOne) SetNextOccurrence(v *Todo) *TodoUpdateOne {
+	return _u.SetNextOccurrenceID(v.ID)
+}
+
+// AddRevisionIDs adds the "revisions" edge to the TodoRevision entity by IDs.
+func (_u *TodoUpdateOne) AddRevisionIDs(ids ...int) *TodoUpdateOne {
+	_u.mutation.AddRevisionIDs(ids...)
+	return _u
+}
+
+// AddRevisions adds the "revisions" edges to the TodoRevision entity.
+func (_u *TodoUpdateOne) AddRevisions(v ...*TodoRevision) *TodoUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddRevisionIDs(ids...)
+}
+
+// AddAttachmentIDs adds the "attachments" edge to the Attachment entity by IDs.
+func (_u *TodoUpdateOne) AddAttachmentIDs(ids ...int) *TodoUpdateOne {
+	_u.mutation.AddAttachmentIDs(ids...)
+	return _u
+}
+
+// AddAttachments adds the "attachments" edges to the Attachment entity.
+func (_u *TodoUpdateOne) AddAttachments(v ...*Attachment) *TodoUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
-	return _u
+	return _u.AddAttachmentIDs(ids...)
 }
 
-// SetUpdatedAt sets the "updatedAt" field.
-func (_u *TodoUpdate) SetUpdatedAt(v time.Time) *TodoUpdate {
-	_u.mutation.SetUpdatedAt(v)
+// AddCommentIDs adds the "comments" edge to the Comment entity by IDs.
+func (_u *TodoUpdateOne) AddCommentIDs(ids ...int) *TodoUpdateOne {
+	_u.mutation.AddCommentIDs(ids...)
 	return _u
 }
 
+// AddComments adds the "comments" edges to the Comment entity.
+func (_u *TodoUpdateOne) AddComments(v ...*Comment) *TodoUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddCommentIDs(ids...)
+}
+
 // Mutation returns the TodoMutation object of the builder.
-func (_u *TodoUpdate) Mutation() *TodoMutation {
+func (_u *TodoUpdateOne) Mutation() *TodoMutation {
 	return _u.mutation
 }
 
-// Save executes the query and returns the number of nodes affected by the update operation.
-func (_u *TodoUpdate) Save(ctx context.Context) (int, error) {
-	_u.defaults()
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+// ClearOwner clears the "owner" edge to the User entity.
+func (_u *TodoUpdateOne) ClearOwner() *TodoUpdateOne {
+	_u.mutation.ClearOwner()
+	return _u
 }
 
-// SaveX is like Save, but panics if an error occurs.
-func (_u *TodoUpdate) SaveX(ctx context.Context) int {
-	affected, err := _u.Save(ctx)
-	if err != nil {
-		panic(err)
-	}
-	return affected
+// ClearProject clears the "project" edge to the Project entity.
+func (_u *TodoUpdateOne) ClearProject() *TodoUpdateOne {
+	_u.mutation.ClearProject()
+	return _u
 }
 
-// Exec executes the query.
-func (_u *TodoUpdate) Exec(ctx context.Context) error {
-	_, err := _u.Save(ctx)
-	return err
+// ClearTags clears all "tags" edges to the Tag entity.
+func (_u *TodoUpdateOne) ClearTags() *TodoUpdateOne {
+	_u.mutation.ClearTags()
+	return _u
 }
 
-// ExecX is like Exec, but panics if an error occurs.
-func (_u *TodoUpdate) ExecX(ctx context.Context) {
-	if err := _u.Exec(ctx); err != nil {
-		panic(err)
-	}
+// RemoveTagIDs removes the "tags" edge to Tag entities by IDs.
+func (_u *TodoUpdateOne) RemoveTagIDs(ids ...int) *TodoUpdateOne {
+	_u.mutation.RemoveTagIDs(ids...)
+	return _u
 }
 
-// defaults sets the default values of the builder before save.
-func (_u *TodoUpdate) defaults() {
-	if _, ok := _u.mutation.UpdatedAt(); !ok {
-		v := todo.UpdateDefaultUpdatedAt()
-		_u.mutation.SetUpdatedAt(v)
+// RemoveTags removes "tags" edges to Tag entities.
+func (_u *TodoUpdateOne) RemoveTags(v ...*Tag) *TodoUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
+	return _u.RemoveTagIDs(ids...)
 }
 
-// check runs all checks and user-defined validators on the builder.
-func (_u *TodoUpdate) check() error {
-	if v, ok := _u.mutation.Title(); ok {
-		if err := todo.TitleValidator(v); err != nil {
-			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Todo.title": %w`, err)}
-		}
-	}
-	return nil
+// ClearParent clears the "parent" edge to the Todo entity.
+func (_u *TodoUpdateOne) ClearParent() *TodoUpdateOne {
+	_u.mutation.ClearParent()
+	return _u
 }
 
-func (_u *TodoUpdate) sqlSave(ctx context.Context) (_node int, err error) {
-	if err := _u.check(); err != nil {
-		return _node, err
-	}
-	_spec := sqlgraph.NewUpdateSpec(todo.Table, todo.Columns, sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt))
-	if ps := _u.mutation.predicates; len(ps) > 0 {
-		_spec.Predicate = func(selector *sql.Selector) {
-			for i := range ps {
-				ps[i](selector)
-			}
-		}
-	}
-	if value, ok := _u.mutation.Title(); ok {
-		_spec.SetField(todo.FieldTitle, field.TypeString, value)
-	}
-	if value, ok := _u.mutation.Description(); ok {
-		_spec.SetField(todo.FieldDescription, field.TypeString, value)
-	}
-	if _u.mutation.DescriptionCleared() {
-		_spec.ClearField(todo.FieldDescription, field.TypeString)
-	}
-	if value, ok := _u.mutation.Completed(); ok {
-		_spec.SetField(todo.FieldCompleted, field.TypeBool, value)
-	}
-	if value, ok := _u.mutation.CreatedAt(); ok {
-		_spec.SetField(todo.FieldCreatedAt, field.TypeTime, value)
-	}
-	if value, ok := _u.mutation.UpdatedAt(); ok {
-		_spec.SetField(todo.FieldUpdatedAt, field.TypeTime, value)
-	}
-	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
-		if _, ok := err.(*sqlgraph.NotFoundError); ok {
-			err = &NotFoundError{todo.Label}
-		} else if sqlgraph.IsConstraintError(err) {
-			err = &ConstraintError{msg: err.Error(), wrap: err}
-		}
-		return 0, err
+// ClearChildren clears all "children" edges to the Todo entity.
+func (_u *TodoUpdateOne) ClearChildren() *TodoUpdateOne {
+	_u.mutation.ClearChildren()
+	return _u
+}
+
+// RemoveChildIDs removes the "children" edge to Todo entities by IDs.
+func (_u *TodoUpdateOne) RemoveChildIDs(ids ...int) *TodoUpdateOne {
+	_u.mutation.RemoveChildIDs(ids...)
+	return _u
+}
+
+// RemoveChildren removes "children" edges to Todo entities.
+func (_u *TodoUpdateOne) RemoveChildren(v ...*Todo) *TodoUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
-	_u.mutation.done = true
-	return _node, nil
+	return _u.RemoveChildIDs(ids...)
 }
 
-// TodoUpdateOne is the builder for updating a single Todo entity.
-type TodoUpdateOne struct {
-	config
-	fields   []string
-	hooks    []Hook
-	mutation *TodoMutation
+// ClearBlocks clears all "blocks" edges to the Todo entity.
+func (_u *TodoUpdateOne) ClearBlocks() *TodoUpdateOne {
+	_u.mutation.ClearBlocks()
+	return _u
 }
 
-// SetTitle sets the "title" field.
-func (_u *TodoUpdateOne) SetTitle(v string) *TodoUpdateOne {
-	_u.mutation.SetTitle(v)
+// RemoveBlockIDs removes the "blocks" edge to Todo entities by IDs.
+func (_u *TodoUpdateOne) RemoveBlockIDs(ids ...int) *TodoUpdateOne {
+	_u.mutation.RemoveBlockIDs(ids...)
 	return _u
 }
 
-// SetNillableTitle sets the "title" field if the given value is not nil.
-func (_u *TodoUpdateOne) SetNillableTitle(v *string) *TodoUpdateOne {
-	if v != nil {
-		_u.SetTitle(*v)
+// RemoveBlocks removes "blocks" edges to Todo entities.
+func (_u *TodoUpdateOne) RemoveBlocks(v ...*Todo) *TodoUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
+	return _u.RemoveBlockIDs(ids...)
+}
+
+// ClearBlockedBy clears all "blockedBy" edges to the Todo entity.
+func (_u *TodoUpdateOne) ClearBlockedBy() *TodoUpdateOne {
+	_u.mutation.ClearBlockedBy()
 	return _u
 }
 
-// SetDescription sets the "description" field.
-func (_u *TodoUpdateOne) SetDescription(v string) *TodoUpdateOne {
-	_u.mutation.SetDescription(v)
+// RemoveBlockedByIDs removes the "blockedBy" edge to Todo entities by IDs.
+func (_u *TodoUpdateOne) RemoveBlockedByIDs(ids ...int) *TodoUpdateOne {
+	_u.mutation.RemoveBlockedByIDs(ids...)
 	return _u
 }
 
-// SetNillableDescription sets the "description" field if the given value is not nil.
-func (_u *TodoUpdateOne) SetNillableDescription(v *string) *TodoUpdateOne {
-	if v != nil {
-		_u.SetDescription(*v)
+// RemoveBlockedBy removes "blockedBy" edges to Todo entities.
+func (_u *TodoUpdateOne) RemoveBlockedBy(v ...*Todo) *TodoUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
+	return _u.RemoveBlockedByIDs(ids...)
+}
+
+// ClearPreviousOccurrence clears the "previousOccurrence" edge to the Todo entity.
+func (_u *TodoUpdateOne) ClearPreviousOccurrence() *TodoUpdateOne {
+	_u.mutation.ClearPreviousOccurrence()
 	return _u
 }
 
-// ClearDescription clears the value of the "description" field.
-func (_u *TodoUpdateOne) ClearDescription() *TodoUpdateOne {
-	_u.mutation.ClearDescription()
+// ClearNextOccurrence clears the "nextOccurrence" edge to the Todo entity.
+func (_u *TodoUpdateOne) ClearNextOccurrence() *TodoUpdateOne {
+	_u.mutation.ClearNextOccurrence()
 	return _u
 }
 
-// SetCompleted sets the "completed" field.
-func (_u *TodoUpdateOne) SetCompleted(v bool) *TodoUpdateOne {
-	_u.mutation.SetCompleted(v)
+// ClearRevisions clears all "revisions" edges to the TodoRevision entity.
+func (_u *TodoUpdateOne) ClearRevisions() *TodoUpdateOne {
+	_u.mutation.ClearRevisions()
 	return _u
 }
 
-// SetNillableCompleted sets the "completed" field if the given value is not nil.
-func (_u *TodoUpdateOne) SetNillableCompleted(v *bool) *TodoUpdateOne {
-	if v != nil {
-		_u.SetCompleted(*v)
+// RemoveRevisionIDs removes the "revisions" edge to TodoRevision entities by IDs.
+func (_u *TodoUpdateOne) RemoveRevisionIDs(ids ...int) *TodoUpdateOne {
+	_u.mutation.RemoveRevisionIDs(ids...)
+	return _u
+}
+
+// RemoveRevisions removes "revisions" edges to TodoRevision entities.
+func (_u *TodoUpdateOne) RemoveRevisions(v ...*TodoRevision) *TodoUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
+	return _u.RemoveRevisionIDs(ids...)
+}
+
+// ClearAttachments clears all "attachments" edges to the Attachment entity.
+func (_u *TodoUpdateOne) ClearAttachments() *TodoUpdateOne {
+	_u.mutation.ClearAttachments()
 	return _u
 }
 
-// SetCreatedAt sets the "createdAt" field.
-func (_u *TodoUpdateOne) SetCreatedAt(v time.Time) *TodoUpdateOne {
-	_u.mutation.SetCreatedAt(v)
+// RemoveAttachmentIDs removes the "attachments" edge to Attachment entities by IDs.
+func (_u *TodoUpdateOne) RemoveAttachmentIDs(ids ...int) *TodoUpdateOne {
+	_u.mutation.RemoveAttachmentIDs(ids...)
 	return _u
 }
 
-// SetNillableCreatedAt sets the "createdAt" field if the given value is not nil.
-func (_u *TodoUpdateOne) SetNillableCreatedAt(v *time.Time) *TodoUpdateOne {
-	if v != nil {
-		_u.SetCreatedAt(*v)
+// RemoveAttachments removes "attachments" edges to Attachment entities.
+func (_u *TodoUpdateOne) RemoveAttachments(v ...*Attachment) *TodoUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
 	}
+	return _u.RemoveAttachmentIDs(ids...)
+}
+
+// ClearComments clears all "comments" edges to the Comment entity.
+func (_u *TodoUpdateOne) ClearComments() *TodoUpdateOne {
+	_u.mutation.ClearComments()
 	return _u
 }
 
-// SetUpdatedAt sets the "updatedAt" field.
-func (_u *TodoUpdateOne) SetUpdatedAt(v time.Time) *TodoUpdateOne {
-	_u.mutation.SetUpdatedAt(v)
+// RemoveCommentIDs removes the "comments" edge to Comment entities by IDs.
+func (_u *TodoUpdateOne) RemoveCommentIDs(ids ...int) *TodoUpdateOne {
+	_u.mutation.RemoveCommentIDs(ids...)
 	return _u
 }
 
-// Mutation returns the TodoMutation object of the builder.
-func (_u *TodoUpdateOne) Mutation() *TodoMutation {
-	return _u.mutation
+// RemoveComments removes "comments" edges to Comment entities.
+func (_u *TodoUpdateOne) RemoveComments(v ...*Comment) *TodoUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveCommentIDs(ids...)
 }
 
 // Where appends a list predicates to the TodoUpdate builder.
@@ -285,7 +1914,9 @@ func (_u *TodoUpdateOne) Select(field string, fields ...string) *TodoUpdateOne {
 
 // Save executes the query and returns the updated Todo entity.
 func (_u *TodoUpdateOne) Save(ctx context.Context) (*Todo, error) {
-	_u.defaults()
+	if err := _u.defaults(); err != nil {
+		return nil, err
+	}
 	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
 }
 
@@ -312,11 +1943,15 @@ func (_u *TodoUpdateOne) ExecX(ctx context.Context) {
 }
 
 // defaults sets the default values of the builder before save.
-func (_u *TodoUpdateOne) defaults() {
+func (_u *TodoUpdateOne) defaults() error {
 	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		if todo.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized todo.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
 		v := todo.UpdateDefaultUpdatedAt()
 		_u.mutation.SetUpdatedAt(v)
 	}
+	return nil
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -326,6 +1961,21 @@ func (_u *TodoUpdateOne) check() error {
 			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Todo.title": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.Priority(); ok {
+		if err := todo.PriorityValidator(v); err != nil {
+			return &ValidationError{Name: "priority", err: fmt.Errorf(`ent: validator failed for field "Todo.priority": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Position(); ok {
+		if err := todo.PositionValidator(v); err != nil {
+			return &ValidationError{Name: "position", err: fmt.Errorf(`ent: validator failed for field "Todo.position": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Recurrence(); ok {
+		if err := todo.RecurrenceValidator(v); err != nil {
+			return &ValidationError{Name: "recurrence", err: fmt.Errorf(`ent: validator failed for field "Todo.recurrence": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -370,12 +2020,514 @@ func (_u *TodoUpdateOne) sqlSave(ctx context.Context) (_node *Todo, err error) {
 	if value, ok := _u.mutation.Completed(); ok {
 		_spec.SetField(todo.FieldCompleted, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.Priority(); ok {
+		_spec.SetField(todo.FieldPriority, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.Position(); ok {
+		_spec.SetField(todo.FieldPosition, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.CreatedAt(); ok {
 		_spec.SetField(todo.FieldCreatedAt, field.TypeTime, value)
 	}
 	if value, ok := _u.mutation.UpdatedAt(); ok {
 		_spec.SetField(todo.FieldUpdatedAt, field.TypeTime, value)
 	}
+	if value, ok := _u.mutation.DueAt(); ok {
+		_spec.SetField(todo.FieldDueAt, field.TypeTime, value)
+	}
+	if _u.mutation.DueAtCleared() {
+		_spec.ClearField(todo.FieldDueAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.RemindAt(); ok {
+		_spec.SetField(todo.FieldRemindAt, field.TypeTime, value)
+	}
+	if _u.mutation.RemindAtCleared() {
+		_spec.ClearField(todo.FieldRemindAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.ReminderSentAt(); ok {
+		_spec.SetField(todo.FieldReminderSentAt, field.TypeTime, value)
+	}
+	if _u.mutation.ReminderSentAtCleared() {
+		_spec.ClearField(todo.FieldReminderSentAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Recurrence(); ok {
+		_spec.SetField(todo.FieldRecurrence, field.TypeString, value)
+	}
+	if _u.mutation.RecurrenceCleared() {
+		_spec.ClearField(todo.FieldRecurrence, field.TypeString)
+	}
+	if value, ok := _u.mutation.DeletedAt(); ok {
+		_spec.SetField(todo.FieldDeletedAt, field.TypeTime, value)
+	}
+	if _u.mutation.DeletedAtCleared() {
+		_spec.ClearField(todo.FieldDeletedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Version(); ok {
+		_spec.SetField(todo.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedVersion(); ok {
+		_spec.AddField(todo.FieldVersion, field.TypeInt, value)
+	}
+	if _u.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.OwnerTable,
+			Columns: []string{todo.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.OwnerTable,
+			Columns: []string{todo.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ProjectCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ProjectTable,
+			Columns: []string{todo.ProjectColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(project.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ProjectIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ProjectTable,
+			Columns: []string{todo.ProjectColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(project.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.TagsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.TagsTable,
+			Columns: todo.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedTagsIDs(); len(nodes) > 0 && !_u.mutation.TagsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.TagsTable,
+			Columns: todo.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.TagsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.TagsTable,
+			Columns: todo.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ParentCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ParentTable,
+			Columns: []string{todo.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ParentIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todo.ParentTable,
+			Columns: []string{todo.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ChildrenCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.ChildrenTable,
+			Columns: []string{todo.ChildrenColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedChildrenIDs(); len(nodes) > 0 && !_u.mutation.ChildrenCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.ChildrenTable,
+			Columns: []string{todo.ChildrenColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ChildrenIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.ChildrenTable,
+			Columns: []string{todo.ChildrenColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.BlocksCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.BlocksTable,
+			Columns: todo.BlocksPrimaryKey,
+			Bidi:    true,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedBlocksIDs(); len(nodes) > 0 && !_u.mutation.BlocksCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.BlocksTable,
+			Columns: todo.BlocksPrimaryKey,
+			Bidi:    true,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.BlocksIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   todo.BlocksTable,
+			Columns: todo.BlocksPrimaryKey,
+			Bidi:    true,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.BlockedByCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.BlockedByTable,
+			Columns: todo.BlockedByPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedBlockedByIDs(); len(nodes) > 0 && !_u.mutation.BlockedByCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.BlockedByTable,
+			Columns: todo.BlockedByPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.BlockedByIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   todo.BlockedByTable,
+			Columns: todo.BlockedByPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.PreviousOccurrenceCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   todo.PreviousOccurrenceTable,
+			Columns: []string{todo.PreviousOccurrenceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PreviousOccurrenceIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   todo.PreviousOccurrenceTable,
+			Columns: []string{todo.PreviousOccurrenceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.NextOccurrenceCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: false,
+			Table:   todo.NextOccurrenceTable,
+			Columns: []string{todo.NextOccurrenceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.NextOccurrenceIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: false,
+			Table:   todo.NextOccurrenceTable,
+			Columns: []string{todo.NextOccurrenceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.RevisionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.RevisionsTable,
+			Columns: []string{todo.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todorevision.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedRevisionsIDs(); len(nodes) > 0 && !_u.mutation.RevisionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.RevisionsTable,
+			Columns: []string{todo.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todorevision.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RevisionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.RevisionsTable,
+			Columns: []string{todo.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todorevision.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.AttachmentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.AttachmentsTable,
+			Columns: []string{todo.AttachmentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attachment.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedAttachmentsIDs(); len(nodes) > 0 && !_u.mutation.AttachmentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.AttachmentsTable,
+			Columns: []string{todo.AttachmentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attachment.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.AttachmentsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.AttachmentsTable,
+			Columns: []string{todo.AttachmentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attachment.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.CommentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.CommentsTable,
+			Columns: []string{todo.CommentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(comment.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedCommentsIDs(); len(nodes) > 0 && !_u.mutation.CommentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.CommentsTable,
+			Columns: []string{todo.CommentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(comment.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.CommentsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.CommentsTable,
+			Columns: []string{todo.CommentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(comment.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Todo{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -0,0 +1,229 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todorevision"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/user"
+)
+
+// TodoRevision is the model entity for the TodoRevision schema.
+type TodoRevision struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// ID of the changed todo
+	TodoID int `json:"todoID,omitempty"`
+	// ID of the user who made the change, unset for changes made by the server
+	ActorID int `json:"actorID,omitempty"`
+	// Kind of change
+	Operation todorevision.Operation `json:"operation,omitempty"`
+	// Names of the fields the change set
+	Fields []string `json:"fields,omitempty"`
+	// Values of the changed fields before the change
+	OldValues map[string]interface{} `json:"oldValues,omitempty"`
+	// Values of the changed fields after the change
+	NewValues map[string]interface{} `json:"newValues,omitempty"`
+	// When the change was made
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the TodoRevisionQuery when eager-loading is set.
+	Edges        TodoRevisionEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// TodoRevisionEdges holds the relations/edges for other nodes in the graph.
+type TodoRevisionEdges struct {
+	// Changed todo
+	Todo *Todo `json:"todo,omitempty"`
+	// User who made the change
+	Actor *User `json:"actor,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// TodoOrErr returns the Todo value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e TodoRevisionEdges) TodoOrErr() (*Todo, error) {
+	if e.Todo != nil {
+		return e.Todo, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: todo.Label}
+	}
+	return nil, &NotLoadedError{edge: "todo"}
+}
+
+// ActorOrErr returns the Actor value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e TodoRevisionEdges) ActorOrErr() (*User, error) {
+	if e.Actor != nil {
+		return e.Actor, nil
+	} else if e.loadedTypes[1] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "actor"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*TodoRevision) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case todorevision.FieldFields, todorevision.FieldOldValues, todorevision.FieldNewValues:
+			values[i] = new([]byte)
+		case todorevision.FieldID, todorevision.FieldTodoID, todorevision.FieldActorID:
+			values[i] = new(sql.NullInt64)
+		case todorevision.FieldOperation:
+			values[i] = new(sql.NullString)
+		case todorevision.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the TodoRevision fields.
+func (_m *TodoRevision) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case todorevision.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case todorevision.FieldTodoID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field todoID", values[i])
+			} else if value.Valid {
+				_m.TodoID = int(value.Int64)
+			}
+		case todorevision.FieldActorID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field actorID", values[i])
+			} else if value.Valid {
+				_m.ActorID = int(value.Int64)
+			}
+		case todorevision.FieldOperation:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field operation", values[i])
+			} else if value.Valid {
+				_m.Operation = todorevision.Operation(value.String)
+			}
+		case todorevision.FieldFields:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field fields", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Fields); err != nil {
+					return fmt.Errorf("unmarshal field fields: %w", err)
+				}
+			}
+		case todorevision.FieldOldValues:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field oldValues", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.OldValues); err != nil {
+					return fmt.Errorf("unmarshal field oldValues: %w", err)
+				}
+			}
+		case todorevision.FieldNewValues:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field newValues", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.NewValues); err != nil {
+					return fmt.Errorf("unmarshal field newValues: %w", err)
+				}
+			}
+		case todorevision.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field createdAt", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the TodoRevision.
+// This includes values selected through modifiers, order, etc.
+func (_m *TodoRevision) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryTodo queries the "todo" edge of the TodoRevision entity.
+func (_m *TodoRevision) QueryTodo() *TodoQuery {
+	return NewTodoRevisionClient(_m.config).QueryTodo(_m)
+}
+
+// QueryActor queries the "actor" edge of the TodoRevision entity.
+func (_m *TodoRevision) QueryActor() *UserQuery {
+	return NewTodoRevisionClient(_m.config).QueryActor(_m)
+}
+
+// Update returns a builder for updating this TodoRevision.
+// Note that you need to call TodoRevision.Unwrap() before calling this method if this TodoRevision
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *TodoRevision) Update() *TodoRevisionUpdateOne {
+	return NewTodoRevisionClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the TodoRevision entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *TodoRevision) Unwrap() *TodoRevision {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: TodoRevision is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *TodoRevision) String() string {
+	var builder strings.Builder
+	builder.WriteString("TodoRevision(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("todoID=")
+	builder.WriteString(fmt.Sprintf("%v", _m.TodoID))
+	builder.WriteString(", ")
+	builder.WriteString("actorID=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ActorID))
+	builder.WriteString(", ")
+	builder.WriteString("operation=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Operation))
+	builder.WriteString(", ")
+	builder.WriteString("fields=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Fields))
+	builder.WriteString(", ")
+	builder.WriteString("oldValues=")
+	builder.WriteString(fmt.Sprintf("%v", _m.OldValues))
+	builder.WriteString(", ")
+	builder.WriteString("newValues=")
+	builder.WriteString(fmt.Sprintf("%v", _m.NewValues))
+	builder.WriteString(", ")
+	builder.WriteString("createdAt=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// TodoRevisions is a parsable slice of TodoRevision.
+type TodoRevisions []*TodoRevision
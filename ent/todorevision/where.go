@@ -0,0 +1,262 @@
+// Code generated by ent, DO NOT EDIT.
+
+package todorevision
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldLTE(FieldID, id))
+}
+
+// TodoID applies equality check predicate on the "todoID" field. It's identical to TodoIDEQ.
+func TodoID(v int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldEQ(FieldTodoID, v))
+}
+
+// ActorID applies equality check predicate on the "actorID" field. It's identical to ActorIDEQ.
+func ActorID(v int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldEQ(FieldActorID, v))
+}
+
+// CreatedAt applies equality check predicate on the "createdAt" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// TodoIDEQ applies the EQ predicate on the "todoID" field.
+func TodoIDEQ(v int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldEQ(FieldTodoID, v))
+}
+
+// TodoIDNEQ applies the NEQ predicate on the "todoID" field.
+func TodoIDNEQ(v int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldNEQ(FieldTodoID, v))
+}
+
+// TodoIDIn applies the In predicate on the "todoID" field.
+func TodoIDIn(vs ...int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldIn(FieldTodoID, vs...))
+}
+
+// TodoIDNotIn applies the NotIn predicate on the "todoID" field.
+func TodoIDNotIn(vs ...int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldNotIn(FieldTodoID, vs...))
+}
+
+// ActorIDEQ applies the EQ predicate on the "actorID" field.
+func ActorIDEQ(v int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldEQ(FieldActorID, v))
+}
+
+// ActorIDNEQ applies the NEQ predicate on the "actorID" field.
+func ActorIDNEQ(v int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldNEQ(FieldActorID, v))
+}
+
+// ActorIDIn applies the In predicate on the "actorID" field.
+func ActorIDIn(vs ...int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldIn(FieldActorID, vs...))
+}
+
+// ActorIDNotIn applies the NotIn predicate on the "actorID" field.
+func ActorIDNotIn(vs ...int) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldNotIn(FieldActorID, vs...))
+}
+
+// ActorIDIsNil applies the IsNil predicate on the "actorID" field.
+func ActorIDIsNil() predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldIsNull(FieldActorID))
+}
+
+// ActorIDNotNil applies the NotNil predicate on the "actorID" field.
+func ActorIDNotNil() predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldNotNull(FieldActorID))
+}
+
+// OperationEQ applies the EQ predicate on the "operation" field.
+func OperationEQ(v Operation) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldEQ(FieldOperation, v))
+}
+
+// OperationNEQ applies the NEQ predicate on the "operation" field.
+func OperationNEQ(v Operation) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldNEQ(FieldOperation, v))
+}
+
+// OperationIn applies the In predicate on the "operation" field.
+func OperationIn(vs ...Operation) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldIn(FieldOperation, vs...))
+}
+
+// OperationNotIn applies the NotIn predicate on the "operation" field.
+func OperationNotIn(vs ...Operation) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldNotIn(FieldOperation, vs...))
+}
+
+// OldValuesIsNil applies the IsNil predicate on the "oldValues" field.
+func OldValuesIsNil() predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldIsNull(FieldOldValues))
+}
+
+// OldValuesNotNil applies the NotNil predicate on the "oldValues" field.
+func OldValuesNotNil() predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldNotNull(FieldOldValues))
+}
+
+// NewValuesIsNil applies the IsNil predicate on the "newValues" field.
+func NewValuesIsNil() predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldIsNull(FieldNewValues))
+}
+
+// NewValuesNotNil applies the NotNil predicate on the "newValues" field.
+func NewValuesNotNil() predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldNotNull(FieldNewValues))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "createdAt" field.
+func CreatedAtEQ(v time.Time) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "createdAt" field.
+func CreatedAtNEQ(v time.Time) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "createdAt" field.
+func CreatedAtIn(vs ...time.Time) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "createdAt" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "createdAt" field.
+func CreatedAtGT(v time.Time) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "createdAt" field.
+func CreatedAtGTE(v time.Time) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "createdAt" field.
+func CreatedAtLT(v time.Time) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "createdAt" field.
+func CreatedAtLTE(v time.Time) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// HasTodo applies the HasEdge predicate on the "todo" edge.
+func HasTodo() predicate.TodoRevision {
+	return predicate.TodoRevision(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, TodoTable, TodoColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasTodoWith applies the HasEdge predicate on the "todo" edge with a given conditions (other predicates).
+func HasTodoWith(preds ...predicate.Todo) predicate.TodoRevision {
+	return predicate.TodoRevision(func(s *sql.Selector) {
+		step := newTodoStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasActor applies the HasEdge predicate on the "actor" edge.
+func HasActor() predicate.TodoRevision {
+	return predicate.TodoRevision(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, ActorTable, ActorColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasActorWith applies the HasEdge predicate on the "actor" edge with a given conditions (other predicates).
+func HasActorWith(preds ...predicate.User) predicate.TodoRevision {
+	return predicate.TodoRevision(func(s *sql.Selector) {
+		step := newActorStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.TodoRevision) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.TodoRevision) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.TodoRevision) predicate.TodoRevision {
+	return predicate.TodoRevision(sql.NotPredicates(p))
+}
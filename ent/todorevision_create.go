@@ -0,0 +1,325 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todorevision"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/user"
+)
+
+// TodoRevisionCreate is the builder for creating a TodoRevision entity.
+type TodoRevisionCreate struct {
+	config
+	mutation *TodoRevisionMutation
+	hooks    []Hook
+}
+
+// SetTodoID sets the "todoID" field.
+func (_c *TodoRevisionCreate) SetTodoID(v int) *TodoRevisionCreate {
+	_c.mutation.SetTodoID(v)
+	return _c
+}
+
+// SetActorID sets the "actorID" field.
+func (_c *TodoRevisionCreate) SetActorID(v int) *TodoRevisionCreate {
+	_c.mutation.SetActorID(v)
+	return _c
+}
+
+// SetNillableActorID sets the "actorID" field if the given value is not nil.
+func (_c *TodoRevisionCreate) SetNillableActorID(v *int) *TodoRevisionCreate {
+	if v != nil {
+		_c.SetActorID(*v)
+	}
+	return _c
+}
+
+// SetOperation sets the "operation" field.
+func (_c *TodoRevisionCreate) SetOperation(v todorevision.Operation) *TodoRevisionCreate {
+	_c.mutation.SetOperation(v)
+	return _c
+}
+
+// SetFields sets the "fields" field.
+func (_c *TodoRevisionCreate) SetFields(v []string) *TodoRevisionCreate {
+	_c.mutation.SetFields(v)
+	return _c
+}
+
+// SetOldValues sets the "oldValues" field.
+func (_c *TodoRevisionCreate) SetOldValues(v map[string]interface{}) *TodoRevisionCreate {
+	_c.mutation.SetOldValues(v)
+	return _c
+}
+
+// SetNewValues sets the "newValues" field.
+func (_c *TodoRevisionCreate) SetNewValues(v map[string]interface{}) *TodoRevisionCreate {
+	_c.mutation.SetNewValues(v)
+	return _c
+}
+
+// SetCreatedAt sets the "createdAt" field.
+func (_c *TodoRevisionCreate) SetCreatedAt(v time.Time) *TodoRevisionCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "createdAt" field if the given value is not nil.
+func (_c *TodoRevisionCreate) SetNillableCreatedAt(v *time.Time) *TodoRevisionCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetTodo sets the "todo" edge to the Todo entity.
+func (_c *TodoRevisionCreate) SetTodo(v *Todo) *TodoRevisionCreate {
+	return _c.SetTodoID(v.ID)
+}
+
+// SetActor sets the "actor" edge to the User entity.
+func (_c *TodoRevisionCreate) SetActor(v *User) *TodoRevisionCreate {
+	return _c.SetActorID(v.ID)
+}
+
+// Mutation returns the TodoRevisionMutation object of the builder.
+func (_c *TodoRevisionCreate) Mutation() *TodoRevisionMutation {
+	return _c.mutation
+}
+
+// Save creates the TodoRevision in the database.
+func (_c *TodoRevisionCreate) Save(ctx context.Context) (*TodoRevision, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *TodoRevisionCreate) SaveX(ctx context.Context) *TodoRevision {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *TodoRevisionCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *TodoRevisionCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *TodoRevisionCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := todorevision.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *TodoRevisionCreate) check() error {
+	if _, ok := _c.mutation.TodoID(); !ok {
+		return &ValidationError{Name: "todoID", err: errors.New(`ent: missing required field "TodoRevision.todoID"`)}
+	}
+	if _, ok := _c.mutation.Operation(); !ok {
+		return &ValidationError{Name: "operation", err: errors.New(`ent: missing required field "TodoRevision.operation"`)}
+	}
+	if v, ok := _c.mutation.Operation(); ok {
+		if err := todorevision.OperationValidator(v); err != nil {
+			return &ValidationError{Name: "operation", err: fmt.Errorf(`ent: validator failed for field "TodoRevision.operation": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.GetFields(); !ok {
+		return &ValidationError{Name: "fields", err: errors.New(`ent: missing required field "TodoRevision.fields"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "createdAt", err: errors.New(`ent: missing required field "TodoRevision.createdAt"`)}
+	}
+	if len(_c.mutation.TodoIDs()) == 0 {
+		return &ValidationError{Name: "todo", err: errors.New(`ent: missing required edge "TodoRevision.todo"`)}
+	}
+	return nil
+}
+
+func (_c *TodoRevisionCreate) sqlSave(ctx context.Context) (*TodoRevision, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *TodoRevisionCreate) createSpec() (*TodoRevision, *sqlgraph.CreateSpec) {
+	var (
+		_node = &TodoRevision{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(todorevision.Table, sqlgraph.NewFieldSpec(todorevision.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.Operation(); ok {
+		_spec.SetField(todorevision.FieldOperation, field.TypeEnum, value)
+		_node.Operation = value
+	}
+	if value, ok := _c.mutation.GetFields(); ok {
+		_spec.SetField(todorevision.FieldFields, field.TypeJSON, value)
+		_node.Fields = value
+	}
+	if value, ok := _c.mutation.OldValues(); ok {
+		_spec.SetField(todorevision.FieldOldValues, field.TypeJSON, value)
+		_node.OldValues = value
+	}
+	if value, ok := _c.mutation.NewValues(); ok {
+		_spec.SetField(todorevision.FieldNewValues, field.TypeJSON, value)
+		_node.NewValues = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(todorevision.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.TodoIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todorevision.TodoTable,
+			Columns: []string{todorevision.TodoColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.TodoID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.ActorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   todorevision.ActorTable,
+			Columns: []string{todorevision.ActorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.ActorID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// TodoRevisionCreateBulk is the builder for creating many TodoRevision entities in bulk.
+type TodoRevisionCreateBulk struct {
+	config
+	err      error
+	builders []*TodoRevisionCreate
+}
+
+// Save creates the TodoRevision entities in the database.
+func (_c *TodoRevisionCreateBulk) Save(ctx context.Context) ([]*TodoRevision, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*TodoRevision, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*TodoRevisionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *TodoRevisionCreateBulk) SaveX(ctx context.Context) []*TodoRevision {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *TodoRevisionCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *TodoRevisionCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
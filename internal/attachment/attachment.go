@@ -0,0 +1,134 @@
+// Package attachment manages the files attached to todos: their content is
+// kept in a storage.BlobStore, and their name, type, size and checksum in
+// Attachment entities.
+package attachment
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/attachment"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/storage"
+)
+
+// keyPrefix starts the storage keys of attachments.
+const keyPrefix = "attachments/"
+
+// File is the content of a file to attach.
+type File struct {
+	// Filename is the name of the file. Directories, which some browsers
+	// send along, are left out.
+	Filename string
+	// ContentType is the media type of the file. When empty or
+	// "application/octet-stream", it is detected from the content.
+	ContentType string
+	Size        int64
+	Content     io.Reader
+}
+
+// Attach stores f and attaches it to the todo with the given ID. The blob is
+// deleted again if the attachment cannot be saved.
+func Attach(ctx context.Context, client *ent.Client, blobs storage.BlobStore, todoID int, f File) (*ent.Attachment, error) {
+	key, err := newKey()
+	if err != nil {
+		return nil, err
+	}
+
+	content := bufio.NewReader(f.Content)
+	contentType := f.ContentType
+	if contentType == "" || contentType == "application/octet-stream" {
+		head, _ := content.Peek(512)
+		contentType = http.DetectContentType(head)
+	}
+	hash := sha256.New()
+	if err := blobs.Put(ctx, key, io.TeeReader(content, hash), f.Size, contentType); err != nil {
+		return nil, err
+	}
+
+	a, err := client.Attachment.Create().
+		SetTodoID(todoID).
+		SetFilename(filename(f.Filename)).
+		SetContentType(contentType).
+		SetSize(f.Size).
+		SetChecksum(hex.EncodeToString(hash.Sum(nil))).
+		SetStorageKey(key).
+		Save(ctx)
+	if err != nil {
+		deleteBlob(ctx, blobs, key)
+		return nil, err
+	}
+	return a, nil
+}
+
+// Delete removes a from its todo, and its blob from blobs.
+func Delete(ctx context.Context, client *ent.Client, blobs storage.BlobStore, a *ent.Attachment) error {
+	if err := client.Attachment.DeleteOne(a).Exec(ctx); err != nil {
+		return err
+	}
+	deleteBlob(ctx, blobs, a.StorageKey)
+	return nil
+}
+
+// DeleteAll removes the attachments of the todos with the given IDs, and
+// their blobs from blobs. It is meant for todos about to be deleted for good.
+func DeleteAll(ctx context.Context, client *ent.Client, blobs storage.BlobStore, todoIDs ...int) error {
+	attachments, err := client.Attachment.Query().
+		Where(attachment.TodoIDIn(todoIDs...)).
+		All(ctx)
+	if err != nil || len(attachments) == 0 {
+		return err
+	}
+	ids := make([]int, len(attachments))
+	for i, a := range attachments {
+		ids[i] = a.ID
+	}
+	if _, err := client.Attachment.Delete().Where(attachment.IDIn(ids...)).Exec(ctx); err != nil {
+		return err
+	}
+	for _, a := range attachments {
+		deleteBlob(ctx, blobs, a.StorageKey)
+	}
+	return nil
+}
+
+// deleteBlob deletes the blob of an attachment that no longer exists. A
+// failure leaves an unreferenced blob behind, which is only logged.
+func deleteBlob(ctx context.Context, blobs storage.BlobStore, key string) {
+	if err := blobs.Delete(ctx, key); err != nil {
+		log.Printf("attachment: deleting blob %s: %v", key, err)
+	}
+}
+
+// newKey returns a random storage key.
+func newKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return keyPrefix + hex.EncodeToString(b), nil
+}
+
+// filename returns the base name of name, as sent by a browser, or "file"
+// when it has none.
+func filename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name))
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	return name
+}
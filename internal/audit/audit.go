@@ -0,0 +1,316 @@
+// Package audit records the history of todos: every change to a todo is
+// saved as a TodoRevision holding who made it and the old and new values of
+// the fields it changed, from which Revert restores earlier states.
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/hook"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todorevision"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/softdelete"
+)
+
+// Fields lists the todo fields whose changes are recorded. Positions, set by
+// moves and by the server when it spreads positions, and the bookkeeping of
+// the reminder scheduler are left out.
+var Fields = []string{
+	todo.FieldTitle,
+	todo.FieldDescription,
+	todo.FieldCompleted,
+	todo.FieldPriority,
+	todo.FieldProjectID,
+	todo.FieldParentID,
+	todo.FieldDueAt,
+	todo.FieldRemindAt,
+	todo.FieldRecurrence,
+}
+
+// value returns the value of field in t as recorded in revisions: unset
+// fields are nil and times are RFC 3339 strings.
+func value(t *ent.Todo, field string) any {
+	switch field {
+	case todo.FieldTitle:
+		return t.Title
+	case todo.FieldDescription:
+		return optionalString(t.Description)
+	case todo.FieldCompleted:
+		return t.Completed
+	case todo.FieldPriority:
+		return t.Priority.String()
+	case todo.FieldProjectID:
+		return optionalID(t.ProjectID)
+	case todo.FieldParentID:
+		return optionalID(t.ParentID)
+	case todo.FieldDueAt:
+		return optionalTime(t.DueAt)
+	case todo.FieldRemindAt:
+		return optionalTime(t.RemindAt)
+	case todo.FieldRecurrence:
+		return optionalString(t.Recurrence)
+	}
+	return nil
+}
+
+func optionalString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func optionalID(id int) any {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+func optionalTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// Hook is a todo mutation hook recording a revision for every todo created,
+// updated, moved to or restored from the trash. Permanent deletes are not
+// recorded: the revisions of a todo are deleted with it. Register it after
+// softdelete.Hook, which turns deletes into updates.
+func Hook(next ent.Mutator) ent.Mutator {
+	return hook.TodoFunc(func(ctx context.Context, m *ent.TodoMutation) (ent.Value, error) {
+		switch {
+		case m.Op().Is(ent.OpCreate):
+			return recordCreate(ctx, next, m)
+		case m.Op().Is(ent.OpUpdate | ent.OpUpdateOne):
+			return recordUpdate(ctx, next, m)
+		}
+		return next.Mutate(ctx, m)
+	})
+}
+
+func recordCreate(ctx context.Context, next ent.Mutator, m *ent.TodoMutation) (ent.Value, error) {
+	v, err := next.Mutate(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	t, ok := v.(*ent.Todo)
+	if !ok {
+		return v, nil
+	}
+
+	rev := newRevision(ctx, m.Client(), t.ID, todorevision.OperationCREATE)
+	fields := []string{}
+	values := make(map[string]any)
+	for _, f := range Fields {
+		if v := value(t, f); v != nil {
+			fields = append(fields, f)
+			values[f] = v
+		}
+	}
+	if err := rev.SetFields(fields).SetNewValues(values).Exec(ctx); err != nil {
+		return nil, fmt.Errorf("recording revision: %w", err)
+	}
+	return v, nil
+}
+
+func recordUpdate(ctx context.Context, next ent.Mutator, m *ent.TodoMutation) (ent.Value, error) {
+	op := todorevision.OperationUPDATE
+	if _, ok := m.DeletedAt(); ok {
+		op = todorevision.OperationDELETE
+	} else if m.DeletedAtCleared() {
+		op = todorevision.OperationRESTORE
+	}
+	if op == todorevision.OperationUPDATE && !changesFields(m) {
+		return next.Mutate(ctx, m)
+	}
+
+	ids, err := m.IDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Load the todos whatever the trash, to see those being restored.
+	before, err := m.Client().Todo.Query().
+		Where(todo.IDIn(ids...)).
+		All(softdelete.Skip(ctx))
+	if err != nil {
+		return nil, err
+	}
+	v, err := next.Mutate(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	after, err := m.Client().Todo.Query().
+		Where(todo.IDIn(ids...)).
+		All(softdelete.Skip(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	old := make(map[int]*ent.Todo, len(before))
+	for _, t := range before {
+		old[t.ID] = t
+	}
+	var revs []*ent.TodoRevisionCreate
+	for _, t := range after {
+		prev, ok := old[t.ID]
+		if !ok {
+			continue
+		}
+		fields := []string{}
+		oldValues, newValues := make(map[string]any), make(map[string]any)
+		for _, f := range Fields {
+			o, n := value(prev, f), value(t, f)
+			if !reflect.DeepEqual(o, n) {
+				fields = append(fields, f)
+				oldValues[f], newValues[f] = o, n
+			}
+		}
+		if op == todorevision.OperationUPDATE && len(fields) == 0 {
+			continue
+		}
+		revs = append(revs, newRevision(ctx, m.Client(), t.ID, op).
+			SetFields(fields).
+			SetOldValues(oldValues).
+			SetNewValues(newValues))
+	}
+	if len(revs) > 0 {
+		if err := m.Client().TodoRevision.CreateBulk(revs...).Exec(ctx); err != nil {
+			return nil, fmt.Errorf("recording revisions: %w", err)
+		}
+	}
+	return v, nil
+}
+
+// changesFields reports whether m sets or clears a recorded field.
+func changesFields(m *ent.TodoMutation) bool {
+	for _, f := range append(m.Fields(), m.ClearedFields()...) {
+		if slices.Contains(Fields, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// newRevision returns a builder for a revision of the todo made by the user
+// of ctx, if any.
+func newRevision(ctx context.Context, client *ent.Client, todoID int, op todorevision.Operation) *ent.TodoRevisionCreate {
+	rev := client.TodoRevision.Create().
+		SetTodoID(todoID).
+		SetOperation(op)
+	if p, ok := auth.FromContext(ctx); ok && p.User != nil {
+		rev = rev.SetActorID(p.User.ID)
+	}
+	return rev
+}
+
+// ErrUnknownField is returned by Revert for revisions of fields it cannot
+// restore.
+var ErrUnknownField = errors.New("revision changed an unknown field")
+
+// Revert sets in m, an update of the todo, the changes restoring its recorded
+// fields to their values right after the revision, by undoing the changes of
+// the later revisions. Like any change of the remind time, restoring it
+// clears the sent reminder. Checking the restored project and parent, and
+// completing the todo, is left to the caller. The revert is itself recorded
+// as a revision once m is saved; run both in a transaction so that no change
+// slips in between.
+func Revert(ctx context.Context, client *ent.Client, m *ent.TodoMutation, todoID, revisionID int) error {
+	_, err := client.TodoRevision.Query().
+		Where(todorevision.ID(revisionID), todorevision.TodoID(todoID)).
+		OnlyID(ctx)
+	if err != nil {
+		return err
+	}
+
+	later, err := client.TodoRevision.Query().
+		Where(todorevision.TodoID(todoID), todorevision.IDGT(revisionID)).
+		Order(ent.Asc(todorevision.FieldID)).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+	// The value of a field after the revision is its old value in the first
+	// later revision that changed it.
+	values := make(map[string]any)
+	for _, rev := range later {
+		for _, f := range rev.Fields {
+			if _, ok := values[f]; !ok {
+				values[f] = rev.OldValues[f]
+			}
+		}
+	}
+
+	for f, v := range values {
+		if err := set(m, f, v); err != nil {
+			return err
+		}
+	}
+	if _, ok := values[todo.FieldRemindAt]; ok {
+		m.ClearReminderSentAt()
+	}
+	return nil
+}
+
+// set sets field to v, a value decoded from a revision, in m.
+func set(m *ent.TodoMutation, field string, v any) error {
+	if v == nil {
+		switch field {
+		case todo.FieldTitle, todo.FieldCompleted, todo.FieldPriority:
+			return fmt.Errorf("%w: %s cannot be unset", ErrUnknownField, field)
+		}
+		if !slices.Contains(Fields, field) {
+			return fmt.Errorf("%w: %s", ErrUnknownField, field)
+		}
+		return m.ClearField(field)
+	}
+
+	switch field {
+	case todo.FieldTitle, todo.FieldDescription, todo.FieldRecurrence:
+		s, ok := v.(string)
+		if !ok {
+			break
+		}
+		return m.SetField(field, s)
+	case todo.FieldCompleted:
+		b, ok := v.(bool)
+		if !ok {
+			break
+		}
+		return m.SetField(field, b)
+	case todo.FieldPriority:
+		s, ok := v.(string)
+		if !ok {
+			break
+		}
+		return m.SetField(field, todo.Priority(s))
+	case todo.FieldProjectID, todo.FieldParentID:
+		// JSON numbers decode as float64.
+		n, ok := v.(float64)
+		if !ok {
+			break
+		}
+		return m.SetField(field, int(n))
+	case todo.FieldDueAt, todo.FieldRemindAt:
+		s, ok := v.(string)
+		if !ok {
+			break
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return fmt.Errorf("invalid %s in revision: %w", field, err)
+		}
+		return m.SetField(field, t)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownField, field)
+	}
+	return fmt.Errorf("invalid %s in revision: %v", field, v)
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/apikey"
+)
+
+// Scopes granted to API keys.
+const (
+	ScopeTodosRead  = "todos:read"
+	ScopeTodosWrite = "todos:write"
+)
+
+// Scopes lists the scopes API keys can be granted.
+var Scopes = []string{ScopeTodosRead, ScopeTodosWrite}
+
+// apiKeyLabel starts every API key, so leaked keys are easy to recognise.
+const apiKeyLabel = "todo"
+
+// lastUsedInterval is how stale the last-used time of a key may get before
+// authenticating with it records a new one.
+const lastUsedInterval = time.Minute
+
+// GenerateAPIKey returns a new API key, formatted "todo_<prefix>_<secret>",
+// together with its prefix and the hash to store.
+func GenerateAPIKey() (key, prefix, hash string, err error) {
+	b := make([]byte, 6+24)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	prefix = hex.EncodeToString(b[:6])
+	key = apiKeyLabel + "_" + prefix + "_" + hex.EncodeToString(b[6:])
+	return key, prefix, HashAPIKey(key), nil
+}
+
+// HashAPIKey returns the stored hash of key. API keys are random enough that
+// a fast hash suffices.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKey authenticates "Authorization: ApiKey" credentials against the API
+// keys of users. Revoked and expired keys are rejected.
+type APIKey struct {
+	Client *ent.Client
+}
+
+// Scheme implements Authenticator.
+func (APIKey) Scheme() string { return "ApiKey" }
+
+// Authenticate implements Authenticator.
+func (a APIKey) Authenticate(ctx context.Context, credentials string) (*Principal, error) {
+	parts := strings.Split(credentials, "_")
+	if len(parts) != 3 || parts[0] != apiKeyLabel {
+		return nil, ErrInvalidCredentials
+	}
+
+	k, err := a.Client.APIKey.Query().
+		Where(apikey.Prefix(parts[1])).
+		WithOwner().
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(HashAPIKey(credentials)), []byte(k.SecretHash)) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+	now := time.Now()
+	if k.RevokedAt != nil || (k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)) {
+		return nil, ErrInvalidCredentials
+	}
+
+	if k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) >= lastUsedInterval {
+		if err := a.Client.APIKey.UpdateOneID(k.ID).SetLastUsedAt(now).Exec(ctx); err != nil {
+			log.Printf("auth: recording use of API key %s: %v", k.Prefix, err)
+		}
+	}
+	return &Principal{User: k.Edges.Owner, APIKey: k}, nil
+}
@@ -0,0 +1,202 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`^todo_[0-9a-f]{12}_[0-9a-f]{48}$`).MatchString(key) {
+		t.Errorf("got key %q, want todo_<prefix>_<secret>", key)
+	}
+	if !strings.HasPrefix(key, "todo_"+prefix+"_") {
+		t.Errorf("got prefix %q for key %q", prefix, key)
+	}
+	if hash != auth.HashAPIKey(key) || strings.Contains(hash, prefix) {
+		t.Errorf("got hash %q for key %q", hash, key)
+	}
+	if other, _, _, _ := auth.GenerateAPIKey(); other == key {
+		t.Errorf("got key %q twice", key)
+	}
+}
+
+// newAPIKey stores a new todos:read API key of owner, letting change adjust
+// it first, and returns the key.
+func newAPIKey(t *testing.T, client *ent.Client, owner *ent.User, change func(*ent.APIKeyCreate)) string {
+	t.Helper()
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	create := client.APIKey.Create().
+		SetName("CI").
+		SetPrefix(prefix).
+		SetSecretHash(hash).
+		SetScopes([]string{auth.ScopeTodosRead}).
+		SetOwner(owner)
+	if change != nil {
+		change(create)
+	}
+	create.SaveX(context.Background())
+	return key
+}
+
+func TestAPIKeyAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		// key returns the credentials to authenticate with, given the key
+		// stored for alice.
+		key     func(key string) string
+		change  func(*ent.APIKeyCreate)
+		wantErr error
+	}{
+		{name: "valid"},
+		{name: "expiring later", change: func(c *ent.APIKeyCreate) { c.SetExpiresAt(time.Now().Add(time.Hour)) }},
+		{name: "expired", change: func(c *ent.APIKeyCreate) { c.SetExpiresAt(time.Now().Add(-time.Second)) }, wantErr: auth.ErrInvalidCredentials},
+		{name: "revoked", change: func(c *ent.APIKeyCreate) { c.SetRevokedAt(time.Now()) }, wantErr: auth.ErrInvalidCredentials},
+		{name: "wrong secret", key: func(key string) string { return key[:len(key)-1] + "x" }, wantErr: auth.ErrInvalidCredentials},
+		{name: "unknown prefix", key: func(key string) string { return "todo_000000000000" + key[17:] }, wantErr: auth.ErrInvalidCredentials},
+		{name: "wrong label", key: func(key string) string { return "todos" + key[4:] }, wantErr: auth.ErrInvalidCredentials},
+		{name: "missing secret", key: func(key string) string { return key[:17] }, wantErr: auth.ErrInvalidCredentials},
+		{name: "extra part", key: func(key string) string { return key + "_x" }, wantErr: auth.ErrInvalidCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t)
+			alice := client.User.Create().SetEmail("alice@example.com").SetDisplayName("Alice").SaveX(ctx)
+			key := newAPIKey(t, client, alice, tt.change)
+			if tt.key != nil {
+				key = tt.key(key)
+			}
+
+			p, err := auth.APIKey{Client: client}.Authenticate(ctx, key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			used := client.APIKey.Query().OnlyX(ctx).LastUsedAt
+			if err != nil {
+				if used != nil {
+					t.Errorf("got last use %v of a rejected key", used)
+				}
+				return
+			}
+			if p.User.ID != alice.ID || p.APIKey == nil {
+				t.Errorf("got principal %+v, want alice with the key", p)
+			}
+			if used == nil {
+				t.Error("got no last use")
+			}
+		})
+	}
+}
+
+func TestAPIKeyLastUsed(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	alice := client.User.Create().SetEmail("alice@example.com").SetDisplayName("Alice").SaveX(ctx)
+	recent := time.Now().Add(-10 * time.Second).Truncate(time.Second)
+	stale := time.Now().Add(-time.Hour).Truncate(time.Second)
+	recentKey := newAPIKey(t, client, alice, func(c *ent.APIKeyCreate) { c.SetName("recent").SetLastUsedAt(recent) })
+	staleKey := newAPIKey(t, client, alice, func(c *ent.APIKeyCreate) { c.SetName("stale").SetLastUsedAt(stale) })
+
+	a := auth.APIKey{Client: client}
+	for _, key := range []string{recentKey, staleKey} {
+		if _, err := a.Authenticate(ctx, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, k := range client.APIKey.Query().AllX(ctx) {
+		switch {
+		case k.Name == "recent" && !k.LastUsedAt.Equal(recent):
+			t.Errorf("got last use %v of a recently used key, want %v", k.LastUsedAt, recent)
+		case k.Name == "stale" && !k.LastUsedAt.After(stale):
+			t.Errorf("got last use %v of a stale key, want a later one", k.LastUsedAt)
+		}
+	}
+}
+
+func TestHasScope(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal auth.Principal
+		want      bool
+	}{
+		{name: "password or token", principal: auth.Principal{}, want: true},
+		{name: "granted scope", principal: auth.Principal{APIKey: &ent.APIKey{Scopes: []string{auth.ScopeTodosRead, auth.ScopeTodosWrite}}}, want: true},
+		{name: "other scope", principal: auth.Principal{APIKey: &ent.APIKey{Scopes: []string{auth.ScopeTodosRead}}}},
+		{name: "no scopes", principal: auth.Principal{APIKey: &ent.APIKey{}}},
+	}
+	for _, tt := range tests {
+		if got := tt.principal.HasScope(auth.ScopeTodosWrite); got != tt.want {
+			t.Errorf("%s: got %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	alice := client.User.Create().SetEmail("alice@example.com").SetDisplayName("Alice").SaveX(ctx)
+	readKey := newAPIKey(t, client, alice, nil)
+	writeKey := newAPIKey(t, client, alice, func(c *ent.APIKeyCreate) { c.SetScopes([]string{auth.ScopeTodosWrite}) })
+	revokedKey := newAPIKey(t, client, alice, func(c *ent.APIKeyCreate) { c.SetScopes(auth.Scopes).SetRevokedAt(time.Now()) })
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantChallenge string
+	}{
+		{name: "granted scope", authorization: "ApiKey " + writeKey, wantStatus: http.StatusOK},
+		{name: "missing scope", authorization: "ApiKey " + readKey, wantStatus: http.StatusForbidden, wantChallenge: `ApiKey realm="todos", error="insufficient_scope", scope="todos:write"`},
+		{name: "revoked key", authorization: "ApiKey " + revokedKey, wantStatus: http.StatusUnauthorized, wantChallenge: `Basic realm="todos"`},
+		{name: "no credentials", wantStatus: http.StatusUnauthorized, wantChallenge: `Basic realm="todos"`},
+	}
+	h := auth.Middleware(auth.APIKey{Client: client})(auth.Required(auth.RequireScope(auth.ScopeTodosWrite)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))))
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/todos", nil)
+		if tt.authorization != "" {
+			r.Header.Set("Authorization", tt.authorization)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: got status %d, want %d", tt.name, w.Code, tt.wantStatus)
+		}
+		if got := w.Header().Get("WWW-Authenticate"); got != tt.wantChallenge {
+			t.Errorf("%s: got challenge %q, want %q", tt.name, got, tt.wantChallenge)
+		}
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	readOnly := &auth.Principal{User: &ent.User{ID: 1}, APIKey: &ent.APIKey{Scopes: []string{auth.ScopeTodosRead}}}
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{name: "granted scope", ctx: auth.NewContext(context.Background(), &auth.Principal{User: &ent.User{ID: 1}})},
+		{name: "missing scope", ctx: auth.NewContext(context.Background(), readOnly), wantErr: auth.ErrForbidden},
+		{name: "anonymous", ctx: context.Background(), wantErr: auth.ErrUnauthenticated},
+	}
+	for _, tt := range tests {
+		if _, err := auth.Authorize(tt.ctx, auth.ScopeTodosWrite); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: got error %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Package auth authenticates HTTP requests and carries the authenticated
+// principal through the request context.
+package auth
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+)
+
+var (
+	// ErrUnauthenticated is returned when a request carries no valid credentials.
+	ErrUnauthenticated = errors.New("authentication required")
+	// ErrInvalidCredentials is returned by authenticators rejecting credentials.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrForbidden is returned when the principal lacks a required scope.
+	ErrForbidden = errors.New("insufficient scope")
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	User *ent.User
+	// APIKey is the API key the request authenticated with, if any.
+	APIKey *ent.APIKey
+}
+
+// HasScope reports whether p may act within scope. Only API keys are
+// restricted to the scopes they were granted.
+func (p *Principal) HasScope(scope string) bool {
+	return p.APIKey == nil || slices.Contains(p.APIKey.Scopes, scope)
+}
+
+type (
+	principalKey struct{}
+	failureKey   struct{}
+)
+
+// NewContext returns a copy of ctx carrying p.
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal carried by ctx, if any.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+// UserFromContext returns the authenticated user of ctx. It returns the
+// error credentials were rejected with, or ErrUnauthenticated when the
+// request carried none.
+func UserFromContext(ctx context.Context) (*ent.User, error) {
+	p, ok := FromContext(ctx)
+	if !ok {
+		if err, ok := ctx.Value(failureKey{}).(error); ok {
+			return nil, err
+		}
+		return nil, ErrUnauthenticated
+	}
+	return p.User, nil
+}
+
+// Authenticator verifies the credentials of one Authorization scheme.
+type Authenticator interface {
+	// Scheme is the Authorization header scheme handled, e.g. "Basic".
+	Scheme() string
+	// Authenticate returns the principal the credentials belong to.
+	Authenticate(ctx context.Context, credentials string) (*Principal, error)
+}
+
+// Middleware authenticates the Authorization header of each request with the
+// authenticator of its scheme and stores the principal in the request
+// context. Requests without valid credentials pass through anonymously, with
+// the reason recorded for UserFromContext; use Required to reject them.
+func Middleware(authenticators ...Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, err := Authenticate(r.Context(), r.Header.Get("Authorization"), authenticators...)
+			if err != nil && !errors.Is(err, ErrInvalidCredentials) {
+				log.Printf("auth: authenticating request: %v", err)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Authenticate verifies the Authorization header value authorization with
+// the authenticator of its scheme. It returns ctx carrying the principal, or
+// the failure that UserFromContext reports. An empty authorization leaves
+// ctx unchanged.
+func Authenticate(ctx context.Context, authorization string, authenticators ...Authenticator) (context.Context, error) {
+	if authorization == "" {
+		return ctx, nil
+	}
+	scheme, credentials, _ := strings.Cut(authorization, " ")
+	for _, a := range authenticators {
+		if !strings.EqualFold(a.Scheme(), scheme) {
+			continue
+		}
+		p, err := a.Authenticate(ctx, strings.TrimSpace(credentials))
+		if err != nil {
+			return context.WithValue(ctx, failureKey{}, err), err
+		}
+		return NewContext(ctx, p), nil
+	}
+	return context.WithValue(ctx, failureKey{}, ErrInvalidCredentials), ErrInvalidCredentials
+}
+
+// Authorize returns the authenticated user of ctx if its principal has
+// scope, ErrForbidden if it lacks it, or the error of UserFromContext.
+func Authorize(ctx context.Context, scope string) (*ent.User, error) {
+	u, err := UserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p, _ := FromContext(ctx); !p.HasScope(scope) {
+		return nil, ErrForbidden
+	}
+	return u, nil
+}
+
+// Required rejects requests without an authenticated principal with
+// 401 Unauthorized.
+func Required(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := UserFromContext(r.Context()); err != nil {
+			w.Header().Add("WWW-Authenticate", `Basic realm="todos"`)
+			if errors.Is(err, ErrInvalidCredentials) {
+				w.Header().Add("WWW-Authenticate", `Bearer realm="todos", error="invalid_token"`)
+				http.Error(w, "Invalid credentials", http.StatusUnauthorized)
+				return
+			}
+			w.Header().Add("WWW-Authenticate", `Bearer realm="todos"`)
+			w.Header().Add("WWW-Authenticate", `ApiKey realm="todos"`)
+			http.Error(w, "Authentication required", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireScope rejects requests whose principal lacks scope with
+// 403 Forbidden. Mount it behind Required.
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if p, ok := FromContext(r.Context()); ok && !p.HasScope(scope) {
+				w.Header().Set("WWW-Authenticate", `ApiKey realm="todos", error="insufficient_scope", scope="`+scope+`"`)
+				http.Error(w, "Insufficient scope", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
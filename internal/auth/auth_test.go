@@ -0,0 +1,97 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+)
+
+// stubAuth accepts the credentials "good" of its scheme for user 1.
+type stubAuth string
+
+func (s stubAuth) Scheme() string { return string(s) }
+
+func (stubAuth) Authenticate(_ context.Context, credentials string) (*auth.Principal, error) {
+	if credentials != "good" {
+		return nil, auth.ErrInvalidCredentials
+	}
+	return &auth.Principal{User: &ent.User{ID: 1}}, nil
+}
+
+func TestRequired(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantChallenge []string
+	}{
+		{name: "authenticated", authorization: "Bearer good", wantStatus: http.StatusOK},
+		{name: "scheme in any case", authorization: "bearer good", wantStatus: http.StatusOK},
+		{
+			name:          "no credentials",
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: []string{`Basic realm="todos"`, `Bearer realm="todos"`, `ApiKey realm="todos"`},
+		},
+		{
+			name:          "invalid credentials",
+			authorization: "Bearer bad",
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: []string{`Basic realm="todos"`, `Bearer realm="todos", error="invalid_token"`},
+		},
+		{
+			name:          "unknown scheme",
+			authorization: "Digest good",
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: []string{`Basic realm="todos"`, `Bearer realm="todos", error="invalid_token"`},
+		},
+	}
+	h := auth.Middleware(stubAuth("Bearer"))(auth.Required(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := auth.UserFromContext(r.Context()); err != nil {
+			t.Errorf("handler reached without a user: %v", err)
+		}
+	})))
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/todos", nil)
+		if tt.authorization != "" {
+			r.Header.Set("Authorization", tt.authorization)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: got status %d, want %d", tt.name, w.Code, tt.wantStatus)
+		}
+		if got := w.Header().Values("WWW-Authenticate"); !slices.Equal(got, tt.wantChallenge) {
+			t.Errorf("%s: got challenges %q, want %q", tt.name, got, tt.wantChallenge)
+		}
+	}
+}
+
+func TestUserFromContext(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name          string
+		authorization string
+		wantErr       error
+	}{
+		{name: "authenticated", authorization: "Bearer good"},
+		{name: "no credentials", wantErr: auth.ErrUnauthenticated},
+		{name: "invalid credentials", authorization: "Bearer bad", wantErr: auth.ErrInvalidCredentials},
+		{name: "unknown scheme", authorization: "Digest good", wantErr: auth.ErrInvalidCredentials},
+	}
+	for _, tt := range tests {
+		ctx, _ := auth.Authenticate(ctx, tt.authorization, stubAuth("Bearer"))
+		u, err := auth.UserFromContext(ctx)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: got error %v, want %v", tt.name, err, tt.wantErr)
+		}
+		if err == nil && u.ID != 1 {
+			t.Errorf("%s: got user %d, want 1", tt.name, u.ID)
+		}
+	}
+}
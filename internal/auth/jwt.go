@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/user"
+)
+
+// KeySet holds the keys JWTs are verified with, by key ID: []byte secrets
+// for HS256 and *rsa.PublicKey keys for RS256. The key stored under the empty
+// ID verifies tokens whose "kid" header matches no other key.
+type KeySet map[string]any
+
+func (ks KeySet) keyFunc(t *jwt.Token) (any, error) {
+	kid, _ := t.Header["kid"].(string)
+	if key, ok := ks[kid]; ok {
+		return key, nil
+	}
+	if key, ok := ks[""]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key ID %q", kid)
+}
+
+// jwk is a JSON Web Key. Only the members of RSA and symmetric keys are
+// decoded.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+// LoadJWKS reads the RSA and symmetric signing keys of the JSON Web Key Set
+// file at path.
+func LoadJWKS(path string) (KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("parsing JWKS: %w", err)
+	}
+
+	ks := make(KeySet)
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		switch k.Kty {
+		case "RSA":
+			n, err := base64.RawURLEncoding.DecodeString(k.N)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: invalid modulus: %w", k.Kid, err)
+			}
+			e, err := base64.RawURLEncoding.DecodeString(k.E)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: invalid exponent: %w", k.Kid, err)
+			}
+			ks[k.Kid] = &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			}
+		case "oct":
+			secret, err := base64.RawURLEncoding.DecodeString(k.K)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: invalid secret: %w", k.Kid, err)
+			}
+			ks[k.Kid] = secret
+		}
+	}
+	if len(ks) == 0 {
+		return nil, fmt.Errorf("JWKS %s contains no signing keys", path)
+	}
+	return ks, nil
+}
+
+// LoadRSAPublicKey reads the PEM encoded RSA public key file at path.
+func LoadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.ParseRSAPublicKeyFromPEM(data)
+}
+
+// claims are the JWT claims read by JWT.
+type claims struct {
+	jwt.RegisteredClaims
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// JWT authenticates "Authorization: Bearer" JSON Web Tokens signed with HS256
+// or RS256. The "sub" claim is matched against the subject of users; the user
+// of an unknown subject is created from the "email" and "name" claims.
+type JWT struct {
+	Client *ent.Client
+	Keys   KeySet
+	// Issuer and Audience, when set, must match the "iss" and "aud" claims.
+	Issuer   string
+	Audience string
+}
+
+// Scheme implements Authenticator.
+func (JWT) Scheme() string { return "Bearer" }
+
+// Authenticate implements Authenticator.
+func (a JWT) Authenticate(ctx context.Context, credentials string) (*Principal, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"HS256", "RS256"}),
+		jwt.WithExpirationRequired(),
+	}
+	if a.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(a.Issuer))
+	}
+	if a.Audience != "" {
+		opts = append(opts, jwt.WithAudience(a.Audience))
+	}
+
+	var c claims
+	if _, err := jwt.ParseWithClaims(credentials, &c, a.Keys.keyFunc, opts...); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	if c.Subject == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	u, err := a.Client.User.Query().Where(user.Subject(c.Subject)).Only(ctx)
+	if ent.IsNotFound(err) {
+		u, err = a.provision(ctx, c)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Principal{User: u}, nil
+}
+
+// provision creates the user signing in with c for the first time.
+func (a JWT) provision(ctx context.Context, c claims) (*ent.User, error) {
+	if c.Email == "" {
+		return nil, ErrInvalidCredentials
+	}
+	name := c.Name
+	if name == "" {
+		name = c.Email
+	}
+	u, err := a.Client.User.Create().
+		SetSubject(c.Subject).
+		SetEmail(c.Email).
+		SetDisplayName(name).
+		Save(ctx)
+	if ent.IsConstraintError(err) {
+		// A concurrent request of the same subject created the user first.
+		return a.Client.User.Query().Where(user.Subject(c.Subject)).Only(ctx)
+	}
+	return u, err
+}
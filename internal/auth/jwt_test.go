@@ -0,0 +1,222 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/enttest"
+	_ "github.com/ishidatakuo/graphql-ent-atlas/ent/runtime"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/user"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+)
+
+func newTestClient(t *testing.T) *ent.Client {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// sign returns the token of claims signed with key by method, under the key
+// ID kid if it is set.
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// writeJWKS writes a JSON Web Key Set holding the public key of rsaKey under
+// the ID "rsa", the secret under the ID "hs" and an encryption key, which is
+// ignored.
+func writeJWKS(t *testing.T, rsaKey *rsa.PrivateKey, secret []byte) string {
+	t.Helper()
+	b64 := base64.RawURLEncoding.EncodeToString
+	data := `{"keys": [
+		{"kty": "RSA", "kid": "rsa", "use": "sig", "n": "` + b64(rsaKey.N.Bytes()) + `", "e": "` + b64(big.NewInt(int64(rsaKey.E)).Bytes()) + `"},
+		{"kty": "oct", "kid": "hs", "k": "` + b64(secret) + `"},
+		{"kty": "oct", "kid": "enc", "use": "enc", "k": "` + b64([]byte("encryption")) + `"}
+	]}`
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestJWTAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: must(x509.MarshalPKIXPublicKey(&rsaKey.PublicKey))})
+
+	jwks, err := auth.LoadJWKS(writeJWKS(t, rsaKey, secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := jwks["enc"]; ok || len(jwks) != 2 {
+		t.Errorf("got key IDs %v, want rsa and hs", keys(jwks))
+	}
+
+	exp := time.Now().Add(time.Hour).Unix()
+	valid := jwt.MapClaims{"sub": "alice", "exp": exp, "iss": "https://id.example.com", "aud": "todos"}
+	with := func(name string, value any) jwt.MapClaims {
+		c := jwt.MapClaims{}
+		for k, v := range valid {
+			c[k] = v
+		}
+		if value == nil {
+			delete(c, name)
+		} else {
+			c[name] = value
+		}
+		return c
+	}
+	hs := auth.KeySet{"": secret}
+	rs := auth.KeySet{"": &rsaKey.PublicKey}
+
+	tests := []struct {
+		name  string
+		keys  auth.KeySet
+		token string
+		// issuer and audience configure the authenticator.
+		issuer, audience string
+		wantErr          error
+	}{
+		{name: "HS256", keys: hs, token: sign(t, jwt.SigningMethodHS256, secret, "", valid)},
+		{name: "RS256", keys: rs, token: sign(t, jwt.SigningMethodRS256, rsaKey, "", valid)},
+		{name: "JWKS RSA key", keys: jwks, token: sign(t, jwt.SigningMethodRS256, rsaKey, "rsa", valid)},
+		{name: "JWKS secret", keys: jwks, token: sign(t, jwt.SigningMethodHS256, secret, "hs", valid)},
+		{name: "JWKS unknown key ID", keys: jwks, token: sign(t, jwt.SigningMethodRS256, rsaKey, "old", valid), wantErr: auth.ErrInvalidCredentials},
+		{name: "JWKS key of another ID", keys: jwks, token: sign(t, jwt.SigningMethodHS256, secret, "rsa", valid), wantErr: auth.ErrInvalidCredentials},
+		{name: "default key for unknown key IDs", keys: auth.KeySet{"rsa": &otherKey.PublicKey, "": secret}, token: sign(t, jwt.SigningMethodHS256, secret, "old", valid)},
+		{name: "wrong secret", keys: hs, token: sign(t, jwt.SigningMethodHS256, []byte("another secret"), "", valid), wantErr: auth.ErrInvalidCredentials},
+		{name: "wrong RSA key", keys: rs, token: sign(t, jwt.SigningMethodRS256, otherKey, "", valid), wantErr: auth.ErrInvalidCredentials},
+		{name: "HS256 signed with the RSA public key", keys: rs, token: sign(t, jwt.SigningMethodHS256, publicPEM, "", valid), wantErr: auth.ErrInvalidCredentials},
+		{name: "RS256 for a secret", keys: hs, token: sign(t, jwt.SigningMethodRS256, rsaKey, "", valid), wantErr: auth.ErrInvalidCredentials},
+		{name: "HS512", keys: hs, token: sign(t, jwt.SigningMethodHS512, secret, "", valid), wantErr: auth.ErrInvalidCredentials},
+		{name: "unsigned", keys: hs, token: sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", valid), wantErr: auth.ErrInvalidCredentials},
+		{name: "malformed", keys: hs, token: "not.a.token", wantErr: auth.ErrInvalidCredentials},
+		{name: "expired", keys: hs, token: sign(t, jwt.SigningMethodHS256, secret, "", with("exp", time.Now().Add(-time.Minute).Unix())), wantErr: auth.ErrInvalidCredentials},
+		{name: "no expiry", keys: hs, token: sign(t, jwt.SigningMethodHS256, secret, "", with("exp", nil)), wantErr: auth.ErrInvalidCredentials},
+		{name: "no subject", keys: hs, token: sign(t, jwt.SigningMethodHS256, secret, "", with("sub", nil)), wantErr: auth.ErrInvalidCredentials},
+		{name: "issuer", keys: hs, token: sign(t, jwt.SigningMethodHS256, secret, "", valid), issuer: "https://id.example.com", audience: "todos"},
+		{name: "wrong issuer", keys: hs, token: sign(t, jwt.SigningMethodHS256, secret, "", with("iss", "https://evil.example.com")), issuer: "https://id.example.com", wantErr: auth.ErrInvalidCredentials},
+		{name: "no issuer", keys: hs, token: sign(t, jwt.SigningMethodHS256, secret, "", with("iss", nil)), issuer: "https://id.example.com", wantErr: auth.ErrInvalidCredentials},
+		{name: "audience among others", keys: hs, token: sign(t, jwt.SigningMethodHS256, secret, "", with("aud", []string{"billing", "todos"})), audience: "todos"},
+		{name: "wrong audience", keys: hs, token: sign(t, jwt.SigningMethodHS256, secret, "", with("aud", "billing")), audience: "todos", wantErr: auth.ErrInvalidCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t)
+			alice := client.User.Create().SetEmail("alice@example.com").SetDisplayName("Alice").SetSubject("alice").SaveX(ctx)
+
+			a := auth.JWT{Client: client, Keys: tt.keys, Issuer: tt.issuer, Audience: tt.audience}
+			p, err := a.Authenticate(ctx, tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && p.User.ID != alice.ID {
+				t.Errorf("got user %d, want %d", p.User.ID, alice.ID)
+			}
+		})
+	}
+}
+
+func TestJWTProvision(t *testing.T) {
+	ctx := context.Background()
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	exp := time.Now().Add(time.Hour).Unix()
+	tests := []struct {
+		name    string
+		claims  jwt.MapClaims
+		want    string
+		wantErr error
+	}{
+		{name: "name", claims: jwt.MapClaims{"sub": "bob", "exp": exp, "email": "bob@example.com", "name": "Bob"}, want: "bob@example.com Bob"},
+		{name: "no name", claims: jwt.MapClaims{"sub": "bob", "exp": exp, "email": "bob@example.com"}, want: "bob@example.com bob@example.com"},
+		{name: "no email", claims: jwt.MapClaims{"sub": "bob", "exp": exp, "name": "Bob"}, wantErr: auth.ErrInvalidCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t)
+			a := auth.JWT{Client: client, Keys: auth.KeySet{"": secret}}
+			token := sign(t, jwt.SigningMethodHS256, secret, "", tt.claims)
+
+			p, err := a.Authenticate(ctx, token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if n := client.User.Query().CountX(ctx); n != 0 {
+					t.Errorf("got %d users, want none", n)
+				}
+				return
+			}
+			if got := p.User.Email + " " + p.User.DisplayName; got != tt.want || p.User.Subject == nil || *p.User.Subject != "bob" {
+				t.Errorf("got user %q with subject %v, want %q with subject bob", got, p.User.Subject, tt.want)
+			}
+
+			// Signing in again finds the user.
+			again, err := a.Authenticate(ctx, token)
+			if err != nil || again.User.ID != p.User.ID {
+				t.Errorf("signing in again: got user %v, error %v, want user %d", again, err, p.User.ID)
+			}
+			if n := client.User.Query().Where(user.Subject("bob")).CountX(ctx); n != 1 {
+				t.Errorf("got %d users, want 1", n)
+			}
+		})
+	}
+}
+
+func TestLoadJWKSWithoutSigningKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, []byte(`{"keys": [{"kty": "oct", "use": "enc", "k": "c2VjcmV0"}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := auth.LoadJWKS(path); err == nil {
+		t.Error("got no error for a JWKS without signing keys")
+	}
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func keys(ks auth.KeySet) []string {
+	ids := make([]string, 0, len(ks))
+	for id := range ks {
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,110 @@
+// Package dependency maintains the "blocked by" dependencies between todos:
+// a todo cannot be completed while a todo it is blocked by is open, and the
+// dependencies never form a cycle.
+package dependency
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/lock"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/softdelete"
+)
+
+var (
+	// ErrCycle is returned when a dependency would make a todo wait for
+	// itself.
+	ErrCycle = errors.New("dependency would create a cycle")
+	// ErrBlocked is returned when completing a todo that is blocked by an
+	// open todo.
+	ErrBlocked = errors.New("todo is blocked by open todos")
+)
+
+// Add makes the todo blockedID wait for the todo blockerID, rejecting
+// dependencies that would form a cycle with ErrCycle. Run it in a
+// transaction: it locks the owners of both todos, so that concurrent
+// additions cannot form a cycle together.
+func Add(ctx context.Context, client *ent.Client, blockedID, blockerID int) error {
+	if blockedID == blockerID {
+		return ErrCycle
+	}
+	if err := lock.Owners(ctx, client, blockedID, blockerID); err != nil {
+		return err
+	}
+	// The new dependency closes a cycle if the blocked todo already blocks
+	// the blocker, directly or not.
+	blocked, err := Blocked(ctx, client, blockedID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(blocked, blockerID) {
+		return ErrCycle
+	}
+
+	exists, err := client.Todo.Query().
+		Where(todo.ID(blockedID), todo.HasBlockedByWith(todo.ID(blockerID))).
+		Exist(ctx)
+	if err != nil || exists {
+		return err
+	}
+	return client.Todo.UpdateOneID(blockedID).
+		AddBlockedByIDs(blockerID).
+		Exec(ctx)
+}
+
+// Remove removes the dependency of the todo blockedID on the todo blockerID,
+// if any.
+func Remove(ctx context.Context, client *ent.Client, blockedID, blockerID int) error {
+	return client.Todo.UpdateOneID(blockedID).
+		RemoveBlockedByIDs(blockerID).
+		Exec(ctx)
+}
+
+// Blocked returns the IDs of the todos waiting for the todo, directly or not.
+// Todos in the trash are included, and so are the todos waiting for them, so
+// that restoring a todo cannot close a cycle.
+func Blocked(ctx context.Context, client *ent.Client, id int) ([]int, error) {
+	ctx = softdelete.Skip(ctx)
+	var ids []int
+	seen := map[int]bool{id: true}
+	for level := []int{id}; len(level) > 0; {
+		next, err := client.Todo.Query().
+			Where(todo.HasBlockedByWith(todo.IDIn(level...))).
+			IDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		level = level[:0]
+		for _, id := range next {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+				level = append(level, id)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// IsBlocked reports whether the todo waits for a todo that is not completed.
+// Todos in the trash block no todo.
+func IsBlocked(ctx context.Context, client *ent.Client, id int) (bool, error) {
+	return client.Todo.Query().
+		Where(todo.ID(id), todo.HasBlockedByWith(todo.Completed(false), todo.DeletedAtIsNil())).
+		Exist(ctx)
+}
+
+// CheckComplete returns ErrBlocked if the todo cannot be completed yet.
+func CheckComplete(ctx context.Context, client *ent.Client, id int) error {
+	blocked, err := IsBlocked(ctx, client, id)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrBlocked
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/apikey"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+)
+
+// apiKeyScopes maps APIKeyScope enum values to auth scopes.
+var apiKeyScopes = map[string]string{
+	"TODOS_READ":  auth.ScopeTodosRead,
+	"TODOS_WRITE": auth.ScopeTodosWrite,
+}
+
+// APIKey GraphQL resolver
+type APIKeyResolver struct {
+	key *ent.APIKey
+}
+
+func (r *APIKeyResolver) ID() graphql.ID {
+	return graphql.ID(strconv.Itoa(r.key.ID))
+}
+
+func (r *APIKeyResolver) Name() string {
+	return r.key.Name
+}
+
+func (r *APIKeyResolver) Prefix() string {
+	return r.key.Prefix
+}
+
+func (r *APIKeyResolver) Scopes() []string {
+	var scopes []string
+	for _, scope := range r.key.Scopes {
+		for value, s := range apiKeyScopes {
+			if s == scope {
+				scopes = append(scopes, value)
+			}
+		}
+	}
+	return scopes
+}
+
+func (r *APIKeyResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.key.CreatedAt}
+}
+
+func (r *APIKeyResolver) LastUsedAt() *graphql.Time {
+	return optionalTime(r.key.LastUsedAt)
+}
+
+func (r *APIKeyResolver) ExpiresAt() *graphql.Time {
+	return optionalTime(r.key.ExpiresAt)
+}
+
+func (r *APIKeyResolver) RevokedAt() *graphql.Time {
+	return optionalTime(r.key.RevokedAt)
+}
+
+func optionalTime(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
+
+// CreateAPIKeyPayload GraphQL resolver
+type CreateAPIKeyPayloadResolver struct {
+	key    *ent.APIKey
+	secret string
+}
+
+func (r *CreateAPIKeyPayloadResolver) APIKey() *APIKeyResolver {
+	return &APIKeyResolver{key: r.key}
+}
+
+func (r *CreateAPIKeyPayloadResolver) Key() string {
+	return r.secret
+}
+
+// Input types
+type CreateAPIKeyInput struct {
+	Name      string
+	Scopes    []string
+	ExpiresAt *graphql.Time
+}
+
+// apiKeyOwner returns the user managing API keys. API keys themselves cannot
+// manage API keys, so a leaked key cannot mint further ones.
+func apiKeyOwner(ctx context.Context) (*ent.User, error) {
+	viewer, err := viewerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p, _ := auth.FromContext(ctx); p.APIKey != nil {
+		return nil, &Error{Code: CodeForbidden, Message: "API keys cannot manage API keys"}
+	}
+	return viewer, nil
+}
+
+// Query resolvers
+func (r *Resolver) APIKeys(ctx context.Context) ([]*APIKeyResolver, error) {
+	viewer, err := apiKeyOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := r.Client.APIKey.Query().
+		Where(apikey.OwnerID(viewer.ID)).
+		Order(ent.Asc(apikey.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*APIKeyResolver, len(keys))
+	for i, key := range keys {
+		resolvers[i] = &APIKeyResolver{key: key}
+	}
+	return resolvers, nil
+}
+
+// Mutation resolvers
+func (r *Resolver) CreateAPIKey(ctx context.Context, args struct{ Input CreateAPIKeyInput }) (*CreateAPIKeyPayloadResolver, error) {
+	viewer, err := apiKeyOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args.Input.Scopes) == 0 {
+		return nil, fmt.Errorf("at least one scope is required")
+	}
+	scopes := make([]string, 0, len(args.Input.Scopes))
+	for _, value := range args.Input.Scopes {
+		if scope := apiKeyScopes[value]; !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	secret, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	builder := r.Client.APIKey.Create().
+		SetName(args.Input.Name).
+		SetPrefix(prefix).
+		SetSecretHash(hash).
+		SetScopes(scopes).
+		SetOwnerID(viewer.ID)
+
+	if args.Input.ExpiresAt != nil {
+		builder = builder.SetExpiresAt(args.Input.ExpiresAt.Time)
+	}
+
+	key, err := builder.Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateAPIKeyPayloadResolver{key: key, secret: secret}, nil
+}
+
+func (r *Resolver) RevokeAPIKey(ctx context.Context, args struct{ ID graphql.ID }) (*APIKeyResolver, error) {
+	id, err := strconv.Atoi(string(args.ID))
+	if err != nil {
+		return nil, fmt.Errorf("invalid API key ID: %w", err)
+	}
+
+	viewer, err := apiKeyOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := r.Client.APIKey.Query().
+		Where(apikey.ID(id), apikey.OwnerID(viewer.ID)).
+		Only(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if key.RevokedAt != nil {
+		return &APIKeyResolver{key: key}, nil
+	}
+
+	key, err = key.Update().
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &APIKeyResolver{key: key}, nil
+}
@@ -0,0 +1,147 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/attachment"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	attachments "github.com/ishidatakuo/graphql-ent-atlas/internal/attachment"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+)
+
+// Upload is a file sent with a multipart request, the value of the Upload
+// scalar.
+type Upload struct {
+	*multipart.FileHeader
+}
+
+func (Upload) ImplementsGraphQLType(name string) bool {
+	return name == "Upload"
+}
+
+func (u *Upload) UnmarshalGraphQL(input interface{}) error {
+	file, ok := input.(*multipart.FileHeader)
+	if !ok {
+		return errors.New("upload values must be files sent with a multipart request")
+	}
+	u.FileHeader = file
+	return nil
+}
+
+// Attachment GraphQL resolver
+type AttachmentResolver struct {
+	attachment *ent.Attachment
+}
+
+func (r *AttachmentResolver) ID() graphql.ID {
+	return graphql.ID(strconv.Itoa(r.attachment.ID))
+}
+
+func (r *AttachmentResolver) Filename() string {
+	return r.attachment.Filename
+}
+
+func (r *AttachmentResolver) ContentType() string {
+	return r.attachment.ContentType
+}
+
+func (r *AttachmentResolver) Size() int32 {
+	return int32(r.attachment.Size)
+}
+
+func (r *AttachmentResolver) Checksum() string {
+	return r.attachment.Checksum
+}
+
+func (r *AttachmentResolver) URL() string {
+	return fmt.Sprintf("/attachments/%d", r.attachment.ID)
+}
+
+func (r *AttachmentResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.attachment.CreatedAt}
+}
+
+func (r *TodoResolver) Attachments(ctx context.Context) ([]*AttachmentResolver, error) {
+	list, err := r.root.loaders(ctx).attachments.load(ctx, r.todo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*AttachmentResolver, len(list))
+	for i, a := range list {
+		resolvers[i] = &AttachmentResolver{attachment: a}
+	}
+	return resolvers, nil
+}
+
+// Mutation resolvers
+
+func (r *Resolver) AttachFile(ctx context.Context, args struct {
+	TodoID graphql.ID
+	File   Upload
+}) (*AttachmentResolver, error) {
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	todoID, err := r.ownedTodoID(ctx, viewer, args.TodoID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := args.File.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	a, err := attachments.Attach(ctx, r.Client, r.Blobs, todoID, attachments.File{
+		Filename:    args.File.Filename,
+		ContentType: args.File.Header.Get("Content-Type"),
+		Size:        args.File.Size,
+		Content:     content,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AttachmentResolver{attachment: a}, nil
+}
+
+func (r *Resolver) DeleteAttachment(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return false, err
+	}
+
+	id, err := strconv.Atoi(string(args.ID))
+	if err != nil {
+		return false, fmt.Errorf("invalid attachment ID: %w", err)
+	}
+
+	a, err := r.Client.Attachment.Query().
+		Where(
+			attachment.ID(id),
+			attachment.HasTodoWith(todo.OwnerID(viewer.ID), todo.DeletedAtIsNil()),
+		).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return false, fmt.Errorf("attachment %d not found", id)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := attachments.Delete(ctx, r.Client, r.Blobs, a); err != nil {
+		return false, err
+	}
+	return true, nil
+}
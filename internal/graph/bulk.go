@@ -0,0 +1,158 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/position"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/subtask"
+)
+
+// maxBulkTodos is the largest number of todos createTodos creates at once.
+const maxBulkTodos = 100
+
+// Mutation resolvers
+
+// CreateTodos creates todos in one transaction, appended in order to the end
+// of the viewer's list.
+func (r *Resolver) CreateTodos(ctx context.Context, args struct{ Inputs []CreateTodoInput }) ([]*TodoResolver, error) {
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return nil, err
+	}
+	if len(args.Inputs) > maxBulkTodos {
+		return nil, fmt.Errorf("cannot create more than %d todos at once", maxBulkTodos)
+	}
+
+	refs := make([][2]int, len(args.Inputs))
+	for i, input := range args.Inputs {
+		projectID, parentID, err := r.todoRefs(ctx, viewer, input.ProjectID, input.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("inputs[%d]: %w", i, err)
+		}
+		refs[i] = [2]int{projectID, parentID}
+	}
+
+	var todos []*ent.Todo
+	err = r.withTx(ctx, func(client *ent.Client) error {
+		// The create hook cannot append todos created together, as none of
+		// them exists yet when it runs: position them here.
+		last, err := client.Todo.Query().
+			Where(todo.OwnerID(viewer.ID)).
+			Order(ent.Desc(todo.FieldPosition)).
+			First(ctx)
+		var pos string
+		switch {
+		case err == nil:
+			pos = last.Position
+		case !ent.IsNotFound(err):
+			return err
+		}
+
+		builders := make([]*ent.TodoCreate, len(args.Inputs))
+		for i, input := range args.Inputs {
+			if pos, err = position.After(pos); err != nil {
+				return err
+			}
+			builders[i] = newTodoCreate(client, viewer, input, refs[i][0], refs[i][1]).
+				SetPosition(pos)
+		}
+
+		todos, err = client.Todo.CreateBulk(builders...).Save(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.todoResolvers(todos), nil
+}
+
+// UpdateTodos applies the same update to all the todos of the viewer matching
+// where, in one transaction. Completing todos blocked by open todos fails
+// unless force is set, and completed recurring todos get their next
+// occurrence. Subtasks are left unchanged.
+func (r *Resolver) UpdateTodos(ctx context.Context, args struct {
+	Where TodoWhereInput
+	Input UpdateTodoInput
+	Force bool
+}) ([]*TodoResolver, error) {
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return nil, err
+	}
+	if args.Input.ExpectedVersion != nil {
+		return nil, errors.New("expectedVersion is not supported by updateTodos")
+	}
+
+	projectID, parentID, err := r.todoRefs(ctx, viewer, args.Input.ProjectID, args.Input.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var todos []*ent.Todo
+	err = r.withTx(ctx, func(client *ent.Client) error {
+		// Select the todos first: the update may make them stop matching.
+		ids, err := args.Where.Filter(client.Todo.Query().Where(todo.OwnerID(viewer.ID))).IDs(ctx)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		update := client.Todo.Update().Where(todo.IDIn(ids...))
+		applyTodoUpdate(update.Mutation(), args.Input, projectID, parentID)
+		if err := update.Exec(ctx); err != nil {
+			return err
+		}
+
+		todos, err = client.Todo.Query().
+			Where(todo.IDIn(ids...)).
+			Order(ent.Asc(todo.FieldPosition), ent.Asc(todo.FieldID)).
+			All(ctx)
+		if err != nil || args.Input.Completed == nil || !*args.Input.Completed {
+			return err
+		}
+		for _, t := range todos {
+			if err := completeTodo(ctx, client, t, nil, args.Force); err != nil {
+				return fmt.Errorf("todo %d: %w", t.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.todoResolvers(todos), nil
+}
+
+// DeleteTodos moves all the todos of the viewer matching where to the trash,
+// in one transaction, applying childPolicy to their subtasks. It returns how
+// many todos it deleted, subtasks included.
+func (r *Resolver) DeleteTodos(ctx context.Context, args struct {
+	Where       TodoWhereInput
+	ChildPolicy string
+}) (int32, error) {
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted int
+	err = r.withTx(ctx, func(client *ent.Client) error {
+		ids, err := args.Where.Filter(client.Todo.Query().Where(todo.OwnerID(viewer.ID))).IDs(ctx)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		deleted, err = subtask.DeleteAll(ctx, client, ids, subtask.Policy(args.ChildPolicy))
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(deleted), nil
+}
@@ -0,0 +1,133 @@
+package graph
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBulkMutations(t *testing.T) {
+	unchanged := []string{"1", "2<1", "3"}
+	tests := []struct {
+		name     string
+		mutation string
+		// wantData is the data of the mutation, if it succeeds.
+		wantData string
+		wantErr  string
+		want     []string
+	}{
+		{
+			name:     "create",
+			mutation: `createTodos(inputs: [{title: "4"}, {title: "5", parentId: 3}]) { id position }`,
+			wantData: `{"createTodos":[{"id":"4","position":"a3"},{"id":"5","position":"a4"}]}`,
+			want:     []string{"1", "2<1", "3", "4", "5<3"},
+		},
+		{
+			name:     "create with a missing parent",
+			mutation: `createTodos(inputs: [{title: "4"}, {title: "5", parentId: 4}]) { id }`,
+			wantErr:  "inputs[1]: todo 4 not found",
+			want:     unchanged,
+		},
+		{
+			name:     "create with an empty title",
+			mutation: `createTodos(inputs: [{title: "4"}, {title: ""}]) { id }`,
+			wantErr:  `ent: validator failed for field "Todo.title": value is less than the required length`,
+			want:     unchanged,
+		},
+		{
+			name:     "create too many",
+			mutation: `createTodos(inputs: [` + strings.Repeat(`{title: "x"}, `, 101) + `]) { id }`,
+			wantErr:  "cannot create more than 100 todos at once",
+			want:     unchanged,
+		},
+		{
+			name:     "update",
+			mutation: `updateTodos(where: {titleHasPrefix: "2"}, input: {title: "two", clearParent: true}) { id title }`,
+			wantData: `{"updateTodos":[{"id":"2","title":"two"}]}`,
+			want:     []string{"1", "2", "3"},
+		},
+		{
+			// Todo 1 is completed first, then rolled back.
+			name:     "complete a blocked todo",
+			mutation: `updateTodos(where: {not: {titleHasPrefix: "2"}}, input: {completed: true}) { id }`,
+			wantErr:  "todo 3: todo is blocked by open todos",
+			want:     unchanged,
+		},
+		{
+			name:     "complete a blocked todo with force",
+			mutation: `updateTodos(where: {not: {titleHasPrefix: "2"}}, input: {completed: true}, force: true) { id }`,
+			wantData: `{"updateTodos":[{"id":"1"},{"id":"3"}]}`,
+			want:     []string{"1 done", "2<1", "3 done"},
+		},
+		{
+			name:     "complete a blocked todo with its blocker",
+			mutation: `updateTodos(where: {}, input: {completed: true}) { id }`,
+			wantData: `{"updateTodos":[{"id":"1"},{"id":"2"},{"id":"3"}]}`,
+			want:     []string{"1 done", "2<1 done", "3 done"},
+		},
+		{
+			name:     "update with an expected version",
+			mutation: `updateTodos(where: {}, input: {title: "x", expectedVersion: 1}) { id }`,
+			wantErr:  "expectedVersion is not supported by updateTodos",
+			want:     unchanged,
+		},
+		{
+			name:     "update nothing",
+			mutation: `updateTodos(where: {titleContains: "nothing"}, input: {title: "x"}) { id }`,
+			wantData: `{"updateTodos":[]}`,
+			want:     unchanged,
+		},
+		{
+			name:     "delete with cascade",
+			mutation: `deleteTodos(where: {titleHasPrefix: "1"}, childPolicy: CASCADE)`,
+			wantData: `{"deleteTodos":2}`,
+			want:     []string{"1 trashed", "2<1 trashed", "3"},
+		},
+		{
+			name:     "delete with block",
+			mutation: `deleteTodos(where: {not: {titleHasPrefix: "2"}}, childPolicy: BLOCK)`,
+			wantErr:  "todo has subtasks",
+			want:     unchanged,
+		},
+		{
+			name:     "delete a todo with its subtasks with block",
+			mutation: `deleteTodos(where: {or: [{titleHasPrefix: "1"}, {titleHasPrefix: "2"}]}, childPolicy: BLOCK)`,
+			wantData: `{"deleteTodos":2}`,
+			want:     []string{"1 trashed", "2<1 trashed", "3"},
+		},
+		{
+			name:     "delete with orphan",
+			mutation: `deleteTodos(where: {titleHasPrefix: "1"})`,
+			wantData: `{"deleteTodos":1}`,
+			want:     []string{"1 trashed", "2", "3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t)
+			ctx := signIn(t, client, "alice@example.com")
+			schema := newTestSchema(client)
+			mustExec(t, ctx, schema, `mutation {
+				one: createTodo(input: {title: "1"}) { id }
+				two: createTodo(input: {title: "2", parentId: 1}) { id }
+				three: createTodo(input: {title: "3"}) { id }
+				addDependency(todoId: 3, blockedById: 2) { id }
+			}`, nil, nil)
+
+			res := schema.Exec(ctx, "mutation { "+tt.mutation+" }", "", nil)
+			msg := ""
+			if len(res.Errors) > 0 {
+				msg = res.Errors[0].Message
+			}
+			if msg != tt.wantErr {
+				t.Errorf("got error %q, want %q", msg, tt.wantErr)
+			}
+			if msg == "" && string(res.Data) != tt.wantData {
+				t.Errorf("got data %s, want %s", res.Data, tt.wantData)
+			}
+			if got := hierarchy(ctx, client); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
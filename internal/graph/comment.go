@@ -0,0 +1,299 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/comment"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+)
+
+// Comment GraphQL resolver
+type CommentResolver struct {
+	root    *Resolver
+	comment *ent.Comment
+}
+
+func (r *CommentResolver) ID() graphql.ID {
+	return graphql.ID(strconv.Itoa(r.comment.ID))
+}
+
+func (r *CommentResolver) Body() string {
+	return r.comment.Body
+}
+
+func (r *CommentResolver) Author(ctx context.Context) (*UserResolver, error) {
+	user, err := r.root.loaders(ctx).users.load(ctx, r.comment.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user %d not found", r.comment.AuthorID)
+	}
+	return &UserResolver{user: user}, nil
+}
+
+func (r *CommentResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.comment.CreatedAt}
+}
+
+func (r *CommentResolver) EditedAt() *graphql.Time {
+	if r.comment.EditedAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.comment.EditedAt}
+}
+
+// CommentConnectionResolver resolves a page of the comments of a todo.
+type CommentConnectionResolver struct {
+	root     *Resolver
+	todoID   int
+	edges    []*CommentEdgeResolver
+	pageInfo *PageInfoResolver
+}
+
+func (r *CommentConnectionResolver) Edges() []*CommentEdgeResolver {
+	return r.edges
+}
+
+func (r *CommentConnectionResolver) PageInfo() *PageInfoResolver {
+	return r.pageInfo
+}
+
+func (r *CommentConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	n, err := r.root.loaders(ctx).commentCounts.load(ctx, r.todoID)
+	return int32(n), err
+}
+
+// CommentEdgeResolver resolves a single edge of a CommentConnection.
+type CommentEdgeResolver struct {
+	node   *CommentResolver
+	cursor Cursor
+}
+
+func (r *CommentEdgeResolver) Node() *CommentResolver {
+	return r.node
+}
+
+func (r *CommentEdgeResolver) Cursor() string {
+	return r.cursor.String()
+}
+
+func (r *TodoResolver) Comments(ctx context.Context, args ConnectionArgs) (*CommentConnectionResolver, error) {
+	return r.root.paginateComments(ctx, r.todo.ID, args)
+}
+
+// paginateComments returns one page of the comments of the todo, oldest
+// first. Comments are ordered by ID, so their cursors only hold the ID. The
+// first pages of the todos of a list are loaded together.
+func (r *Resolver) paginateComments(ctx context.Context, todoID int, args ConnectionArgs) (*CommentConnectionResolver, error) {
+	limit, err := args.limit()
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []*ent.Comment
+	if args.After == nil && args.Before == nil && args.Last == nil {
+		comments, err = r.loaders(ctx).commentPages.load(ctx, commentPage{todoID: todoID, size: limit + 1})
+	} else {
+		comments, err = r.commentsBetween(ctx, todoID, args, limit+1)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	backward := args.Last != nil
+	hasMore := len(comments) > limit
+	if hasMore {
+		comments = comments[:limit]
+	}
+	if backward {
+		for i, j := 0, len(comments)-1; i < j; i, j = i+1, j-1 {
+			comments[i], comments[j] = comments[j], comments[i]
+		}
+	}
+
+	conn := &CommentConnectionResolver{
+		root:     r,
+		todoID:   todoID,
+		edges:    make([]*CommentEdgeResolver, len(comments)),
+		pageInfo: &PageInfoResolver{},
+	}
+	for i, c := range comments {
+		conn.edges[i] = &CommentEdgeResolver{node: &CommentResolver{root: r, comment: c}, cursor: Cursor{ID: c.ID}}
+	}
+	if n := len(conn.edges); n > 0 {
+		conn.pageInfo.startCursor = &conn.edges[0].cursor
+		conn.pageInfo.endCursor = &conn.edges[n-1].cursor
+	}
+	if backward {
+		conn.pageInfo.hasPreviousPage = hasMore
+		conn.pageInfo.hasNextPage = args.Before != nil
+	} else {
+		conn.pageInfo.hasNextPage = hasMore
+		conn.pageInfo.hasPreviousPage = args.After != nil
+	}
+
+	return conn, nil
+}
+
+// commentsBetween returns up to n comments of the todo within the cursors of
+// args: the first ones, or the last ones in reverse order if args.Last is set.
+func (r *Resolver) commentsBetween(ctx context.Context, todoID int, args ConnectionArgs, n int) ([]*ent.Comment, error) {
+	q := r.Client.Comment.Query().Where(comment.TodoID(todoID))
+	if args.After != nil {
+		c, err := decodeCommentCursor(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		q = q.Where(comment.IDGT(c.ID))
+	}
+	if args.Before != nil {
+		c, err := decodeCommentCursor(*args.Before)
+		if err != nil {
+			return nil, err
+		}
+		q = q.Where(comment.IDLT(c.ID))
+	}
+	if args.Last != nil {
+		q = q.Order(comment.ByID(sql.OrderDesc()))
+	} else {
+		q = q.Order(comment.ByID())
+	}
+	return q.Limit(n).All(ctx)
+}
+
+// decodeCommentCursor decodes a cursor of a CommentConnection, which has no
+// order field.
+func decodeCommentCursor(s string) (Cursor, error) {
+	c, err := decodeCursor(s)
+	if err != nil {
+		return c, err
+	}
+	if c.Field != "" {
+		return c, errors.New("cursor does not belong to a comment connection")
+	}
+	return c, nil
+}
+
+// commentBody returns body without its surrounding white space, or an error
+// when nothing is left.
+func commentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", errors.New("comment body must not be empty")
+	}
+	return body, nil
+}
+
+// Mutation resolvers
+
+func (r *Resolver) AddComment(ctx context.Context, args struct {
+	TodoID graphql.ID
+	Body   string
+}) (*CommentResolver, error) {
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	todoID, err := r.ownedTodoID(ctx, viewer, args.TodoID)
+	if err != nil {
+		return nil, err
+	}
+	body, err := commentBody(args.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := r.Client.Comment.Create().
+		SetTodoID(todoID).
+		SetAuthorID(viewer.ID).
+		SetBody(body).
+		Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommentResolver{root: r, comment: c}, nil
+}
+
+func (r *Resolver) EditComment(ctx context.Context, args struct {
+	ID   graphql.ID
+	Body string
+}) (*CommentResolver, error) {
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := r.authoredComment(ctx, viewer, args.ID)
+	if err != nil {
+		return nil, err
+	}
+	body, err := commentBody(args.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err = c.Update().
+		SetBody(body).
+		SetEditedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommentResolver{root: r, comment: c}, nil
+}
+
+func (r *Resolver) DeleteComment(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return false, err
+	}
+
+	c, err := r.authoredComment(ctx, viewer, args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.Client.Comment.DeleteOne(c).Exec(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// authoredComment returns the comment with the given ID that the viewer
+// wrote on one of their todos. Only the owner of a todo can comment on it, so
+// the comments of the viewer's todos are all theirs for now.
+func (r *Resolver) authoredComment(ctx context.Context, viewer *ent.User, id graphql.ID) (*ent.Comment, error) {
+	commentID, err := strconv.Atoi(string(id))
+	if err != nil {
+		return nil, fmt.Errorf("invalid comment ID: %w", err)
+	}
+
+	c, err := r.Client.Comment.Query().
+		Where(
+			comment.ID(commentID),
+			comment.AuthorID(viewer.ID),
+			comment.HasTodoWith(todo.OwnerID(viewer.ID), todo.DeletedAtIsNil()),
+		).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, fmt.Errorf("comment %d not found", commentID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,199 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// commentPageData is the data of a page of the comments of todo 1.
+type commentPageData struct {
+	Todo struct {
+		Comments commentConnectionData
+	}
+}
+
+type commentConnectionData struct {
+	TotalCount int
+	Edges      []struct {
+		Cursor string
+		Node   struct{ ID string }
+	}
+	PageInfo struct {
+		HasNextPage     bool
+		HasPreviousPage bool
+		StartCursor     *string
+		EndCursor       *string
+	}
+}
+
+func (c commentConnectionData) ids() []string {
+	ids := make([]string, len(c.Edges))
+	for i, e := range c.Edges {
+		ids[i] = e.Node.ID
+	}
+	return ids
+}
+
+func TestCommentsConnection(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := signIn(t, client, "alice@example.com")
+	schema := newTestSchema(client)
+	mustExec(t, ctx, schema, `mutation { createTodo(input: {title: "Plan the trip"}) { id } }`, nil, nil)
+	for i := range 5 {
+		mustExec(t, ctx, schema, `mutation($body: String!) { addComment(todoId: "1", body: $body) { id } }`,
+			map[string]any{"body": fmt.Sprintf("comment %d", i+1)}, nil)
+	}
+
+	const query = `query($first: Int, $after: String, $last: Int, $before: String) {
+		todo(id: "1") {
+			comments(first: $first, after: $after, last: $last, before: $before) {
+				totalCount
+				edges { cursor node { id } }
+				pageInfo { hasNextPage hasPreviousPage startCursor endCursor }
+			}
+		}
+	}`
+	page := func(vars map[string]any) commentConnectionData {
+		t.Helper()
+		var data commentPageData
+		mustExec(t, WithLoaders(ctx, NewLoaders(client)), schema, query, vars, &data)
+		return data.Todo.Comments
+	}
+
+	first := page(map[string]any{"first": 2})
+	if got, want := first.ids(), []string{"1", "2"}; !slices.Equal(got, want) || !first.PageInfo.HasNextPage || first.PageInfo.HasPreviousPage {
+		t.Errorf("first 2: got %v %+v, want %v with a next page", got, first.PageInfo, want)
+	}
+	if first.TotalCount != 5 {
+		t.Errorf("got total count %d, want 5", first.TotalCount)
+	}
+
+	next := page(map[string]any{"first": 2, "after": *first.PageInfo.EndCursor})
+	if got, want := next.ids(), []string{"3", "4"}; !slices.Equal(got, want) || !next.PageInfo.HasNextPage || !next.PageInfo.HasPreviousPage {
+		t.Errorf("first 2 after 2: got %v %+v, want %v with both pages", got, next.PageInfo, want)
+	}
+
+	last := page(map[string]any{"last": 2})
+	if got, want := last.ids(), []string{"4", "5"}; !slices.Equal(got, want) || last.PageInfo.HasNextPage || !last.PageInfo.HasPreviousPage {
+		t.Errorf("last 2: got %v %+v, want %v with a previous page", got, last.PageInfo, want)
+	}
+
+	before := page(map[string]any{"last": 2, "before": *last.PageInfo.StartCursor})
+	if got, want := before.ids(), []string{"2", "3"}; !slices.Equal(got, want) || !before.PageInfo.HasNextPage || !before.PageInfo.HasPreviousPage {
+		t.Errorf("last 2 before 4: got %v %+v, want %v with both pages", got, before.PageInfo, want)
+	}
+
+	empty := page(map[string]any{"first": 0})
+	if len(empty.Edges) != 0 || !empty.PageInfo.HasNextPage || empty.PageInfo.EndCursor != nil {
+		t.Errorf("first 0: got %v %+v, want no comments and a next page", empty.ids(), empty.PageInfo)
+	}
+
+	var todoCursor struct {
+		TodosConnection struct {
+			Edges []struct{ Cursor string }
+		}
+	}
+	mustExec(t, ctx, schema, `{ todosConnection(first: 1) { edges { cursor } } }`, nil, &todoCursor)
+	msg := exec(ctx, schema, query, map[string]any{"after": todoCursor.TodosConnection.Edges[0].Cursor}, nil)
+	if msg != "cursor does not belong to a comment connection" {
+		t.Errorf("todo cursor: got error %q", msg)
+	}
+}
+
+// TestCommentsFirstPagesLoadedTogether checks the first pages of the
+// comments of several todos, which a single query loads.
+func TestCommentsFirstPagesLoadedTogether(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := signIn(t, client, "alice@example.com")
+	schema := newTestSchema(client)
+	mustExec(t, ctx, schema, `mutation { createTodos(inputs: [{title: "1"}, {title: "2"}, {title: "3"}]) { id } }`, nil, nil)
+	for _, todoID := range []int{1, 2, 1, 1, 2} {
+		mustExec(t, ctx, schema, fmt.Sprintf(`mutation { addComment(todoId: %d, body: "hello") { id } }`, todoID), nil, nil)
+	}
+
+	var data struct {
+		Todos []struct {
+			Comments commentConnectionData
+		}
+	}
+	mustExec(t, WithLoaders(ctx, NewLoaders(client)), schema, `{
+		todos { comments(first: 2) { totalCount edges { node { id } } pageInfo { hasNextPage } } }
+	}`, nil, &data)
+	want := []struct {
+		ids         []string
+		total       int
+		hasNextPage bool
+	}{
+		{ids: []string{"1", "3"}, total: 3, hasNextPage: true},
+		{ids: []string{"2", "5"}, total: 2},
+		{ids: []string{}, total: 0},
+	}
+	for i, w := range want {
+		c := data.Todos[i].Comments
+		if !slices.Equal(c.ids(), w.ids) || c.TotalCount != w.total || c.PageInfo.HasNextPage != w.hasNextPage {
+			t.Errorf("todo %d: got %v of %d, next page %t, want %v of %d, next page %t",
+				i+1, c.ids(), c.TotalCount, c.PageInfo.HasNextPage, w.ids, w.total, w.hasNextPage)
+		}
+	}
+}
+
+func TestCommentMutations(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := signIn(t, client, "alice@example.com")
+	other := signIn(t, client, "bob@example.com")
+	schema := newTestSchema(client)
+	mustExec(t, ctx, schema, `mutation { createTodo(input: {title: "Plan the trip"}) { id } }`, nil, nil)
+
+	var added struct {
+		AddComment struct {
+			ID       string
+			Body     string
+			Author   struct{ Email string }
+			EditedAt *string
+		}
+	}
+	mustExec(t, ctx, schema, `mutation { addComment(todoId: "1", body: "  Book the **train**\n") { id body author { email } editedAt } }`, nil, &added)
+	if c := added.AddComment; c.Body != "Book the **train**" || c.Author.Email != "alice@example.com" || c.EditedAt != nil {
+		t.Errorf("got comment %+v", c)
+	}
+
+	var edited struct {
+		EditComment struct {
+			Body     string
+			EditedAt *string
+		}
+	}
+	mustExec(t, ctx, schema, `mutation { editComment(id: "1", body: "Book the plane") { body editedAt } }`, nil, &edited)
+	if c := edited.EditComment; c.Body != "Book the plane" || c.EditedAt == nil {
+		t.Errorf("got edited comment %+v, want a new body and an edit time", c)
+	}
+
+	tests := []struct {
+		name     string
+		viewer   string
+		mutation string
+		wantErr  string
+	}{
+		{name: "empty body", mutation: `addComment(todoId: "1", body: " \n") { id }`, wantErr: "comment body must not be empty"},
+		{name: "empty edit", mutation: `editComment(id: "1", body: "") { id }`, wantErr: "comment body must not be empty"},
+		{name: "missing todo", mutation: `addComment(todoId: "2", body: "hi") { id }`, wantErr: "todo 2 not found"},
+		{name: "todo of another user", viewer: "bob", mutation: `addComment(todoId: "1", body: "hi") { id }`, wantErr: "todo 1 not found"},
+		{name: "edit by another user", viewer: "bob", mutation: `editComment(id: "1", body: "hi") { id }`, wantErr: "comment 1 not found"},
+		{name: "delete by another user", viewer: "bob", mutation: `deleteComment(id: "1")`, wantErr: "comment 1 not found"},
+		{name: "delete", mutation: `deleteComment(id: "1")`},
+		{name: "deleted comment", mutation: `editComment(id: "1", body: "hi") { id }`, wantErr: "comment 1 not found"},
+	}
+	for _, tt := range tests {
+		viewer := ctx
+		if tt.viewer == "bob" {
+			viewer = other
+		}
+		if msg := exec(viewer, schema, "mutation { "+tt.mutation+" }", nil, nil); msg != tt.wantErr {
+			t.Errorf("%s: got error %q, want %q", tt.name, msg, tt.wantErr)
+		}
+	}
+	if n := client.Comment.Query().CountX(ctx); n != 0 {
+		t.Errorf("got %d comments after deleting the only one", n)
+	}
+}
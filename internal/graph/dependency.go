@@ -0,0 +1,115 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/dependency"
+)
+
+func (r *TodoResolver) Blocks(ctx context.Context) ([]*TodoResolver, error) {
+	todos, err := r.root.loaders(ctx).blocks.load(ctx, r.todo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.root.todoResolvers(todos), nil
+}
+
+func (r *TodoResolver) BlockedBy(ctx context.Context) ([]*TodoResolver, error) {
+	todos, err := r.root.loaders(ctx).blockedBy.load(ctx, r.todo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.root.todoResolvers(todos), nil
+}
+
+// IsBlocked reports whether a todo this todo waits for is not completed, as
+// dependency.IsBlocked does.
+func (r *TodoResolver) IsBlocked(ctx context.Context) (bool, error) {
+	blockers, err := r.root.loaders(ctx).blockedBy.load(ctx, r.todo.ID)
+	if err != nil {
+		return false, err
+	}
+	for _, blocker := range blockers {
+		if !blocker.Completed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Mutation resolvers
+
+// AddDependency makes a todo wait for another one. Dependencies forming a
+// cycle are rejected.
+func (r *Resolver) AddDependency(ctx context.Context, args struct {
+	TodoID      graphql.ID
+	BlockedByID graphql.ID
+}) (*TodoResolver, error) {
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := r.ownedTodoID(ctx, viewer, args.TodoID)
+	if err != nil {
+		return nil, err
+	}
+	blockerID, err := r.ownedTodoID(ctx, viewer, args.BlockedByID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.withTx(ctx, func(client *ent.Client) error {
+		return dependency.Add(ctx, client, id, blockerID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.ownedTodo(ctx, id)
+}
+
+// RemoveDependency removes the dependency of a todo on another one.
+func (r *Resolver) RemoveDependency(ctx context.Context, args struct {
+	TodoID      graphql.ID
+	BlockedByID graphql.ID
+}) (*TodoResolver, error) {
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := r.ownedTodoID(ctx, viewer, args.TodoID)
+	if err != nil {
+		return nil, err
+	}
+	blockerID, err := strconv.Atoi(string(args.BlockedByID))
+	if err != nil {
+		return nil, fmt.Errorf("invalid todo ID: %w", err)
+	}
+
+	if err := dependency.Remove(ctx, r.Client, id, blockerID); err != nil {
+		return nil, err
+	}
+
+	return r.ownedTodo(ctx, id)
+}
+
+// ownedTodo returns the resolver of the todo with the given ID, which the
+// caller has checked belongs to the viewer.
+func (r *Resolver) ownedTodo(ctx context.Context, id int) (*TodoResolver, error) {
+	todo, err := r.Client.Todo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.todoResolver(todo), nil
+}
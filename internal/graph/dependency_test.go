@@ -0,0 +1,84 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAddDependency(t *testing.T) {
+	tests := []struct {
+		name string
+		// setup runs before the dependency is added, on todos 1, 2 and 3.
+		setup   []string
+		todoID  int
+		blocker int
+		wantErr string
+	}{
+		{name: "independent todos", todoID: 2, blocker: 1},
+		{name: "itself", todoID: 1, blocker: 1, wantErr: "dependency would create a cycle"},
+		{
+			name:    "direct cycle",
+			setup:   []string{`addDependency(todoId: 2, blockedById: 1) { id }`},
+			todoID:  1,
+			blocker: 2,
+			wantErr: "dependency would create a cycle",
+		},
+		{
+			name: "indirect cycle",
+			setup: []string{
+				`addDependency(todoId: 2, blockedById: 1) { id }`,
+				`addDependency(todoId: 3, blockedById: 2) { id }`,
+			},
+			todoID:  1,
+			blocker: 3,
+			wantErr: "dependency would create a cycle",
+		},
+		{
+			// Restoring todo 2 would close the cycle.
+			name: "cycle through a trashed todo",
+			setup: []string{
+				`addDependency(todoId: 2, blockedById: 1) { id }`,
+				`addDependency(todoId: 3, blockedById: 2) { id }`,
+				`deleteTodo(id: 2)`,
+			},
+			todoID:  1,
+			blocker: 3,
+			wantErr: "dependency would create a cycle",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t)
+			ctx := signIn(t, client, "alice@example.com")
+			schema := newTestSchema(client)
+			mustExec(t, ctx, schema, `mutation { createTodos(inputs: [{title: "1"}, {title: "2"}, {title: "3"}]) { id } }`, nil, nil)
+			for _, m := range tt.setup {
+				mustExec(t, ctx, schema, "mutation { "+m+" }", nil, nil)
+			}
+
+			msg := exec(ctx, schema, fmt.Sprintf(`mutation { addDependency(todoId: %d, blockedById: %d) { id } }`, tt.todoID, tt.blocker), nil, nil)
+			if msg != tt.wantErr {
+				t.Errorf("got error %q, want %q", msg, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompleteBlockedTodo(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := signIn(t, client, "alice@example.com")
+	schema := newTestSchema(client)
+	mustExec(t, ctx, schema, `mutation { createTodos(inputs: [{title: "1"}, {title: "2"}, {title: "3"}]) { id } }`, nil, nil)
+	mustExec(t, ctx, schema, `mutation { addDependency(todoId: 2, blockedById: 1) { id } }`, nil, nil)
+	mustExec(t, ctx, schema, `mutation { addDependency(todoId: 3, blockedById: 1) { id } }`, nil, nil)
+
+	const complete = `mutation($id: ID!, $force: Boolean!) { updateTodo(id: $id, input: {completed: true}, force: $force) { completed } }`
+	if msg := exec(ctx, schema, complete, map[string]any{"id": "2", "force": false}, nil); msg != "todo is blocked by open todos" {
+		t.Errorf("completing a blocked todo: got error %q", msg)
+	}
+	mustExec(t, ctx, schema, complete, map[string]any{"id": "2", "force": true}, nil)
+
+	// Trashed todos block no todo.
+	mustExec(t, ctx, schema, `mutation { deleteTodo(id: 1) }`, nil, nil)
+	mustExec(t, ctx, schema, complete, map[string]any{"id": "3", "force": false}, nil)
+}
@@ -0,0 +1,69 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDueTodos(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := signIn(t, client, "alice@example.com")
+	schema := newTestSchema(client)
+	now := time.Now().Truncate(time.Second)
+	day := func(n int) string { return now.AddDate(0, 0, n).Format(time.RFC3339) }
+	mustExec(t, ctx, schema, `mutation($a: Time, $b: Time, $c: Time, $d: Time, $e: Time) {
+		createTodo(input: {title: "Pay rent", dueAt: $b}) { id }
+		tax: createTodo(input: {title: "File taxes", dueAt: $a}) { id }
+		plants: createTodo(input: {title: "Water plants", dueAt: $b}) { id }
+		milk: createTodo(input: {title: "Buy milk", dueAt: $c}) { id }
+		trip: createTodo(input: {title: "Plan the trip", dueAt: $d}) { id }
+		visa: createTodo(input: {title: "Apply for a visa", dueAt: $e}) { id }
+		someday: createTodo(input: {title: "Learn Go"}) { id }
+		updateTodo(id: 4, input: {completed: true}) { id }
+	}`, map[string]any{"a": day(-3), "b": day(-2), "c": day(-1), "d": day(2), "e": day(5)}, nil)
+	// A todo of another user is never listed.
+	bob := signIn(t, client, "bob@example.com")
+	mustExec(t, bob, schema, `mutation($due: Time) { createTodo(input: {title: "Call mum", dueAt: $due}) { id } }`,
+		map[string]any{"due": day(-2)}, nil)
+
+	ids := func(todos []struct{ ID string }) []string {
+		ids := []string{}
+		for _, t := range todos {
+			ids = append(ids, t.ID)
+		}
+		return ids
+	}
+
+	var overdue struct{ OverdueTodos []struct{ ID string } }
+	mustExec(t, ctx, schema, `{ overdueTodos { id } }`, nil, &overdue)
+	// The most overdue first, by ID on the same due date, leaving out the
+	// completed todo 4, the todos due later and the one without a due date.
+	if got, want := ids(overdue.OverdueTodos), []string{"2", "1", "3"}; !slices.Equal(got, want) {
+		t.Errorf("got overdue todos %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     []string
+		wantErr  string
+	}{
+		{name: "range", from: day(-2), to: day(2), want: []string{"1", "3", "4", "5"}},
+		{name: "single instant", from: day(5), to: day(5), want: []string{"6"}},
+		{name: "nothing due", from: day(6), to: day(9), want: []string{}},
+		{name: "reversed range", from: day(2), to: day(-2), wantErr: "to must not be before from"},
+	}
+	for _, tt := range tests {
+		var got struct{ DueBetween []struct{ ID string } }
+		err := exec(ctx, schema, `query($from: Time!, $to: Time!) { dueBetween(from: $from, to: $to) { id } }`,
+			map[string]any{"from": tt.from, "to": tt.to}, &got)
+		if err != tt.wantErr {
+			t.Errorf("%s: got error %q, want %q", tt.name, err, tt.wantErr)
+			continue
+		}
+		if ids := ids(got.DueBetween); tt.wantErr == "" && !slices.Equal(ids, tt.want) {
+			t.Errorf("%s: got todos %v, want %v", tt.name, ids, tt.want)
+		}
+	}
+}
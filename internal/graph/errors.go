@@ -0,0 +1,62 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+)
+
+// Error codes reported in the "code" extension of GraphQL errors.
+const (
+	CodeUnauthenticated = "UNAUTHENTICATED"
+	CodeForbidden       = "FORBIDDEN"
+	CodeConflict        = "CONFLICT"
+	// CodeQueryTooLarge and CodeQueryTooComplex reject operations exceeding
+	// the Limits of the server before they run.
+	CodeQueryTooLarge   = "QUERY_TOO_LARGE"
+	CodeQueryTooComplex = "QUERY_TOO_COMPLEX"
+)
+
+// Error is a GraphQL error carrying a machine-readable code in its extensions.
+type Error struct {
+	Code    string
+	Message string
+	// Err is the underlying error, if any.
+	Err error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.Code}
+}
+
+// viewerFromContext returns the authenticated user of ctx. Requests without
+// valid credentials get an UNAUTHENTICATED error.
+func viewerFromContext(ctx context.Context) (*ent.User, error) {
+	return authError(auth.UserFromContext(ctx))
+}
+
+// authorize returns the authenticated user of ctx if it may act within
+// scope. Principals lacking the scope get a FORBIDDEN error.
+func authorize(ctx context.Context, scope string) (*ent.User, error) {
+	return authError(auth.Authorize(ctx, scope))
+}
+
+func authError(user *ent.User, err error) (*ent.User, error) {
+	switch {
+	case errors.Is(err, auth.ErrUnauthenticated), errors.Is(err, auth.ErrInvalidCredentials):
+		return nil, &Error{Code: CodeUnauthenticated, Message: err.Error(), Err: err}
+	case errors.Is(err, auth.ErrForbidden):
+		return nil, &Error{Code: CodeForbidden, Message: err.Error(), Err: err}
+	}
+	return user, err
+}
@@ -0,0 +1,224 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/predicate"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/tag"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+)
+
+// TodoWhereInput filters todo listings. All set fields are combined with AND.
+type TodoWhereInput struct {
+	Completed      *bool
+	TitleContains  *string
+	TitleHasPrefix *string
+	CreatedAtGTE   *graphql.Time
+	CreatedAtLTE   *graphql.Time
+	UpdatedAtGTE   *graphql.Time
+	UpdatedAtLTE   *graphql.Time
+	HasTag         *string
+	Priority       *string
+	And            *[]*TodoWhereInput
+	Or             *[]*TodoWhereInput
+	Not            *TodoWhereInput
+}
+
+// P returns the predicate described by the input, or nil if it is empty.
+func (w *TodoWhereInput) P() predicate.Todo {
+	if w == nil {
+		return nil
+	}
+
+	var ps []predicate.Todo
+	if w.Completed != nil {
+		ps = append(ps, todo.CompletedEQ(*w.Completed))
+	}
+	if w.TitleContains != nil {
+		ps = append(ps, todo.TitleContainsFold(*w.TitleContains))
+	}
+	if w.TitleHasPrefix != nil {
+		// Ignore case, like titleContains. ent generates no fold variant of
+		// HasPrefix.
+		ps = append(ps, predicate.Todo(sql.FieldHasPrefixFold(todo.FieldTitle, *w.TitleHasPrefix)))
+	}
+	if w.CreatedAtGTE != nil {
+		ps = append(ps, todo.CreatedAtGTE(w.CreatedAtGTE.Time))
+	}
+	if w.CreatedAtLTE != nil {
+		ps = append(ps, todo.CreatedAtLTE(w.CreatedAtLTE.Time))
+	}
+	if w.UpdatedAtGTE != nil {
+		ps = append(ps, todo.UpdatedAtGTE(w.UpdatedAtGTE.Time))
+	}
+	if w.UpdatedAtLTE != nil {
+		ps = append(ps, todo.UpdatedAtLTE(w.UpdatedAtLTE.Time))
+	}
+	if w.HasTag != nil {
+		ps = append(ps, todo.HasTagsWith(tag.Name(*w.HasTag)))
+	}
+	if w.Priority != nil {
+		ps = append(ps, todo.PriorityEQ(todo.Priority(*w.Priority)))
+	}
+	if w.And != nil {
+		for _, sub := range *w.And {
+			if p := sub.P(); p != nil {
+				ps = append(ps, p)
+			}
+		}
+	}
+	if w.Or != nil {
+		var or []predicate.Todo
+		for _, sub := range *w.Or {
+			if p := sub.P(); p != nil {
+				or = append(or, p)
+			}
+		}
+		if len(or) > 0 {
+			ps = append(ps, todo.Or(or...))
+		}
+	}
+	if p := w.Not.P(); p != nil {
+		ps = append(ps, todo.Not(p))
+	}
+
+	switch len(ps) {
+	case 0:
+		return nil
+	case 1:
+		return ps[0]
+	default:
+		return todo.And(ps...)
+	}
+}
+
+// Filter applies the input to q.
+func (w *TodoWhereInput) Filter(q *ent.TodoQuery) *ent.TodoQuery {
+	if p := w.P(); p != nil {
+		return q.Where(p)
+	}
+	return q
+}
+
+// Order directions.
+const (
+	OrderDirectionAsc  = "ASC"
+	OrderDirectionDesc = "DESC"
+)
+
+// Todo order fields.
+const (
+	TodoOrderFieldCreatedAt = "CREATED_AT"
+	TodoOrderFieldUpdatedAt = "UPDATED_AT"
+	TodoOrderFieldTitle     = "TITLE"
+	TodoOrderFieldPosition  = "POSITION"
+)
+
+// todoOrderField describes a column todos can be ordered and paginated by.
+type todoOrderField struct {
+	column string
+	value  func(*ent.Todo) any
+	decode func(json.RawMessage) (any, error)
+}
+
+func decodeTime(raw json.RawMessage) (any, error) {
+	var t time.Time
+	err := json.Unmarshal(raw, &t)
+	return t, err
+}
+
+func decodeString(raw json.RawMessage) (any, error) {
+	var s string
+	err := json.Unmarshal(raw, &s)
+	return s, err
+}
+
+var todoOrderFields = map[string]todoOrderField{
+	TodoOrderFieldCreatedAt: {
+		column: todo.FieldCreatedAt,
+		value:  func(t *ent.Todo) any { return t.CreatedAt },
+		decode: decodeTime,
+	},
+	TodoOrderFieldUpdatedAt: {
+		column: todo.FieldUpdatedAt,
+		value:  func(t *ent.Todo) any { return t.UpdatedAt },
+		decode: decodeTime,
+	},
+	TodoOrderFieldTitle: {
+		column: todo.FieldTitle,
+		value:  func(t *ent.Todo) any { return t.Title },
+		decode: decodeString,
+	},
+	TodoOrderFieldPosition: {
+		column: todo.FieldPosition,
+		value:  func(t *ent.Todo) any { return t.Position },
+		decode: decodeString,
+	},
+}
+
+// DefaultTodoOrder is used when a listing does not specify an order.
+var DefaultTodoOrder = &TodoOrder{Direction: OrderDirectionAsc, Field: TodoOrderFieldPosition}
+
+// TodoOrder orders todo listings by a single field, breaking ties by ID.
+type TodoOrder struct {
+	Direction string
+	Field     string
+}
+
+func (o *TodoOrder) orderField() (todoOrderField, error) {
+	f, ok := todoOrderFields[o.Field]
+	if !ok {
+		return f, fmt.Errorf("unknown todo order field %q", o.Field)
+	}
+	return f, nil
+}
+
+func (o *TodoOrder) desc() bool {
+	return o.Direction == OrderDirectionDesc
+}
+
+// Validate reports whether the order refers to a known field and direction.
+func (o *TodoOrder) Validate() error {
+	if o.Direction != OrderDirectionAsc && o.Direction != OrderDirectionDesc {
+		return fmt.Errorf("unknown order direction %q", o.Direction)
+	}
+	_, err := o.orderField()
+	return err
+}
+
+// OrderOptions returns the ent order options for the order. When reverse is
+// set, the direction is flipped.
+func (o *TodoOrder) OrderOptions(reverse bool) ([]todo.OrderOption, error) {
+	f, err := o.orderField()
+	if err != nil {
+		return nil, err
+	}
+	term := sql.OrderAsc()
+	if o.desc() != reverse {
+		term = sql.OrderDesc()
+	}
+	return []todo.OrderOption{
+		sql.OrderByField(f.column, term).ToFunc(),
+		todo.ByID(term),
+	}, nil
+}
+
+// Order applies the order to q, falling back to DefaultTodoOrder.
+func (o *TodoOrder) Order(q *ent.TodoQuery) (*ent.TodoQuery, error) {
+	if o == nil {
+		o = DefaultTodoOrder
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	opts, err := o.OrderOptions(false)
+	if err != nil {
+		return nil, err
+	}
+	return q.Order(opts...), nil
+}
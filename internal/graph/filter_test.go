@@ -0,0 +1,97 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTodoWhereInput(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := signIn(t, client, "alice@example.com")
+	schema := newTestSchema(client)
+	mustExec(t, ctx, schema, `mutation {
+		createTodos(inputs: [
+			{title: "Buy milk"},
+			{title: "Buy bread"},
+			{title: "Call mom", priority: HIGH},
+			{title: "Write report"},
+		]) { id }
+		milk: updateTodo(id: 1, input: {completed: true}) { id }
+		mom: updateTodo(id: 3, input: {completed: true}) { id }
+		addTagsToTodo(todoId: 4, tags: ["work"]) { id }
+	}`, nil, nil)
+
+	tests := []struct {
+		name  string
+		where map[string]any
+		want  []string
+	}{
+		{name: "empty", where: map[string]any{}, want: []string{"1", "2", "3", "4"}},
+		{name: "completed", where: map[string]any{"completed": true}, want: []string{"1", "3"}},
+		{name: "title contains, ignoring case", where: map[string]any{"titleContains": "BUY"}, want: []string{"1", "2"}},
+		{name: "title prefix", where: map[string]any{"titleHasPrefix": "Call"}, want: []string{"3"}},
+		{name: "title prefix, ignoring case", where: map[string]any{"titleHasPrefix": "bUY "}, want: []string{"1", "2"}},
+		{name: "title prefix, not anywhere", where: map[string]any{"titleHasPrefix": "milk"}, want: []string{}},
+		{name: "title contains, anywhere", where: map[string]any{"titleContains": "MILK"}, want: []string{"1"}},
+		{name: "tag", where: map[string]any{"hasTag": "work"}, want: []string{"4"}},
+		{name: "priority", where: map[string]any{"priority": "HIGH"}, want: []string{"3"}},
+		{name: "created in the future", where: map[string]any{"createdAtGTE": "2100-01-01T00:00:00Z"}, want: []string{}},
+		{name: "fields combined", where: map[string]any{"completed": true, "titleContains": "buy"}, want: []string{"1"}},
+		{
+			name:  "and",
+			where: map[string]any{"and": []any{map[string]any{"titleContains": "buy"}, map[string]any{"completed": false}}},
+			want:  []string{"2"},
+		},
+		{
+			name:  "or",
+			where: map[string]any{"or": []any{map[string]any{"titleHasPrefix": "Call"}, map[string]any{"hasTag": "work"}}},
+			want:  []string{"3", "4"},
+		},
+		{name: "not", where: map[string]any{"not": map[string]any{"completed": true}}, want: []string{"2", "4"}},
+		{
+			name: "nested",
+			where: map[string]any{
+				"not": map[string]any{
+					"or": []any{map[string]any{"titleContains": "milk"}, map[string]any{"and": []any{map[string]any{"completed": false}, map[string]any{"titleHasPrefix": "Write"}}}},
+				},
+			},
+			want: []string{"2", "3"},
+		},
+		{name: "empty or", where: map[string]any{"or": []any{}, "completed": false}, want: []string{"2", "4"}},
+	}
+	for _, tt := range tests {
+		var data struct {
+			Todos []struct{ ID string }
+		}
+		mustExec(t, ctx, schema, `query($where: TodoWhereInput) { todos(where: $where) { id } }`, map[string]any{"where": tt.where}, &data)
+		ids := []string{}
+		for _, todo := range data.Todos {
+			ids = append(ids, todo.ID)
+		}
+		if !slices.Equal(ids, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, ids, tt.want)
+		}
+	}
+}
+
+func TestTodoOrderValidate(t *testing.T) {
+	tests := []struct {
+		order   TodoOrder
+		wantErr string
+	}{
+		{order: TodoOrder{Direction: OrderDirectionAsc, Field: TodoOrderFieldTitle}},
+		{order: TodoOrder{Direction: OrderDirectionDesc, Field: TodoOrderFieldCreatedAt}},
+		{order: TodoOrder{Direction: "UP", Field: TodoOrderFieldTitle}, wantErr: `unknown order direction "UP"`},
+		{order: TodoOrder{Direction: OrderDirectionAsc, Field: "DUE_AT"}, wantErr: `unknown todo order field "DUE_AT"`},
+		{order: TodoOrder{Field: TodoOrderFieldTitle}, wantErr: `unknown order direction ""`},
+	}
+	for _, tt := range tests {
+		msg := ""
+		if err := tt.order.Validate(); err != nil {
+			msg = err.Error()
+		}
+		if msg != tt.wantErr {
+			t.Errorf("%+v: got error %q, want %q", tt.order, msg, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/project"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/audit"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+)
+
+// TodoRevision GraphQL resolver
+type TodoRevisionResolver struct {
+	root     *Resolver
+	revision *ent.TodoRevision
+}
+
+func (r *TodoRevisionResolver) ID() graphql.ID {
+	return graphql.ID(strconv.Itoa(r.revision.ID))
+}
+
+func (r *TodoRevisionResolver) Operation() string {
+	return r.revision.Operation.String()
+}
+
+func (r *TodoRevisionResolver) Actor(ctx context.Context) (*UserResolver, error) {
+	if r.revision.ActorID == 0 {
+		return nil, nil
+	}
+
+	user, err := r.root.loaders(ctx).users.load(ctx, r.revision.ActorID)
+	if err != nil || user == nil {
+		return nil, err
+	}
+
+	return &UserResolver{user: user}, nil
+}
+
+func (r *TodoRevisionResolver) Changes() []*FieldChangeResolver {
+	changes := make([]*FieldChangeResolver, len(r.revision.Fields))
+	for i, field := range r.revision.Fields {
+		changes[i] = &FieldChangeResolver{
+			field:    field,
+			oldValue: r.revision.OldValues[field],
+			newValue: r.revision.NewValues[field],
+		}
+	}
+	return changes
+}
+
+func (r *TodoRevisionResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.revision.CreatedAt}
+}
+
+// FieldChange GraphQL resolver
+type FieldChangeResolver struct {
+	field              string
+	oldValue, newValue any
+}
+
+func (r *FieldChangeResolver) Field() string {
+	return r.field
+}
+
+func (r *FieldChangeResolver) OldValue() *string {
+	return formatValue(r.oldValue)
+}
+
+func (r *FieldChangeResolver) NewValue() *string {
+	return formatValue(r.newValue)
+}
+
+// formatValue formats a value recorded in a revision, nil for unset fields.
+func formatValue(v any) *string {
+	if v == nil {
+		return nil
+	}
+	s := fmt.Sprint(v)
+	return &s
+}
+
+func (r *TodoResolver) History(ctx context.Context) ([]*TodoRevisionResolver, error) {
+	revisions, err := r.root.loaders(ctx).revisions.load(ctx, r.todo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*TodoRevisionResolver, len(revisions))
+	for i, revision := range revisions {
+		resolvers[i] = &TodoRevisionResolver{root: r.root, revision: revision}
+	}
+	return resolvers, nil
+}
+
+// Mutation resolvers
+
+// RevertTodo restores a todo to its state right after one of its revisions.
+// The revert is checked and completes the todo like any update: the restored
+// project and parent must exist, and a todo blocked by open todos cannot be
+// completed again.
+func (r *Resolver) RevertTodo(ctx context.Context, args struct {
+	ID         graphql.ID
+	RevisionID graphql.ID
+}) (*TodoResolver, error) {
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := r.ownedTodoID(ctx, viewer, args.ID)
+	if err != nil {
+		return nil, err
+	}
+	revisionID, err := strconv.Atoi(string(args.RevisionID))
+	if err != nil {
+		return nil, fmt.Errorf("invalid revision ID: %w", err)
+	}
+
+	var updated *ent.Todo
+	err = r.withTx(ctx, func(client *ent.Client) error {
+		builder := client.Todo.UpdateOneID(id)
+		m := builder.Mutation()
+		if err := audit.Revert(ctx, client, m, id, revisionID); err != nil {
+			return err
+		}
+		if err := checkRevertRefs(ctx, client, viewer, m); err != nil {
+			return err
+		}
+
+		if updated, err = builder.Save(ctx); err != nil {
+			return err
+		}
+		if completed, ok := m.Completed(); !ok || !completed {
+			return nil
+		}
+		return completeTodo(ctx, client, updated, nil, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.todoResolver(updated), nil
+}
+
+// checkRevertRefs checks that the project and the parent todo a revert
+// restores still exist and belong to the viewer.
+func checkRevertRefs(ctx context.Context, client *ent.Client, viewer *ent.User, m *ent.TodoMutation) error {
+	if projectID, ok := m.ProjectID(); ok {
+		exists, err := client.Project.Query().
+			Where(project.ID(projectID), project.OwnerID(viewer.ID)).
+			Exist(ctx)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("project %d not found", projectID)
+		}
+	}
+	if parentID, ok := m.ParentID(); ok {
+		exists, err := client.Todo.Query().
+			Where(todo.ID(parentID), todo.OwnerID(viewer.ID)).
+			Exist(ctx)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("todo %d not found", parentID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package graph
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todorevision"
+)
+
+// lastRevision returns the ID of the latest revision of todo 1.
+func lastRevision(ctx context.Context, client *ent.Client) int {
+	return client.TodoRevision.Query().
+		Where(todorevision.TodoID(1)).
+		Order(ent.Desc(todorevision.FieldID)).
+		FirstIDX(ctx)
+}
+
+func TestRevertTodo(t *testing.T) {
+	remindAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		// setup creates todo 1 and changes it, returning the revision to
+		// revert it to.
+		setup   func(ctx context.Context, client *ent.Client, ownerID int) int
+		wantErr string
+		// check checks the todos once the revert is done.
+		check func(t *testing.T, ctx context.Context, client *ent.Client)
+	}{
+		{
+			name: "completed recurring todo",
+			setup: func(ctx context.Context, client *ent.Client, ownerID int) int {
+				client.Todo.Create().SetTitle("Water plants").SetRecurrence("FREQ=DAILY").SetDueAt(remindAt).SetOwnerID(ownerID).SaveX(ctx)
+				client.Todo.UpdateOneID(1).SetCompleted(true).ExecX(ctx)
+				rev := lastRevision(ctx, client)
+				client.Todo.UpdateOneID(1).SetCompleted(false).ExecX(ctx)
+				return rev
+			},
+			check: func(t *testing.T, ctx context.Context, client *ent.Client) {
+				if !client.Todo.GetX(ctx, 1).Completed {
+					t.Error("the todo is not completed")
+				}
+				if !client.Todo.Query().Where(todo.PreviousOccurrenceID(1)).ExistX(ctx) {
+					t.Error("completing the todo created no next occurrence")
+				}
+			},
+		},
+		{
+			name: "completed blocked todo",
+			setup: func(ctx context.Context, client *ent.Client, ownerID int) int {
+				client.Todo.Create().SetTitle("Paint the fence").SetOwnerID(ownerID).SaveX(ctx)
+				client.Todo.Create().SetTitle("Buy paint").SetOwnerID(ownerID).SaveX(ctx)
+				client.Todo.UpdateOneID(1).SetCompleted(true).ExecX(ctx)
+				rev := lastRevision(ctx, client)
+				client.Todo.UpdateOneID(1).SetCompleted(false).AddBlockedByIDs(2).ExecX(ctx)
+				return rev
+			},
+			wantErr: "todo is blocked by open todos",
+			check: func(t *testing.T, ctx context.Context, client *ent.Client) {
+				if client.Todo.GetX(ctx, 1).Completed {
+					t.Error("the failed revert completed the todo")
+				}
+			},
+		},
+		{
+			name: "remind time",
+			setup: func(ctx context.Context, client *ent.Client, ownerID int) int {
+				client.Todo.Create().SetTitle("Call the dentist").SetRemindAt(remindAt).SetOwnerID(ownerID).SaveX(ctx)
+				rev := lastRevision(ctx, client)
+				client.Todo.UpdateOneID(1).SetRemindAt(remindAt.Add(time.Hour)).SetReminderSentAt(remindAt.Add(time.Hour)).ExecX(ctx)
+				return rev
+			},
+			check: func(t *testing.T, ctx context.Context, client *ent.Client) {
+				got := client.Todo.GetX(ctx, 1)
+				if got.RemindAt == nil || !got.RemindAt.Equal(remindAt) || got.ReminderSentAt != nil {
+					t.Errorf("got remind time %v, reminder sent at %v, want %v, no reminder sent", got.RemindAt, got.ReminderSentAt, remindAt)
+				}
+			},
+		},
+		{
+			name: "deleted project",
+			setup: func(ctx context.Context, client *ent.Client, ownerID int) int {
+				project := client.Project.Create().SetName("Home").SetOwnerID(ownerID).SaveX(ctx)
+				client.Todo.Create().SetTitle("Fix the sink").SetProject(project).SetOwnerID(ownerID).SaveX(ctx)
+				rev := lastRevision(ctx, client)
+				client.Todo.UpdateOneID(1).ClearProjectID().ExecX(ctx)
+				client.Project.DeleteOne(project).ExecX(ctx)
+				return rev
+			},
+			wantErr: "project 1 not found",
+		},
+		{
+			name: "trashed parent",
+			setup: func(ctx context.Context, client *ent.Client, ownerID int) int {
+				client.Todo.Create().SetTitle("Book the train").SetOwnerID(ownerID).SaveX(ctx)
+				client.Todo.Create().SetTitle("Plan the trip").SetOwnerID(ownerID).SaveX(ctx)
+				client.Todo.UpdateOneID(1).SetParentID(2).ExecX(ctx)
+				rev := lastRevision(ctx, client)
+				client.Todo.UpdateOneID(1).ClearParentID().ExecX(ctx)
+				client.Todo.DeleteOneID(2).ExecX(ctx)
+				return rev
+			},
+			wantErr: "todo 2 not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t)
+			ctx := signIn(t, client, "alice@example.com")
+			schema := newTestSchema(client)
+			rev := tt.setup(ctx, client, 1)
+
+			msg := exec(ctx, schema, `mutation($rev: ID!) { revertTodo(id: "1", revisionId: $rev) { id } }`,
+				map[string]any{"rev": strconv.Itoa(rev)}, nil)
+			if msg != tt.wantErr {
+				t.Errorf("got error %q, want %q", msg, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, ctx, client)
+			}
+		})
+	}
+}
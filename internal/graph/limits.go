@@ -0,0 +1,267 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	qerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/lexer"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+const (
+	// defaultListSize is the number of items of the list fields without a
+	// @listSize annotation.
+	defaultListSize = 10
+	// maxCost caps costs, so that huge lists cannot overflow them.
+	maxCost = math.MaxInt32
+)
+
+// costSchema is Schema loaded with gqlparser, which unlike graphql-go gives
+// access to the directives annotating the fields.
+var costSchema = sync.OnceValue(func() *ast.Schema {
+	return gqlparser.MustLoadSchema(&ast.Source{Name: "schema", Input: Schema})
+})
+
+// Limits bound the operations the server runs. Zero values disable a limit.
+type Limits struct {
+	// MaxDepth bounds how deeply the fields of an operation nest.
+	MaxDepth int
+	// MaxComplexity bounds the cost of an operation.
+	MaxComplexity int
+	// MaxTokens bounds the number of tokens of a query.
+	MaxTokens int
+}
+
+// Check returns errors rejecting the operation if it exceeds the limits,
+// listing its measured complexity. Queries that do not parse or validate are
+// left for the schema to reject.
+func (l Limits) Check(query, operationName string, variables map[string]interface{}) []*qerrors.QueryError {
+	if l.MaxTokens > 0 && !withinTokens(query, l.MaxTokens) {
+		return []*qerrors.QueryError{{
+			Message: fmt.Sprintf("query exceeds the limit of %d tokens", l.MaxTokens),
+			Extensions: map[string]interface{}{
+				"code":      CodeQueryTooLarge,
+				"maxTokens": l.MaxTokens,
+			},
+		}}
+	}
+	if l.MaxDepth <= 0 && l.MaxComplexity <= 0 {
+		return nil
+	}
+
+	c, err := Measure(query, operationName, variables)
+	if err != nil {
+		return nil
+	}
+	extensions := map[string]interface{}{
+		"code":          CodeQueryTooComplex,
+		"depth":         c.Depth,
+		"maxDepth":      l.MaxDepth,
+		"cost":          c.Cost,
+		"maxComplexity": l.MaxComplexity,
+	}
+	var errs []*qerrors.QueryError
+	if l.MaxDepth > 0 && c.Depth > l.MaxDepth {
+		errs = append(errs, &qerrors.QueryError{
+			Message:    fmt.Sprintf("query has depth %d, exceeding the maximum of %d", c.Depth, l.MaxDepth),
+			Extensions: extensions,
+		})
+	}
+	if l.MaxComplexity > 0 && c.Cost > l.MaxComplexity {
+		errs = append(errs, &qerrors.QueryError{
+			Message:    fmt.Sprintf("query costs %d, exceeding the maximum of %d", c.Cost, l.MaxComplexity),
+			Extensions: extensions,
+		})
+	}
+	return errs
+}
+
+// withinTokens reports whether query has at most max tokens.
+func withinTokens(query string, max int) bool {
+	lex := lexer.New(&ast.Source{Input: query})
+	for n := 0; n <= max; n++ {
+		tok, err := lex.ReadToken()
+		if err != nil || tok.Kind == lexer.EOF {
+			// Lexing errors are reported by the schema.
+			return true
+		}
+	}
+	return false
+}
+
+// Complexity is the measure of an operation.
+type Complexity struct {
+	// Depth is how deeply the fields of the operation nest, its top-level
+	// fields being at depth 1.
+	Depth int
+	// Cost estimates the work of the operation: every field costs its @cost
+	// weight times the number of items of the lists it is in, as given by
+	// the @listSize annotations of the schema.
+	Cost int
+}
+
+// Measure returns the complexity of the operation named operationName in
+// query, or of its only operation. Introspection fields are not counted.
+func Measure(query, operationName string, variables map[string]interface{}) (Complexity, error) {
+	schema := costSchema()
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return Complexity{}, err
+	}
+	if errs := validator.Validate(schema, doc); len(errs) > 0 {
+		return Complexity{}, errs
+	}
+	op := doc.Operations.ForName(operationName)
+	if op == nil {
+		return Complexity{}, fmt.Errorf("unknown operation %q", operationName)
+	}
+	vars, err := validator.VariableValues(schema, op, variables)
+	if err != nil {
+		return Complexity{}, err
+	}
+
+	m := measurer{schema: schema, vars: vars}
+	return m.selections(op.SelectionSet, 1, nil), nil
+}
+
+type measurer struct {
+	schema *ast.Schema
+	vars   map[string]interface{}
+}
+
+// selections measures the fields of set, which are at depth. sized holds
+// the sizes of the fields sized by the field set belongs to.
+func (m *measurer) selections(set ast.SelectionSet, depth int, sized map[string]int) Complexity {
+	var c Complexity
+	for _, sel := range set {
+		var sc Complexity
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(sel.Name, "__") {
+				continue
+			}
+			sc = m.field(sel, depth, sized)
+		case *ast.InlineFragment:
+			sc = m.selections(sel.SelectionSet, depth, sized)
+		case *ast.FragmentSpread:
+			sc = m.selections(sel.Definition.SelectionSet, depth, sized)
+		}
+		c.Depth = max(c.Depth, sc.Depth)
+		c.Cost = min(c.Cost+sc.Cost, maxCost)
+	}
+	return c
+}
+
+func (m *measurer) field(f *ast.Field, depth int, sized map[string]int) Complexity {
+	def := f.Definition
+	weight := 0
+	if t := m.schema.Types[def.Type.Name()]; t != nil && t.IsCompositeType() {
+		weight = 1
+	}
+	if d := def.Directives.ForName("cost"); d != nil {
+		weight = intArgument(d, "weight", weight)
+	}
+
+	size := 1
+	var fieldsSized map[string]int
+	if n, ok := sized[f.Name]; ok {
+		size = n
+	} else if d := def.Directives.ForName("listSize"); d != nil {
+		size = m.listSize(f, d)
+		if names := stringsArgument(d, "sizedFields"); len(names) > 0 {
+			fieldsSized = make(map[string]int, len(names))
+			for _, name := range names {
+				fieldsSized[name] = size
+			}
+			size = 1
+		}
+	} else if def.Type.Elem != nil {
+		size = defaultListSize
+	}
+
+	children := m.selections(f.SelectionSet, depth+1, fieldsSized)
+	return Complexity{
+		Depth: max(depth, children.Depth),
+		Cost:  mulCost(size, min(weight+children.Cost, maxCost)),
+	}
+}
+
+// listSize returns the number of items of f, annotated with the @listSize
+// directive d.
+func (m *measurer) listSize(f *ast.Field, d *ast.Directive) int {
+	for _, name := range stringsArgument(d, "slicingArguments") {
+		arg := f.Arguments.ForName(name)
+		if arg == nil {
+			continue
+		}
+		v, err := arg.Value.Value(m.vars)
+		if err != nil {
+			continue
+		}
+		if items, ok := v.([]interface{}); ok {
+			return len(items)
+		}
+		if n, ok := toInt(v); ok {
+			return max(n, 0)
+		}
+	}
+	return intArgument(d, "assumedSize", defaultListSize)
+}
+
+func mulCost(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	if a > maxCost/b {
+		return maxCost
+	}
+	return a * b
+}
+
+func intArgument(d *ast.Directive, name string, fallback int) int {
+	if arg := d.Arguments.ForName(name); arg != nil {
+		v, _ := arg.Value.Value(nil)
+		if n, ok := toInt(v); ok {
+			return n
+		}
+	}
+	return fallback
+}
+
+func stringsArgument(d *ast.Directive, name string) []string {
+	arg := d.Arguments.ForName(name)
+	if arg == nil {
+		return nil
+	}
+	var names []string
+	for _, child := range arg.Value.Children {
+		names = append(names, child.Value.Raw)
+	}
+	return names
+}
+
+// toInt converts an integer argument, either a literal or a variable decoded
+// from JSON, to an int.
+func toInt(v interface{}) (int, bool) {
+	switch v := v.(type) {
+	case int:
+		return v, true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(min(max(v, math.MinInt32), maxCost)), true
+	case float64:
+		return int(min(max(v, math.MinInt32), maxCost)), true
+	case json.Number:
+		n, err := v.Int64()
+		return int(min(max(n, math.MinInt32), maxCost)), err == nil
+	}
+	return 0, false
+}
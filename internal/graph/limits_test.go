@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMeasure(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		want      Complexity
+	}{
+		{name: "scalars are free", query: `{ todo(id: 1) { id title } }`, want: Complexity{Depth: 2, Cost: 1}},
+		{name: "assumed size", query: `{ todos { id } }`, want: Complexity{Depth: 2, Cost: 50}},
+		{name: "default list size", query: `{ todos { tags { name } } }`, want: Complexity{Depth: 3, Cost: 50 * (1 + 10)}},
+		{name: "weighted scalar", query: `{ todo(id: 1) { progress isBlocked } }`, want: Complexity{Depth: 2, Cost: 3}},
+		{name: "weighted list", query: `{ searchTodos(query: "milk", first: 4) { id } }`, want: Complexity{Depth: 2, Cost: 4 * 5}},
+		{name: "list argument", query: `mutation { createTodos(inputs: [{title: "a"}, {title: "b"}]) { id } }`, want: Complexity{Depth: 2, Cost: 2}},
+		{
+			name:  "sized fields",
+			query: `{ todosConnection(first: 5) { totalCount edges { node { id } } pageInfo { hasNextPage } } }`,
+			want:  Complexity{Depth: 4, Cost: 1 + 1 + 5*(1+1) + 1},
+		},
+		{
+			name:  "sized fields of the assumed size",
+			query: `{ todosConnection { edges { node { id } } } }`,
+			want:  Complexity{Depth: 4, Cost: 1 + 20*(1+1)},
+		},
+		{
+			name:      "slicing variable",
+			query:     `query($n: Int) { todosConnection(last: $n) { edges { node { id } } } }`,
+			variables: map[string]interface{}{"n": 3},
+			want:      Complexity{Depth: 4, Cost: 1 + 3*(1+1)},
+		},
+		{
+			name:  "fragments",
+			query: `{ todo(id: 1) { ...parent ... on Todo { project { id } } } } fragment parent on Todo { parent { id } }`,
+			want:  Complexity{Depth: 3, Cost: 1 + 1 + 1},
+		},
+		{name: "introspection", query: `{ __schema { types { name } } }`, want: Complexity{}},
+		{
+			name:  "overflow",
+			query: `{ todosConnection(first: 2147483647) { edges { node { children { children { children { id } } } } } } }`,
+			want:  Complexity{Depth: 7, Cost: math.MaxInt32},
+		},
+	}
+	for _, tt := range tests {
+		got, err := Measure(tt.query, "", tt.variables)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLimitsCheck(t *testing.T) {
+	tests := []struct {
+		name          string
+		limits        Limits
+		query         string
+		operationName string
+		wantCodes     []string
+	}{
+		{name: "no limits", query: `{ todos { children { children { id } } } }`},
+		{name: "within limits", limits: Limits{MaxDepth: 2, MaxComplexity: 50, MaxTokens: 10}, query: `{ todos { id } }`},
+		{name: "too deep", limits: Limits{MaxDepth: 2}, query: `{ todos { tags { name } } }`, wantCodes: []string{CodeQueryTooComplex}},
+		{name: "too costly", limits: Limits{MaxComplexity: 49}, query: `{ todos { id } }`, wantCodes: []string{CodeQueryTooComplex}},
+		{
+			name:      "too deep and costly",
+			limits:    Limits{MaxDepth: 2, MaxComplexity: 100},
+			query:     `{ todos { tags { name } } }`,
+			wantCodes: []string{CodeQueryTooComplex, CodeQueryTooComplex},
+		},
+		{name: "too many tokens", limits: Limits{MaxTokens: 10, MaxDepth: 1}, query: `{ todo(id: 1) { id } }`, wantCodes: []string{CodeQueryTooLarge}},
+		{name: "exactly the tokens", limits: Limits{MaxTokens: 11}, query: `{ todo(id: 1) { id } }`},
+		{name: "overflowing cost", limits: Limits{MaxComplexity: math.MaxInt32 - 1}, query: `{ todosConnection(first: 2147483647) { edges { node { children { children { id } } } } } }`, wantCodes: []string{CodeQueryTooComplex}},
+		{name: "invalid query", limits: Limits{MaxDepth: 1}, query: `{ todos { unknown { id } } }`},
+		{name: "unknown operation", limits: Limits{MaxDepth: 1}, query: `query a { todos { tags { name } } }`, operationName: "b"},
+		{name: "named operation", limits: Limits{MaxDepth: 2}, query: `query a { todos { id } } query b { todos { tags { name } } }`, operationName: "b", wantCodes: []string{CodeQueryTooComplex}},
+	}
+	for _, tt := range tests {
+		errs := tt.limits.Check(tt.query, tt.operationName, nil)
+		var codes []string
+		for _, err := range errs {
+			codes = append(codes, err.Extensions["code"].(string))
+		}
+		if len(codes) != len(tt.wantCodes) {
+			t.Errorf("%s: got errors %v, want codes %v", tt.name, errs, tt.wantCodes)
+			continue
+		}
+		for i, code := range codes {
+			if code != tt.wantCodes[i] {
+				t.Errorf("%s: got code %s, want %s", tt.name, code, tt.wantCodes[i])
+			}
+		}
+	}
+
+	errs := Limits{MaxDepth: 2, MaxComplexity: 1000}.Check(`{ todos { tags { name } } }`, "", nil)
+	if len(errs) != 1 {
+		t.Fatalf("got errors %v, want one", errs)
+	}
+	ext := errs[0].Extensions
+	if ext["depth"] != 3 || ext["maxDepth"] != 2 || ext["cost"] != 550 || ext["maxComplexity"] != 1000 {
+		t.Errorf("got extensions %v", ext)
+	}
+	if want := "query has depth 3, exceeding the maximum of 2"; errs[0].Message != want {
+		t.Errorf("got message %q, want %q", errs[0].Message, want)
+	}
+}
@@ -0,0 +1,352 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/gorilla/websocket"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/attachment"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/comment"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/predicate"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/project"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/tag"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todorevision"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/user"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/softdelete"
+)
+
+const (
+	// loaderWait is how long a loader collects keys before fetching them.
+	loaderWait = 2 * time.Millisecond
+	// loaderMaxBatch is the largest number of keys a loader fetches at once.
+	loaderMaxBatch = 100
+)
+
+// MaxParallelism is the number of resolvers to let the schema run in
+// parallel with graphql.MaxParallelism. Resolvers waiting for a loader hold
+// on to their slot, so it bounds how many edges the loaders batch together.
+const MaxParallelism = loaderMaxBatch
+
+// loader batches the loads of values by key made within a short wait into a
+// single fetch. Keys the fetch does not return load as the zero value. Values
+// are not kept past their batch, so that the fields of a mutation see the
+// changes of the mutations run before it in the same request.
+type loader[K comparable, V any] struct {
+	fetch    func(ctx context.Context, keys []K) (map[K]V, error)
+	wait     time.Duration
+	maxBatch int
+
+	mu    sync.Mutex
+	batch *loaderBatch[K, V]
+	// pending maps the keys being loaded to their batch.
+	pending map[K]*loaderBatch[K, V]
+}
+
+// loaderBatch is a set of keys fetched together.
+type loaderBatch[K comparable, V any] struct {
+	keys []K
+	// full is closed when the batch reaches the largest size.
+	full chan struct{}
+	// done is closed when values and err are set.
+	done   chan struct{}
+	values map[K]V
+	err    error
+}
+
+func newLoader[K comparable, V any](wait time.Duration, maxBatch int, fetch func(context.Context, []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		pending:  make(map[K]*loaderBatch[K, V]),
+	}
+}
+
+// load returns the value of key, fetching it along with the keys loaded by
+// other resolvers in the meantime. The fetch runs with the context of the
+// first load of the batch.
+func (l *loader[K, V]) load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	b, ok := l.pending[key]
+	if !ok {
+		if l.batch == nil {
+			l.batch = &loaderBatch[K, V]{
+				full: make(chan struct{}),
+				done: make(chan struct{}),
+			}
+			go l.dispatch(ctx, l.batch)
+		}
+		b = l.batch
+		b.keys = append(b.keys, key)
+		l.pending[key] = b
+		if len(b.keys) >= l.maxBatch {
+			l.batch = nil
+			close(b.full)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-b.done:
+		return b.values[key], b.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// dispatch fetches the keys of b once the wait is over or b is full.
+func (l *loader[K, V]) dispatch(ctx context.Context, b *loaderBatch[K, V]) {
+	timer := time.NewTimer(l.wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		l.mu.Lock()
+		if l.batch == b {
+			l.batch = nil
+		}
+		l.mu.Unlock()
+	case <-b.full:
+	}
+
+	b.values, b.err = l.fetch(ctx, b.keys)
+	l.mu.Lock()
+	for _, key := range b.keys {
+		delete(l.pending, key)
+	}
+	l.mu.Unlock()
+	close(b.done)
+}
+
+// Loaders batch the edge lookups of the resolvers of a request, which would
+// otherwise issue one query per todo of a list. Like the resolvers, they hide
+// trashed todos.
+type Loaders struct {
+	users           *loader[int, *ent.User]
+	projects        *loader[int, *ent.Project]
+	todos           *loader[int, *ent.Todo]
+	children        *loader[int, []*ent.Todo]
+	nextOccurrences *loader[int, *ent.Todo]
+	tags            *loader[int, []*ent.Tag]
+	blocks          *loader[int, []*ent.Todo]
+	blockedBy       *loader[int, []*ent.Todo]
+	revisions       *loader[int, []*ent.TodoRevision]
+	attachments     *loader[int, []*ent.Attachment]
+	commentCounts   *loader[int, int]
+	commentPages    *loader[commentPage, []*ent.Comment]
+}
+
+// commentPage is the key of the first comments of a todo, up to size.
+type commentPage struct {
+	todoID int
+	size   int
+}
+
+// NewLoaders returns loaders reading from client, to be used for a single
+// request.
+func NewLoaders(client *ent.Client) *Loaders {
+	return newLoaders(client, loaderWait, loaderMaxBatch)
+}
+
+func newLoaders(client *ent.Client, wait time.Duration, maxBatch int) *Loaders {
+	return &Loaders{
+		users: newLoader(wait, maxBatch, func(ctx context.Context, ids []int) (map[int]*ent.User, error) {
+			users, err := client.User.Query().Where(user.IDIn(ids...)).All(ctx)
+			return byKey(users, err, func(u *ent.User) int { return u.ID })
+		}),
+		projects: newLoader(wait, maxBatch, func(ctx context.Context, ids []int) (map[int]*ent.Project, error) {
+			projects, err := client.Project.Query().Where(project.IDIn(ids...)).All(ctx)
+			return byKey(projects, err, func(p *ent.Project) int { return p.ID })
+		}),
+		todos: newLoader(wait, maxBatch, func(ctx context.Context, ids []int) (map[int]*ent.Todo, error) {
+			todos, err := client.Todo.Query().Where(todo.IDIn(ids...)).All(ctx)
+			return byKey(todos, err, func(t *ent.Todo) int { return t.ID })
+		}),
+		children: newLoader(wait, maxBatch, func(ctx context.Context, ids []int) (map[int][]*ent.Todo, error) {
+			children, err := client.Todo.Query().
+				Where(todo.ParentIDIn(ids...)).
+				Order(ent.Asc(todo.FieldPosition), ent.Asc(todo.FieldID)).
+				All(ctx)
+			return groupByKey(children, err, func(t *ent.Todo) int { return t.ParentID })
+		}),
+		nextOccurrences: newLoader(wait, maxBatch, func(ctx context.Context, ids []int) (map[int]*ent.Todo, error) {
+			todos, err := client.Todo.Query().Where(todo.PreviousOccurrenceIDIn(ids...)).All(ctx)
+			return byKey(todos, err, func(t *ent.Todo) int { return t.PreviousOccurrenceID })
+		}),
+		// The todos whose edges are loaded may be in the trash, so the edges
+		// are loaded through them regardless of the trash. The todos at the
+		// other end are still filtered.
+		tags: newLoader(wait, maxBatch, func(ctx context.Context, ids []int) (map[int][]*ent.Tag, error) {
+			todos, err := client.Todo.Query().
+				Where(todo.IDIn(ids...)).
+				WithTags(func(q *ent.TagQuery) {
+					q.Order(ent.Asc(tag.FieldName))
+				}).
+				All(softdelete.Skip(ctx))
+			return edgesByKey(todos, err, func(t *ent.Todo) []*ent.Tag { return t.Edges.Tags })
+		}),
+		blocks: newLoader(wait, maxBatch, func(ctx context.Context, ids []int) (map[int][]*ent.Todo, error) {
+			todos, err := client.Todo.Query().
+				Where(todo.IDIn(ids...)).
+				WithBlocks(orderNeighbors).
+				All(softdelete.Skip(ctx))
+			return edgesByKey(todos, err, func(t *ent.Todo) []*ent.Todo { return t.Edges.Blocks })
+		}),
+		blockedBy: newLoader(wait, maxBatch, func(ctx context.Context, ids []int) (map[int][]*ent.Todo, error) {
+			todos, err := client.Todo.Query().
+				Where(todo.IDIn(ids...)).
+				WithBlockedBy(orderNeighbors).
+				All(softdelete.Skip(ctx))
+			return edgesByKey(todos, err, func(t *ent.Todo) []*ent.Todo { return t.Edges.BlockedBy })
+		}),
+		revisions: newLoader(wait, maxBatch, func(ctx context.Context, ids []int) (map[int][]*ent.TodoRevision, error) {
+			revisions, err := client.TodoRevision.Query().
+				Where(todorevision.TodoIDIn(ids...)).
+				Order(ent.Desc(todorevision.FieldID)).
+				All(ctx)
+			return groupByKey(revisions, err, func(r *ent.TodoRevision) int { return r.TodoID })
+		}),
+		attachments: newLoader(wait, maxBatch, func(ctx context.Context, ids []int) (map[int][]*ent.Attachment, error) {
+			attachments, err := client.Attachment.Query().
+				Where(attachment.TodoIDIn(ids...)).
+				Order(ent.Asc(attachment.FieldID)).
+				All(ctx)
+			return groupByKey(attachments, err, func(a *ent.Attachment) int { return a.TodoID })
+		}),
+		commentCounts: newLoader(wait, maxBatch, func(ctx context.Context, ids []int) (map[int]int, error) {
+			var counts []struct {
+				TodoID int `json:"todo_id"`
+				Count  int `json:"count"`
+			}
+			err := client.Comment.Query().
+				Where(comment.TodoIDIn(ids...)).
+				GroupBy(comment.FieldTodoID).
+				Aggregate(ent.Count()).
+				Scan(ctx, &counts)
+			if err != nil {
+				return nil, err
+			}
+			m := make(map[int]int, len(counts))
+			for _, c := range counts {
+				m[c.TodoID] = c.Count
+			}
+			return m, nil
+		}),
+		commentPages: newLoader(wait, maxBatch, func(ctx context.Context, pages []commentPage) (map[commentPage][]*ent.Comment, error) {
+			// The todos of a list are usually asked the same page size.
+			bySize := make(map[int][]int)
+			for _, p := range pages {
+				bySize[p.size] = append(bySize[p.size], p.todoID)
+			}
+			m := make(map[commentPage][]*ent.Comment, len(pages))
+			for size, ids := range bySize {
+				comments, err := client.Comment.Query().
+					Where(comment.TodoIDIn(ids...), firstComments(ids, size)).
+					Order(ent.Asc(comment.FieldID)).
+					All(ctx)
+				if err != nil {
+					return nil, err
+				}
+				for _, c := range comments {
+					p := commentPage{todoID: c.TodoID, size: size}
+					m[p] = append(m[p], c)
+				}
+			}
+			return m, nil
+		}),
+	}
+}
+
+// firstComments selects the oldest comments of each of the todos, up to n
+// per todo.
+func firstComments(todoIDs []int, n int) predicate.Comment {
+	return func(s *sql.Selector) {
+		t := sql.Table(comment.Table)
+		numbered := sql.Select(t.C(comment.FieldID)).
+			AppendSelectExprAs(sql.RowNumber().PartitionBy(t.C(comment.FieldTodoID)).OrderBy(t.C(comment.FieldID)), "n").
+			From(t).
+			Where(sql.InInts(t.C(comment.FieldTodoID), todoIDs...)).
+			As("numbered")
+		s.Where(sql.In(s.C(comment.FieldID),
+			sql.Select(numbered.C(comment.FieldID)).From(numbered).Where(sql.LTE(numbered.C("n"), n)),
+		))
+	}
+}
+
+// orderNeighbors hides trashed todos from an eager-loaded todo edge, which
+// the trash of the context does not apply to, and orders them as listings.
+func orderNeighbors(q *ent.TodoQuery) {
+	q.Where(todo.DeletedAtIsNil()).
+		Order(ent.Asc(todo.FieldPosition), ent.Asc(todo.FieldID))
+}
+
+func byKey[V any](values []V, err error, key func(V) int) (map[int]V, error) {
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[int]V, len(values))
+	for _, v := range values {
+		m[key(v)] = v
+	}
+	return m, nil
+}
+
+func groupByKey[V any](values []V, err error, key func(V) int) (map[int][]V, error) {
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[int][]V)
+	for _, v := range values {
+		m[key(v)] = append(m[key(v)], v)
+	}
+	return m, nil
+}
+
+func edgesByKey[V any](todos []*ent.Todo, err error, edges func(*ent.Todo) []V) (map[int][]V, error) {
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[int][]V, len(todos))
+	for _, t := range todos {
+		m[t.ID] = edges(t)
+	}
+	return m, nil
+}
+
+type loadersKey struct{}
+
+// WithLoaders returns a copy of ctx carrying l.
+func WithLoaders(ctx context.Context, l *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+// loaders returns the loaders of the request of ctx. Without any, as for
+// subscriptions, it returns loaders that do not batch.
+func (r *Resolver) loaders(ctx context.Context) *Loaders {
+	if l, ok := ctx.Value(loadersKey{}).(*Loaders); ok {
+		return l
+	}
+	return newLoaders(r.Client, loaderWait, 1)
+}
+
+// LoaderMiddleware gives every request its own Loaders. WebSocket upgrades
+// are left alone: the events of a subscription are resolved one at a time,
+// leaving nothing to batch.
+func LoaderMiddleware(client *ent.Client) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if websocket.IsWebSocketUpgrade(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx := WithLoaders(r.Context(), NewLoaders(client))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
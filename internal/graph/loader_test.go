@@ -0,0 +1,203 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	graphql "github.com/graph-gophers/graphql-go"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/enttest"
+	_ "github.com/ishidatakuo/graphql-ent-atlas/ent/runtime"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+)
+
+// testLoaderWait leaves the resolvers of a test plenty of time to join a
+// batch, however slow the machine.
+const testLoaderWait = 50 * time.Millisecond
+
+// countingDriver counts the queries run outside transactions.
+type countingDriver struct {
+	dialect.Driver
+	queries atomic.Int64
+}
+
+func (d *countingDriver) Query(ctx context.Context, query string, args, v any) error {
+	d.queries.Add(1)
+	return d.Driver.Query(ctx, query, args, v)
+}
+
+func newTestClient(t *testing.T) (*ent.Client, *countingDriver) {
+	t.Helper()
+	drv, err := entsql.Open(dialect.SQLite, "file:ent?mode=memory&_fk=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every connection opens its own in-memory database.
+	drv.DB().SetMaxOpenConns(1)
+	counter := &countingDriver{Driver: drv}
+	client := enttest.NewClient(t, enttest.WithOptions(ent.Driver(counter)))
+	t.Cleanup(func() { client.Close() })
+	return client, counter
+}
+
+// seedTodos creates a user with n todos in a project, each with two tags, an
+// attachment and a comment. Every odd todo is a subtask of, blocked by and the
+// next occurrence of the todo before it. It returns a context authenticated
+// as the user.
+func seedTodos(t *testing.T, client *ent.Client, n int) context.Context {
+	t.Helper()
+	ctx := context.Background()
+	user := client.User.Create().SetEmail("alice@example.com").SetDisplayName("Alice").SaveX(ctx)
+	ctx = auth.NewContext(ctx, &auth.Principal{User: user})
+
+	project := client.Project.Create().SetName("Home").SetOwnerID(user.ID).SaveX(ctx)
+	tags := client.Tag.CreateBulk(
+		client.Tag.Create().SetName("errand").SetOwnerID(user.ID),
+		client.Tag.Create().SetName("weekly").SetOwnerID(user.ID),
+	).SaveX(ctx)
+
+	var previous *ent.Todo
+	for i := range n {
+		create := client.Todo.Create().
+			SetTitle("todo").
+			SetOwnerID(user.ID).
+			SetProjectID(project.ID).
+			AddTags(tags...)
+		if i%2 == 1 {
+			create.SetParentID(previous.ID).
+				AddBlockedByIDs(previous.ID).
+				SetPreviousOccurrenceID(previous.ID)
+		}
+		previous = create.SaveX(ctx)
+		client.Attachment.Create().
+			SetTodoID(previous.ID).
+			SetFilename("screenshot.png").
+			SetContentType("image/png").
+			SetSize(1024).
+			SetChecksum("0").
+			SetStorageKey(fmt.Sprintf("attachments/%d", i)).
+			SaveX(ctx)
+		client.Comment.Create().
+			SetTodoID(previous.ID).
+			SetAuthorID(user.ID).
+			SetBody("Done yet?").
+			SaveX(ctx)
+	}
+	return ctx
+}
+
+func TestLoadersBatchTodoEdges(t *testing.T) {
+	tests := []struct {
+		fields string
+		// queries is the number of queries run for the edges, whatever the
+		// number of todos.
+		queries int64
+	}{
+		{fields: `owner { id }`, queries: 1},
+		{fields: `project { name }`, queries: 1},
+		{fields: `tags { name }`, queries: 2},
+		{fields: `parent { id } previousOccurrence { id }`, queries: 1},
+		{fields: `children { id } progress`, queries: 1},
+		{fields: `nextOccurrence { id }`, queries: 1},
+		{fields: `blocks { id }`, queries: 2},
+		{fields: `blockedBy { id } isBlocked`, queries: 2},
+		{fields: `history { actor { id } }`, queries: 2},
+		{fields: `attachments { filename }`, queries: 1},
+		{fields: `comments { totalCount edges { node { body } } }`, queries: 2},
+	}
+	for _, tt := range tests {
+		for _, n := range []int{2, 10} {
+			client, counter := newTestClient(t)
+			ctx := seedTodos(t, client, n)
+			ctx = WithLoaders(ctx, newLoaders(client, testLoaderWait, loaderMaxBatch))
+			schema := graphql.MustParseSchema(Schema, &Resolver{Client: client}, graphql.MaxParallelism(MaxParallelism))
+
+			counter.queries.Store(0)
+			res := schema.Exec(ctx, `{ todos { id `+tt.fields+` } }`, "", nil)
+			if len(res.Errors) > 0 {
+				t.Fatalf("%s: %v", tt.fields, res.Errors)
+			}
+			// One more query lists the todos.
+			if got, want := counter.queries.Load(), tt.queries+1; got != want {
+				t.Errorf("%s of %d todos: got %d queries, want %d", tt.fields, n, got, want)
+			}
+		}
+	}
+}
+
+func TestLoadersMatchUnbatchedResults(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := seedTodos(t, client, 6)
+	client.Todo.UpdateOneID(1).SetCompleted(true).ExecX(ctx)
+	client.Todo.DeleteOneID(3).ExecX(ctx)
+	schema := graphql.MustParseSchema(Schema, &Resolver{Client: client}, graphql.MaxParallelism(MaxParallelism))
+
+	const query = `{
+		todos {
+			id
+			owner { id }
+			project { name }
+			tags { name }
+			parent { id }
+			children { id }
+			progress
+			blocks { id }
+			blockedBy { id }
+			isBlocked
+			previousOccurrence { id }
+			nextOccurrence { id }
+			history { operation actor { id } }
+			attachments { id filename }
+			comments(first: 1) { totalCount edges { cursor node { id body } } pageInfo { hasNextPage } }
+		}
+		trashedTodos { id tags { name } blocks { id } }
+	}`
+	unbatched := schema.Exec(ctx, query, "", nil)
+	batched := schema.Exec(WithLoaders(ctx, NewLoaders(client)), query, "", nil)
+	if len(unbatched.Errors) > 0 || len(batched.Errors) > 0 {
+		t.Fatalf("errors: %v, %v", unbatched.Errors, batched.Errors)
+	}
+	if string(batched.Data) != string(unbatched.Data) {
+		t.Errorf("batched results differ:\n got %s\nwant %s", batched.Data, unbatched.Data)
+	}
+
+	// Trashed todos are hidden from the edges that lead to them.
+	res := schema.Exec(WithLoaders(ctx, NewLoaders(client)), `{
+		todo(id: "4") { parent { id } blockedBy { id } previousOccurrence { id } }
+		blocked: todo(id: "2") { isBlocked }
+	}`, "", nil)
+	want := `{"todo":{"parent":null,"blockedBy":[],"previousOccurrence":null},"blocked":{"isBlocked":false}}`
+	if len(res.Errors) > 0 || string(res.Data) != want {
+		t.Errorf("got %s %v, want %s", res.Data, res.Errors, want)
+	}
+}
+
+func TestLoaderMiddleware(t *testing.T) {
+	client, _ := newTestClient(t)
+	var loaders *Loaders
+	handler := LoaderMiddleware(client)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		loaders, _ = r.Context().Value(loadersKey{}).(*Loaders)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/graphql", nil))
+	if loaders == nil {
+		t.Error("request has no loaders")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if loaders != nil {
+		t.Error("WebSocket upgrade has loaders")
+	}
+}
@@ -0,0 +1,181 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/position"
+)
+
+// Mutation resolvers
+
+// MoveTodo moves a todo after the todo "after", before the todo "before", or
+// between both. Only the position of the moved todo is rewritten, unless its
+// neighbours share a position, in which case only those are spread out.
+func (r *Resolver) MoveTodo(ctx context.Context, args struct {
+	ID     graphql.ID
+	After  *graphql.ID
+	Before *graphql.ID
+}) (*TodoResolver, error) {
+	id, err := strconv.Atoi(string(args.ID))
+	if err != nil {
+		return nil, fmt.Errorf("invalid todo ID: %w", err)
+	}
+	if args.After == nil && args.Before == nil {
+		return nil, fmt.Errorf("after or before is required")
+	}
+
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	var moved *ent.Todo
+	err = r.withTx(ctx, func(client *ent.Client) error {
+		moved, err = moveTodo(ctx, client, viewer.ID, id, args.After, args.Before)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.todoResolver(moved), nil
+}
+
+func moveTodo(ctx context.Context, client *ent.Client, ownerID, id int, after, before *graphql.ID) (*ent.Todo, error) {
+	owned := client.Todo.Query().Where(todo.OwnerID(ownerID))
+	if _, err := owned.Clone().Where(todo.ID(id)).Only(ctx); err != nil {
+		return nil, err
+	}
+	// The moved todo is not its own neighbour.
+	others := owned.Clone().Where(todo.IDNEQ(id))
+
+	var lo, hi string
+	if after != nil {
+		t, err := neighbour(ctx, client, others, id, *after)
+		if err != nil {
+			return nil, err
+		}
+		lo = t.Position
+	}
+	if before != nil {
+		t, err := neighbour(ctx, client, others, id, *before)
+		if err != nil {
+			return nil, err
+		}
+		hi = t.Position
+	}
+
+	var err error
+	switch {
+	case before == nil:
+		hi, err = nextPosition(ctx, others, lo)
+	case after == nil:
+		lo, err = previousPosition(ctx, others, hi)
+	case lo >= hi:
+		err = fmt.Errorf("todo %s is not before todo %s", *after, *before)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := position.Between(lo, hi)
+	if err != nil {
+		return nil, err
+	}
+	return client.Todo.UpdateOneID(id).
+		SetPosition(pos).
+		Save(ctx)
+}
+
+// neighbour returns the todo of others with the given ID, first spreading
+// out the todos sharing its position so that there is room next to it.
+func neighbour(ctx context.Context, client *ent.Client, others *ent.TodoQuery, movedID int, id graphql.ID) (*ent.Todo, error) {
+	neighbourID, err := strconv.Atoi(string(id))
+	if err != nil {
+		return nil, fmt.Errorf("invalid todo ID: %w", err)
+	}
+	if neighbourID == movedID {
+		return nil, fmt.Errorf("todo %d cannot be moved next to itself", movedID)
+	}
+
+	t, err := others.Clone().Where(todo.ID(neighbourID)).Only(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := spreadPositions(ctx, client, others, t.Position); err != nil {
+		return nil, err
+	}
+	return others.Clone().Where(todo.ID(neighbourID)).Only(ctx)
+}
+
+// spreadPositions gives distinct positions to the todos of others sharing
+// pos, which concurrent writes can produce. Ties are ordered by ID, as in
+// listings, and the first todo keeps its position.
+func spreadPositions(ctx context.Context, client *ent.Client, others *ent.TodoQuery, pos string) error {
+	tied, err := others.Clone().
+		Where(todo.Position(pos)).
+		Order(ent.Asc(todo.FieldID)).
+		All(ctx)
+	if err != nil || len(tied) < 2 {
+		return err
+	}
+
+	hi, err := nextPosition(ctx, others, pos)
+	if err != nil {
+		return err
+	}
+	lo := pos
+	for _, t := range tied[1:] {
+		if lo, err = position.Between(lo, hi); err != nil {
+			return err
+		}
+		// Keep updatedAt: the order of the todos does not change.
+		err := client.Todo.UpdateOneID(t.ID).
+			SetPosition(lo).
+			SetUpdatedAt(t.UpdatedAt).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextPosition returns the first position of others after pos, or "" if
+// there is none.
+func nextPosition(ctx context.Context, others *ent.TodoQuery, pos string) (string, error) {
+	t, err := others.Clone().
+		Where(todo.PositionGT(pos)).
+		Order(ent.Asc(todo.FieldPosition)).
+		First(ctx)
+	if ent.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return t.Position, nil
+}
+
+// previousPosition returns the last position of others before pos, or "" if
+// there is none.
+func previousPosition(ctx context.Context, others *ent.TodoQuery, pos string) (string, error) {
+	t, err := others.Clone().
+		Where(todo.PositionLT(pos)).
+		Order(ent.Desc(todo.FieldPosition)).
+		First(ctx)
+	if ent.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return t.Position, nil
+}
@@ -0,0 +1,104 @@
+package graph
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+)
+
+func TestMoveTodo(t *testing.T) {
+	tests := []struct {
+		name string
+		// setup runs before the move, on todos 1, 2, 3 and 4 in this order.
+		setup   []string
+		move    string
+		want    []string
+		wantErr string
+	}{
+		{name: "after", move: `moveTodo(id: 4, after: 1)`, want: []string{"1", "4", "2", "3"}},
+		{name: "before", move: `moveTodo(id: 1, before: 4)`, want: []string{"2", "3", "1", "4"}},
+		{name: "between", move: `moveTodo(id: 4, after: 2, before: 3)`, want: []string{"1", "2", "4", "3"}},
+		{name: "to the start", move: `moveTodo(id: 3, before: 1)`, want: []string{"3", "1", "2", "4"}},
+		{name: "to the end", move: `moveTodo(id: 2, after: 4)`, want: []string{"1", "3", "4", "2"}},
+		{
+			name:  "between moved todos",
+			setup: []string{`moveTodo(id: 4, after: 1) { id }`, `moveTodo(id: 3, after: 1, before: 4) { id }`},
+			move:  `moveTodo(id: 2, after: 3, before: 4)`,
+			want:  []string{"1", "3", "2", "4"},
+		},
+		{name: "no neighbour", move: `moveTodo(id: 1)`, wantErr: "after or before is required"},
+		{name: "next to itself", move: `moveTodo(id: 1, after: 1)`, wantErr: "todo 1 cannot be moved next to itself"},
+		{name: "neighbours out of order", move: `moveTodo(id: 1, after: 3, before: 2)`, wantErr: "todo 3 is not before todo 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t)
+			ctx := signIn(t, client, "alice@example.com")
+			schema := newTestSchema(client)
+			mustExec(t, ctx, schema, `mutation { createTodos(inputs: [{title: "1"}, {title: "2"}, {title: "3"}, {title: "4"}]) { id } }`, nil, nil)
+			for _, m := range tt.setup {
+				mustExec(t, ctx, schema, "mutation { "+m+" }", nil, nil)
+			}
+
+			if msg := exec(ctx, schema, "mutation { "+tt.move+" { id } }", nil, nil); msg != tt.wantErr {
+				t.Fatalf("got error %q, want %q", msg, tt.wantErr)
+			}
+			if tt.wantErr == "" {
+				if got := todoOrder(t, ctx, schema); !slices.Equal(got, tt.want) {
+					t.Errorf("got order %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// TestMoveTodoSpreadsTiedPositions moves a todo next to todos sharing a
+// position, as concurrent writes can leave them.
+func TestMoveTodoSpreadsTiedPositions(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := signIn(t, client, "alice@example.com")
+	schema := newTestSchema(client)
+	mustExec(t, ctx, schema, `mutation { createTodos(inputs: [{title: "1"}, {title: "2"}, {title: "3"}, {title: "4"}]) { id } }`, nil, nil)
+	tied := client.Todo.GetX(ctx, 1).Position
+	client.Todo.Update().Where(todo.IDIn(2, 3)).SetPosition(tied).ExecX(ctx)
+	before := client.Todo.GetX(ctx, 2)
+	versions := map[int]int{}
+	for _, td := range client.Todo.Query().AllX(ctx) {
+		versions[td.ID] = td.Version
+	}
+
+	mustExec(t, ctx, schema, `mutation { moveTodo(id: 4, after: 1) { id } }`, nil, nil)
+	if got, want := todoOrder(t, ctx, schema), []string{"1", "4", "2", "3"}; !slices.Equal(got, want) {
+		t.Errorf("got order %v, want %v", got, want)
+	}
+	if pos := client.Todo.GetX(ctx, 1).Position; pos != tied {
+		t.Errorf("the first tied todo moved from %q to %q", tied, pos)
+	}
+	if after := client.Todo.GetX(ctx, 2); !after.UpdatedAt.Equal(before.UpdatedAt) {
+		t.Errorf("spreading the positions changed the update time from %v to %v", before.UpdatedAt, after.UpdatedAt)
+	}
+	// Reordering does not modify the todos, so it keeps their versions.
+	for _, id := range []int{2, 3, 4} {
+		if v := client.Todo.GetX(ctx, id).Version; v != versions[id] {
+			t.Errorf("todo %d: got version %d, want %d", id, v, versions[id])
+		}
+	}
+}
+
+// todoOrder returns the IDs of the todos of the viewer in their manual order.
+func todoOrder(t *testing.T, ctx context.Context, schema *graphql.Schema) []string {
+	t.Helper()
+	var data struct {
+		Todos []struct{ ID string }
+	}
+	mustExec(t, ctx, schema, `{ todos { id } }`, nil, &data)
+	ids := make([]string, len(data.Todos))
+	for i, todo := range data.Todos {
+		ids[i] = todo.ID
+	}
+	return ids
+}
@@ -0,0 +1,259 @@
+package graph
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/predicate"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// Cursor is the keyset position of a todo within a connection: the value of
+// the order field and the ID used as tie-breaker.
+type Cursor struct {
+	Field string          `json:"f"`
+	Value json.RawMessage `json:"v"`
+	ID    int             `json:"i"`
+}
+
+func cursorOf(t *ent.Todo, field string) Cursor {
+	v, _ := json.Marshal(todoOrderFields[field].value(t))
+	return Cursor{Field: field, Value: v, ID: t.ID}
+}
+
+// String encodes the cursor into an opaque string.
+func (c Cursor) String() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (Cursor, error) {
+	var c Cursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, fmt.Errorf("invalid cursor: %w", err)
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return c, nil
+}
+
+// cursorPredicate returns the predicate selecting todos after (or, when
+// before is set, before) the cursor in the given order.
+func cursorPredicate(s string, order *TodoOrder, before bool) (predicate.Todo, error) {
+	c, err := decodeCursor(s)
+	if err != nil {
+		return nil, err
+	}
+	if c.Field != order.Field {
+		return nil, errors.New("cursor does not match the requested order")
+	}
+	f, err := order.orderField()
+	if err != nil {
+		return nil, err
+	}
+	v, err := f.decode(c.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	cmp := sql.GT
+	if order.desc() != before {
+		cmp = sql.LT
+	}
+	return func(s *sql.Selector) {
+		s.Where(sql.Or(
+			cmp(s.C(f.column), v),
+			sql.And(sql.EQ(s.C(f.column), v), cmp(s.C(todo.FieldID), c.ID)),
+		))
+	}, nil
+}
+
+// ConnectionArgs are the Relay pagination arguments.
+type ConnectionArgs struct {
+	First  *int32
+	After  *string
+	Last   *int32
+	Before *string
+}
+
+// limit returns the number of items the arguments ask for.
+func (args ConnectionArgs) limit() (int, error) {
+	if args.First != nil && args.Last != nil {
+		return 0, errors.New("first and last cannot be used together")
+	}
+	limit := defaultPageSize
+	if args.First != nil {
+		limit = int(*args.First)
+	}
+	if args.Last != nil {
+		limit = int(*args.Last)
+	}
+	if limit < 0 {
+		return 0, errors.New("first and last must not be negative")
+	}
+	if limit > maxPageSize {
+		return 0, fmt.Errorf("first and last must not exceed %d", maxPageSize)
+	}
+	return limit, nil
+}
+
+// TodosConnectionArgs are the arguments of the todosConnection field.
+type TodosConnectionArgs struct {
+	ConnectionArgs
+	Where   *TodoWhereInput
+	OrderBy *TodoOrder
+}
+
+// TodoConnectionResolver resolves a page of todos.
+type TodoConnectionResolver struct {
+	edges      []*TodoEdgeResolver
+	pageInfo   *PageInfoResolver
+	totalCount int
+}
+
+func (r *TodoConnectionResolver) Edges() []*TodoEdgeResolver {
+	return r.edges
+}
+
+func (r *TodoConnectionResolver) PageInfo() *PageInfoResolver {
+	return r.pageInfo
+}
+
+func (r *TodoConnectionResolver) TotalCount() int32 {
+	return int32(r.totalCount)
+}
+
+// TodoEdgeResolver resolves a single edge of a TodoConnection.
+type TodoEdgeResolver struct {
+	node   *TodoResolver
+	cursor Cursor
+}
+
+func (r *TodoEdgeResolver) Node() *TodoResolver {
+	return r.node
+}
+
+func (r *TodoEdgeResolver) Cursor() string {
+	return r.cursor.String()
+}
+
+// PageInfoResolver resolves the Relay PageInfo type.
+type PageInfoResolver struct {
+	hasNextPage     bool
+	hasPreviousPage bool
+	startCursor     *Cursor
+	endCursor       *Cursor
+}
+
+func (r *PageInfoResolver) HasNextPage() bool {
+	return r.hasNextPage
+}
+
+func (r *PageInfoResolver) HasPreviousPage() bool {
+	return r.hasPreviousPage
+}
+
+func (r *PageInfoResolver) StartCursor() *string {
+	if r.startCursor == nil {
+		return nil
+	}
+	s := r.startCursor.String()
+	return &s
+}
+
+func (r *PageInfoResolver) EndCursor() *string {
+	if r.endCursor == nil {
+		return nil
+	}
+	s := r.endCursor.String()
+	return &s
+}
+
+// paginateTodos returns one page of the todos matched by q in the given
+// order, sliced with keyset cursors on (order field, id).
+func (r *Resolver) paginateTodos(ctx context.Context, q *ent.TodoQuery, args ConnectionArgs, order *TodoOrder) (*TodoConnectionResolver, error) {
+	if order == nil {
+		order = DefaultTodoOrder
+	}
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+	limit, err := args.limit()
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := q.Clone().Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.After != nil {
+		p, err := cursorPredicate(*args.After, order, false)
+		if err != nil {
+			return nil, err
+		}
+		q = q.Where(p)
+	}
+	if args.Before != nil {
+		p, err := cursorPredicate(*args.Before, order, true)
+		if err != nil {
+			return nil, err
+		}
+		q = q.Where(p)
+	}
+
+	backward := args.Last != nil
+	opts, err := order.OrderOptions(backward)
+	if err != nil {
+		return nil, err
+	}
+	q = q.Order(opts...)
+
+	todos, err := q.Limit(limit + 1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hasMore := len(todos) > limit
+	if hasMore {
+		todos = todos[:limit]
+	}
+	if backward {
+		for i, j := 0, len(todos)-1; i < j; i, j = i+1, j-1 {
+			todos[i], todos[j] = todos[j], todos[i]
+		}
+	}
+
+	conn := &TodoConnectionResolver{
+		edges:      make([]*TodoEdgeResolver, len(todos)),
+		pageInfo:   &PageInfoResolver{},
+		totalCount: totalCount,
+	}
+	for i, t := range todos {
+		conn.edges[i] = &TodoEdgeResolver{node: r.todoResolver(t), cursor: cursorOf(t, order.Field)}
+	}
+	if n := len(conn.edges); n > 0 {
+		conn.pageInfo.startCursor = &conn.edges[0].cursor
+		conn.pageInfo.endCursor = &conn.edges[n-1].cursor
+	}
+	if backward {
+		conn.pageInfo.hasPreviousPage = hasMore
+		conn.pageInfo.hasNextPage = args.Before != nil
+	} else {
+		conn.pageInfo.hasNextPage = hasMore
+		conn.pageInfo.hasPreviousPage = args.After != nil
+	}
+
+	return conn, nil
+}
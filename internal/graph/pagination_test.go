@@ -0,0 +1,139 @@
+package graph
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// todoPage is a page of todosConnection.
+type todoPage struct {
+	TodosConnection struct {
+		TotalCount int
+		Edges      []struct {
+			Node struct{ ID string }
+		}
+		PageInfo struct {
+			HasNextPage     bool
+			HasPreviousPage bool
+			StartCursor     *string
+			EndCursor       *string
+		}
+	}
+}
+
+const todosConnectionQuery = `query($first: Int, $after: String, $last: Int, $before: String, $where: TodoWhereInput, $orderBy: TodoOrder) {
+	todosConnection(first: $first, after: $after, last: $last, before: $before, where: $where, orderBy: $orderBy) {
+		totalCount
+		edges { node { id } }
+		pageInfo { hasNextPage hasPreviousPage startCursor endCursor }
+	}
+}`
+
+// walkTodos pages through todosConnection two todos at a time, forward or,
+// when backward is set, backward, and returns the IDs in connection order.
+func walkTodos(t *testing.T, ctx context.Context, schema *graphql.Schema, orderBy map[string]any, backward bool) []string {
+	t.Helper()
+	var ids []string
+	var cursor *string
+	for range 10 {
+		size, from := "first", "after"
+		if backward {
+			size, from = "last", "before"
+		}
+		vars := map[string]any{size: 2}
+		if cursor != nil {
+			vars[from] = *cursor
+		}
+		if orderBy != nil {
+			vars["orderBy"] = orderBy
+		}
+		var page todoPage
+		mustExec(t, ctx, schema, todosConnectionQuery, vars, &page)
+		conn := page.TodosConnection
+		var pageIDs []string
+		for _, e := range conn.Edges {
+			pageIDs = append(pageIDs, e.Node.ID)
+		}
+		if conn.TotalCount != 6 {
+			t.Errorf("got total count %d, want 6", conn.TotalCount)
+		}
+
+		more, moreBehind := conn.PageInfo.HasNextPage, conn.PageInfo.HasPreviousPage
+		if backward {
+			ids, cursor = append(pageIDs, ids...), conn.PageInfo.StartCursor
+			more, moreBehind = moreBehind, more
+		} else {
+			ids, cursor = append(ids, pageIDs...), conn.PageInfo.EndCursor
+		}
+		if moreBehind != (len(ids) > len(pageIDs)) {
+			t.Errorf("page %v: got %t for the pages already walked", pageIDs, moreBehind)
+		}
+		if !more {
+			return ids
+		}
+	}
+	t.Fatalf("walked past the last page, got %v", ids)
+	return nil
+}
+
+func TestTodosConnectionPaging(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := signIn(t, client, "alice@example.com")
+	schema := newTestSchema(client)
+	// Titles repeat, so that the IDs break ties.
+	mustExec(t, ctx, schema, `mutation {
+		createTodos(inputs: [{title: "b"}, {title: "a"}, {title: "b"}, {title: "a"}, {title: "c"}, {title: "b"}]) { id }
+	}`, nil, nil)
+
+	tests := []struct {
+		name    string
+		orderBy map[string]any
+		want    []string
+	}{
+		{name: "default order", want: []string{"1", "2", "3", "4", "5", "6"}},
+		{name: "title", orderBy: map[string]any{"field": "TITLE"}, want: []string{"2", "4", "1", "3", "6", "5"}},
+		{name: "title descending", orderBy: map[string]any{"field": "TITLE", "direction": "DESC"}, want: []string{"5", "6", "3", "1", "4", "2"}},
+		{name: "position descending", orderBy: map[string]any{"field": "POSITION", "direction": "DESC"}, want: []string{"6", "5", "4", "3", "2", "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := walkTodos(t, ctx, schema, tt.orderBy, false); !slices.Equal(got, tt.want) {
+				t.Errorf("forward: got %v, want %v", got, tt.want)
+			}
+			if got := walkTodos(t, ctx, schema, tt.orderBy, true); !slices.Equal(got, tt.want) {
+				t.Errorf("backward: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTodosConnectionArgs(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := signIn(t, client, "alice@example.com")
+	schema := newTestSchema(client)
+	mustExec(t, ctx, schema, `mutation { createTodos(inputs: [{title: "a"}, {title: "b"}]) { id } }`, nil, nil)
+	var page todoPage
+	mustExec(t, ctx, schema, todosConnectionQuery, map[string]any{"first": 1}, &page)
+	positionCursor := *page.TodosConnection.PageInfo.EndCursor
+
+	tests := []struct {
+		name    string
+		vars    map[string]any
+		wantErr string
+	}{
+		{name: "first and last", vars: map[string]any{"first": 1, "last": 1}, wantErr: "first and last cannot be used together"},
+		{name: "negative first", vars: map[string]any{"first": -1}, wantErr: "first and last must not be negative"},
+		{name: "large last", vars: map[string]any{"last": 101}, wantErr: "first and last must not exceed 100"},
+		{name: "cursor of another order", vars: map[string]any{"after": positionCursor, "orderBy": map[string]any{"field": "TITLE"}}, wantErr: "cursor does not match the requested order"},
+		{name: "malformed cursor", vars: map[string]any{"after": "%%%"}, wantErr: "invalid cursor: illegal base64 data at input byte 0"},
+		{name: "cursor of the order", vars: map[string]any{"after": positionCursor}},
+	}
+	for _, tt := range tests {
+		if msg := exec(ctx, schema, todosConnectionQuery, tt.vars, nil); msg != tt.wantErr {
+			t.Errorf("%s: got error %q, want %q", tt.name, msg, tt.wantErr)
+		}
+	}
+}
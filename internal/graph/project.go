@@ -0,0 +1,244 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/project"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+)
+
+// Project GraphQL resolver
+type ProjectResolver struct {
+	root    *Resolver
+	project *ent.Project
+}
+
+func (r *Resolver) projectResolver(project *ent.Project) *ProjectResolver {
+	return &ProjectResolver{root: r, project: project}
+}
+
+func (r *ProjectResolver) ID() graphql.ID {
+	return graphql.ID(strconv.Itoa(r.project.ID))
+}
+
+func (r *ProjectResolver) Name() string {
+	return r.project.Name
+}
+
+func (r *ProjectResolver) Color() *string {
+	if r.project.Color == "" {
+		return nil
+	}
+	return &r.project.Color
+}
+
+func (r *ProjectResolver) Archived() bool {
+	return r.project.Archived
+}
+
+func (r *ProjectResolver) Position() int32 {
+	return int32(r.project.Position)
+}
+
+func (r *ProjectResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.project.CreatedAt}
+}
+
+func (r *ProjectResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.project.UpdatedAt}
+}
+
+func (r *ProjectResolver) Todos(ctx context.Context, args TodosConnectionArgs) (*TodoConnectionResolver, error) {
+	q, err := r.root.viewerTodos(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q = q.Where(todo.ProjectID(r.project.ID))
+	return r.root.paginateTodos(ctx, args.Where.Filter(q), args.ConnectionArgs, args.OrderBy)
+}
+
+// Input types
+type CreateProjectInput struct {
+	Name     string
+	Color    *string
+	Position *int32
+}
+
+type UpdateProjectInput struct {
+	Name     *string
+	Color    *string
+	Archived *bool
+	Position *int32
+}
+
+// viewerProjects returns a query over the projects owned by the
+// authenticated user, in position order.
+func (r *Resolver) viewerProjects(ctx context.Context) (*ent.ProjectQuery, error) {
+	viewer, err := authorize(ctx, auth.ScopeTodosRead)
+	if err != nil {
+		return nil, err
+	}
+	return r.Client.Project.Query().
+		Where(project.OwnerID(viewer.ID)).
+		Order(ent.Asc(project.FieldPosition), ent.Asc(project.FieldID)), nil
+}
+
+// ownedProjectID parses id and checks that it names a project of viewer.
+func (r *Resolver) ownedProjectID(ctx context.Context, viewer *ent.User, id graphql.ID) (int, error) {
+	projectID, err := strconv.Atoi(string(id))
+	if err != nil {
+		return 0, fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	exists, err := r.Client.Project.Query().
+		Where(project.ID(projectID), project.OwnerID(viewer.ID)).
+		Exist(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, fmt.Errorf("project %d not found", projectID)
+	}
+
+	return projectID, nil
+}
+
+// Query resolvers
+func (r *Resolver) Project(ctx context.Context, args struct{ ID graphql.ID }) (*ProjectResolver, error) {
+	id, err := strconv.Atoi(string(args.ID))
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	q, err := r.viewerProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := q.Where(project.ID(id)).Only(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.projectResolver(project), nil
+}
+
+func (r *Resolver) Projects(ctx context.Context, args struct{ IncludeArchived bool }) ([]*ProjectResolver, error) {
+	q, err := r.viewerProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !args.IncludeArchived {
+		q = q.Where(project.Archived(false))
+	}
+
+	projects, err := q.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*ProjectResolver, len(projects))
+	for i, project := range projects {
+		resolvers[i] = r.projectResolver(project)
+	}
+
+	return resolvers, nil
+}
+
+// Mutation resolvers
+func (r *Resolver) CreateProject(ctx context.Context, args struct{ Input CreateProjectInput }) (*ProjectResolver, error) {
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := r.Client.Project.Create().
+		SetName(args.Input.Name).
+		SetOwnerID(viewer.ID)
+
+	if args.Input.Color != nil {
+		builder = builder.SetColor(*args.Input.Color)
+	}
+	if args.Input.Position != nil {
+		builder = builder.SetPosition(int(*args.Input.Position))
+	}
+
+	project, err := builder.Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.projectResolver(project), nil
+}
+
+func (r *Resolver) UpdateProject(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input UpdateProjectInput
+}) (*ProjectResolver, error) {
+	id, err := strconv.Atoi(string(args.ID))
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := r.Client.Project.UpdateOneID(id).
+		Where(project.OwnerID(viewer.ID))
+
+	if args.Input.Name != nil {
+		builder = builder.SetName(*args.Input.Name)
+	}
+	if args.Input.Color != nil {
+		if *args.Input.Color == "" {
+			builder = builder.ClearColor()
+		} else {
+			builder = builder.SetColor(*args.Input.Color)
+		}
+	}
+	if args.Input.Archived != nil {
+		builder = builder.SetArchived(*args.Input.Archived)
+	}
+	if args.Input.Position != nil {
+		builder = builder.SetPosition(int(*args.Input.Position))
+	}
+
+	project, err := builder.Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.projectResolver(project), nil
+}
+
+// DeleteProject deletes a project. Its todos are kept and leave the project.
+func (r *Resolver) DeleteProject(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+	id, err := strconv.Atoi(string(args.ID))
+	if err != nil {
+		return false, fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return false, err
+	}
+
+	err = r.Client.Project.DeleteOneID(id).
+		Where(project.OwnerID(viewer.ID)).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
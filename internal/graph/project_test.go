@@ -0,0 +1,186 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+// projectNames returns the names of projects.
+func projectNames(projects []struct{ Name string }) []string {
+	names := []string{}
+	for _, p := range projects {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func TestProjects(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := signIn(t, client, "alice@example.com")
+	schema := newTestSchema(client)
+
+	var created struct {
+		CreateProject struct {
+			ID       string
+			Color    *string
+			Archived bool
+		}
+	}
+	mustExec(t, ctx, schema, `mutation { createProject(input: {name: "Home", color: "#00ff00", position: 2}) { id color archived } }`, nil, &created)
+	if p := created.CreateProject; p.ID != "1" || p.Color == nil || *p.Color != "#00ff00" || p.Archived {
+		t.Errorf("got project %+v", p)
+	}
+	mustExec(t, ctx, schema, `mutation {
+		createProject(input: {name: "Work", position: 1}) { id }
+		trip: createProject(input: {name: "Trip", position: 3}) { id }
+		updateProject(id: 3, input: {archived: true}) { id }
+	}`, nil, nil)
+
+	var listed struct {
+		Projects []struct{ Name string }
+		All      []struct{ Name string } `json:"all"`
+	}
+	mustExec(t, ctx, schema, `{ projects { name } all: projects(includeArchived: true) { name } }`, nil, &listed)
+	if got, want := projectNames(listed.Projects), []string{"Work", "Home"}; !slices.Equal(got, want) {
+		t.Errorf("got projects %v, want %v", got, want)
+	}
+	if got, want := projectNames(listed.All), []string{"Work", "Home", "Trip"}; !slices.Equal(got, want) {
+		t.Errorf("got projects %v including archived ones, want %v", got, want)
+	}
+
+	var updated struct {
+		UpdateProject struct {
+			Name     string
+			Color    *string
+			Position int
+		}
+	}
+	mustExec(t, ctx, schema, `mutation { updateProject(id: 1, input: {name: "House", color: "", position: 0}) { name color position } }`, nil, &updated)
+	if p := updated.UpdateProject; p.Name != "House" || p.Color != nil || p.Position != 0 {
+		t.Errorf("got updated project %+v", p)
+	}
+
+	// Deleting a project keeps its todos, outside any project.
+	mustExec(t, ctx, schema, `mutation {
+		createTodo(input: {title: "Fix the sink", projectId: 1}) { id }
+		deleteProject(id: 1)
+	}`, nil, nil)
+	var todo struct {
+		Todo struct{ Project *struct{ ID string } }
+	}
+	mustExec(t, ctx, schema, `{ todo(id: 1) { project { id } } }`, nil, &todo)
+	if todo.Todo.Project != nil {
+		t.Errorf("got project %s of a todo of a deleted project", todo.Todo.Project.ID)
+	}
+	if msg := exec(ctx, schema, `{ project(id: 1) { id } }`, nil, nil); msg != "ent: project not found" {
+		t.Errorf("got error %q for a deleted project", msg)
+	}
+}
+
+func TestProjectTodos(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := signIn(t, client, "alice@example.com")
+	schema := newTestSchema(client)
+	mustExec(t, ctx, schema, `mutation {
+		createProject(input: {name: "Home"}) { id }
+		createTodo(input: {title: "Fix the sink", projectId: 1}) { id }
+		milk: createTodo(input: {title: "Buy milk"}) { id }
+		plants: createTodo(input: {title: "Water plants", projectId: 1}) { id }
+		mow: createTodo(input: {title: "Mow the lawn", projectId: 1}) { id }
+	}`, nil, nil)
+
+	type page struct {
+		Project struct {
+			Todos struct {
+				TotalCount int
+				Edges      []struct{ Node struct{ Title string } }
+				PageInfo   struct {
+					HasNextPage bool
+					EndCursor   string
+				}
+			}
+		}
+	}
+	const query = `query($after: String) {
+		project(id: 1) {
+			todos(first: 2, after: $after) { totalCount edges { node { title } } pageInfo { hasNextPage endCursor } }
+		}
+	}`
+	var titles []string
+	var after any
+	for i := 0; ; i++ {
+		var p page
+		mustExec(t, ctx, schema, query, map[string]any{"after": after}, &p)
+		todos := p.Project.Todos
+		if todos.TotalCount != 3 {
+			t.Errorf("page %d: got total count %d, want 3", i, todos.TotalCount)
+		}
+		for _, e := range todos.Edges {
+			titles = append(titles, e.Node.Title)
+		}
+		if !todos.PageInfo.HasNextPage {
+			break
+		}
+		if i == 2 {
+			t.Fatal("got more pages than todos")
+		}
+		after = todos.PageInfo.EndCursor
+	}
+	if want := []string{"Fix the sink", "Water plants", "Mow the lawn"}; !slices.Equal(titles, want) {
+		t.Errorf("got todos %v, want %v", titles, want)
+	}
+
+	// clearProject removes a todo from its project.
+	mustExec(t, ctx, schema, `mutation { updateTodo(id: 1, input: {clearProject: true}) { id } }`, nil, nil)
+	var p page
+	mustExec(t, ctx, schema, query, map[string]any{"after": nil}, &p)
+	if p.Project.Todos.TotalCount != 2 {
+		t.Errorf("got %d todos after removing one from the project, want 2", p.Project.Todos.TotalCount)
+	}
+	// projectId wins over clearProject.
+	var moved struct {
+		UpdateTodo struct{ Project *struct{ ID string } }
+	}
+	mustExec(t, ctx, schema, `mutation { updateTodo(id: 2, input: {projectId: 1, clearProject: true}) { project { id } } }`, nil, &moved)
+	if moved.UpdateTodo.Project == nil || moved.UpdateTodo.Project.ID != "1" {
+		t.Errorf("got project %v, want 1", moved.UpdateTodo.Project)
+	}
+}
+
+func TestProjectsOfOtherUsers(t *testing.T) {
+	client, _ := newTestClient(t)
+	alice := signIn(t, client, "alice@example.com")
+	schema := newTestSchema(client)
+	mustExec(t, alice, schema, `mutation {
+		createProject(input: {name: "Home"}) { id }
+		createTodo(input: {title: "Fix the sink", projectId: 1}) { id }
+	}`, nil, nil)
+
+	bob := signIn(t, client, "bob@example.com")
+	var projects struct{ Projects []struct{ Name string } }
+	mustExec(t, bob, schema, `{ projects(includeArchived: true) { name } }`, nil, &projects)
+	if len(projects.Projects) != 0 {
+		t.Errorf("got projects %v of another user", projects.Projects)
+	}
+	for _, tt := range []struct{ query, wantErr string }{
+		{`{ project(id: 1) { id } }`, "ent: project not found"},
+		{`mutation { updateProject(id: 1, input: {name: "Mine"}) { id } }`, "ent: project not found"},
+		{`mutation { deleteProject(id: 1) }`, "ent: project not found"},
+		{`mutation { createTodo(input: {title: "Mine", projectId: 1}) { id } }`, "project 1 not found"},
+	} {
+		if msg := exec(bob, schema, tt.query, nil, nil); msg != tt.wantErr {
+			t.Errorf("%s: got error %q, want %q", tt.query, msg, tt.wantErr)
+		}
+	}
+
+	var project struct {
+		Project struct {
+			Name  string
+			Todos struct{ TotalCount int }
+		}
+	}
+	mustExec(t, alice, schema, `{ project(id: 1) { name todos { totalCount } } }`, nil, &project)
+	if project.Project.Name != "Home" || project.Project.Todos.TotalCount != 1 {
+		t.Errorf("got project %+v, want it unchanged", project.Project)
+	}
+}
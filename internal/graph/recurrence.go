@@ -0,0 +1,33 @@
+package graph
+
+import "context"
+
+func (r *TodoResolver) Recurrence() *string {
+	if r.todo.Recurrence == "" {
+		return nil
+	}
+	return &r.todo.Recurrence
+}
+
+func (r *TodoResolver) PreviousOccurrence(ctx context.Context) (*TodoResolver, error) {
+	if r.todo.PreviousOccurrenceID == 0 {
+		return nil, nil
+	}
+
+	// A previous occurrence in the trash does not load.
+	previous, err := r.root.loaders(ctx).todos.load(ctx, r.todo.PreviousOccurrenceID)
+	if err != nil || previous == nil {
+		return nil, err
+	}
+
+	return r.root.todoResolver(previous), nil
+}
+
+func (r *TodoResolver) NextOccurrence(ctx context.Context) (*TodoResolver, error) {
+	next, err := r.root.loaders(ctx).nextOccurrences.load(ctx, r.todo.ID)
+	if err != nil || next == nil {
+		return nil, err
+	}
+
+	return r.root.todoResolver(next), nil
+}
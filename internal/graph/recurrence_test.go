@@ -0,0 +1,47 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/softdelete"
+)
+
+// TestCompleteAfterTrashingNextOccurrence completes a recurring todo again
+// after its next occurrence was trashed, which must not create a second next
+// occurrence.
+func TestCompleteAfterTrashingNextOccurrence(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := signIn(t, client, "alice@example.com")
+	schema := newTestSchema(client)
+
+	var created struct {
+		CreateTodo struct{ ID string }
+	}
+	mustExec(t, ctx, schema, `mutation {
+		createTodo(input: {title: "Water plants", recurrence: "FREQ=DAILY", dueAt: "2026-10-18T09:00:00Z"}) { id }
+	}`, nil, &created)
+	id := created.CreateTodo.ID
+
+	var completed struct {
+		UpdateTodo struct {
+			NextOccurrence *struct{ ID string }
+		}
+	}
+	const complete = `mutation($id: ID!) { updateTodo(id: $id, input: {completed: true}) { nextOccurrence { id } } }`
+	mustExec(t, ctx, schema, complete, map[string]any{"id": id}, &completed)
+	if completed.UpdateTodo.NextOccurrence == nil {
+		t.Fatal("completing the todo created no next occurrence")
+	}
+	mustExec(t, ctx, schema, `mutation($id: ID!) { deleteTodo(id: $id) }`,
+		map[string]any{"id": completed.UpdateTodo.NextOccurrence.ID}, nil)
+	mustExec(t, ctx, schema, `mutation($id: ID!) { updateTodo(id: $id, input: {completed: false}) { id } }`,
+		map[string]any{"id": id}, nil)
+
+	mustExec(t, ctx, schema, complete, map[string]any{"id": id}, &completed)
+	if completed.UpdateTodo.NextOccurrence != nil {
+		t.Errorf("got next occurrence %s, want the trashed one to stay the next occurrence", completed.UpdateTodo.NextOccurrence.ID)
+	}
+	if n := client.Todo.Query().CountX(softdelete.Skip(ctx)); n != 2 {
+		t.Errorf("got %d todos, want 2", n)
+	}
+}
@@ -4,21 +4,46 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	graphql "github.com/graph-gophers/graphql-go"
 	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/dependency"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/pubsub"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/recurrence"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/search"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/storage"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/subtask"
 )
 
 // Resolver is the root resolver
 type Resolver struct {
 	Client *ent.Client
+	Broker pubsub.Broker
+	// Blobs stores the files attached to todos.
+	Blobs storage.BlobStore
 }
 
 // Todo GraphQL resolver
 type TodoResolver struct {
+	root *Resolver
 	todo *ent.Todo
 }
 
+func (r *Resolver) todoResolver(todo *ent.Todo) *TodoResolver {
+	return &TodoResolver{root: r, todo: todo}
+}
+
+func (r *Resolver) todoResolvers(todos []*ent.Todo) []*TodoResolver {
+	resolvers := make([]*TodoResolver, len(todos))
+	for i, todo := range todos {
+		resolvers[i] = r.todoResolver(todo)
+	}
+	return resolvers
+}
+
 func (r *TodoResolver) ID() graphql.ID {
 	return graphql.ID(strconv.Itoa(r.todo.ID))
 }
@@ -38,6 +63,18 @@ func (r *TodoResolver) Completed() bool {
 	return r.todo.Completed
 }
 
+func (r *TodoResolver) Priority() string {
+	return r.todo.Priority.String()
+}
+
+func (r *TodoResolver) Position() string {
+	return r.todo.Position
+}
+
+func (r *TodoResolver) Version() int32 {
+	return int32(r.todo.Version)
+}
+
 func (r *TodoResolver) CreatedAt() graphql.Time {
 	return graphql.Time{Time: r.todo.CreatedAt}
 }
@@ -46,16 +83,91 @@ func (r *TodoResolver) UpdatedAt() graphql.Time {
 	return graphql.Time{Time: r.todo.UpdatedAt}
 }
 
+func (r *TodoResolver) DueAt() *graphql.Time {
+	return optionalTime(r.todo.DueAt)
+}
+
+func (r *TodoResolver) RemindAt() *graphql.Time {
+	return optionalTime(r.todo.RemindAt)
+}
+
+func (r *TodoResolver) Owner(ctx context.Context) (*UserResolver, error) {
+	if r.todo.OwnerID == 0 {
+		return nil, nil
+	}
+
+	user, err := r.root.loaders(ctx).users.load(ctx, r.todo.OwnerID)
+	if err != nil || user == nil {
+		return nil, err
+	}
+
+	return &UserResolver{user: user}, nil
+}
+
+func (r *TodoResolver) Project(ctx context.Context) (*ProjectResolver, error) {
+	if r.todo.ProjectID == 0 {
+		return nil, nil
+	}
+
+	project, err := r.root.loaders(ctx).projects.load(ctx, r.todo.ProjectID)
+	if err != nil || project == nil {
+		return nil, err
+	}
+
+	return r.root.projectResolver(project), nil
+}
+
 // Input types
 type CreateTodoInput struct {
 	Title       string
 	Description *string
+	Priority    *string
+	ProjectID   *graphql.ID
+	ParentID    *graphql.ID
+	Recurrence  *string
+	DueAt       *graphql.Time
+	RemindAt    *graphql.Time
 }
 
 type UpdateTodoInput struct {
-	Title       *string
-	Description *string
-	Completed   *bool
+	Title           *string
+	Description     *string
+	Completed       *bool
+	Priority        *string
+	ProjectID       *graphql.ID
+	ClearProject    *bool
+	ParentID        *graphql.ID
+	ClearParent     *bool
+	Recurrence      *string
+	DueAt           *graphql.Time
+	ClearDueAt      *bool
+	RemindAt        *graphql.Time
+	ClearRemindAt   *bool
+	ExpectedVersion *int32
+}
+
+// viewerTodos returns a query over the todos owned by the authenticated user.
+func (r *Resolver) viewerTodos(ctx context.Context) (*ent.TodoQuery, error) {
+	viewer, err := authorize(ctx, auth.ScopeTodosRead)
+	if err != nil {
+		return nil, err
+	}
+	return r.Client.Todo.Query().Where(todo.OwnerID(viewer.ID)), nil
+}
+
+// withTx runs fn in a transaction, committing it if fn succeeds.
+func (r *Resolver) withTx(ctx context.Context, fn func(client *ent.Client) error) error {
+	tx, err := r.Client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx.Client()); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			return fmt.Errorf("%w: rolling back: %v", err, rerr)
+		}
+		return err
+	}
+	return tx.Commit()
 }
 
 // Query resolvers
@@ -65,81 +177,347 @@ func (r *Resolver) Todo(ctx context.Context, args struct{ ID graphql.ID }) (*Tod
 		return nil, fmt.Errorf("invalid todo ID: %w", err)
 	}
 
-	todo, err := r.Client.Todo.Get(ctx, id)
+	q, err := r.viewerTodos(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := q.Where(todo.ID(id)).Only(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.todoResolver(todo), nil
+}
+
+func (r *Resolver) Todos(ctx context.Context, args struct {
+	Where   *TodoWhereInput
+	OrderBy *TodoOrder
+}) ([]*TodoResolver, error) {
+	q, err := r.viewerTodos(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q, err = args.OrderBy.Order(args.Where.Filter(q))
 	if err != nil {
 		return nil, err
 	}
 
-	return &TodoResolver{todo: todo}, nil
+	todos, err := q.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.todoResolvers(todos), nil
+}
+
+func (r *Resolver) TodosConnection(ctx context.Context, args TodosConnectionArgs) (*TodoConnectionResolver, error) {
+	q, err := r.viewerTodos(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.paginateTodos(ctx, args.Where.Filter(q), args.ConnectionArgs, args.OrderBy)
 }
 
-func (r *Resolver) Todos(ctx context.Context) ([]*TodoResolver, error) {
-	todos, err := r.Client.Todo.Query().All(ctx)
+func (r *Resolver) SearchTodos(ctx context.Context, args struct {
+	Query string
+	First *int32
+}) ([]*TodoResolver, error) {
+	limit := defaultPageSize
+	if args.First != nil {
+		limit = int(*args.First)
+	}
+	if limit < 0 || limit > maxPageSize {
+		return nil, fmt.Errorf("first must be between 0 and %d", maxPageSize)
+	}
+
+	q, err := r.viewerTodos(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var resolvers []*TodoResolver
-	for _, todo := range todos {
-		resolvers = append(resolvers, &TodoResolver{todo: todo})
+	todos, err := search.Todos(ctx, q, args.Query, limit)
+	if err != nil {
+		return nil, err
 	}
 
-	return resolvers, nil
+	return r.todoResolvers(todos), nil
+}
+
+// OverdueTodos returns the open todos whose due date has passed, the most
+// overdue first.
+func (r *Resolver) OverdueTodos(ctx context.Context) ([]*TodoResolver, error) {
+	q, err := r.viewerTodos(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	todos, err := q.
+		Where(todo.CompletedEQ(false), todo.DueAtLT(time.Now())).
+		Order(ent.Asc(todo.FieldDueAt), ent.Asc(todo.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.todoResolvers(todos), nil
+}
+
+// DueBetween returns the todos due within [from, to], by due date.
+func (r *Resolver) DueBetween(ctx context.Context, args struct {
+	From graphql.Time
+	To   graphql.Time
+}) ([]*TodoResolver, error) {
+	if args.To.Before(args.From.Time) {
+		return nil, fmt.Errorf("to must not be before from")
+	}
+
+	q, err := r.viewerTodos(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	todos, err := q.
+		Where(todo.DueAtGTE(args.From.Time), todo.DueAtLTE(args.To.Time)).
+		Order(ent.Asc(todo.FieldDueAt), ent.Asc(todo.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.todoResolvers(todos), nil
 }
 
 // Mutation resolvers
 func (r *Resolver) CreateTodo(ctx context.Context, args struct{ Input CreateTodoInput }) (*TodoResolver, error) {
-	builder := r.Client.Todo.Create().
-		SetTitle(args.Input.Title)
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return nil, err
+	}
 
-	if args.Input.Description != nil {
-		builder = builder.SetDescription(*args.Input.Description)
+	projectID, parentID, err := r.todoRefs(ctx, viewer, args.Input.ProjectID, args.Input.ParentID)
+	if err != nil {
+		return nil, err
 	}
 
-	todo, err := builder.Save(ctx)
+	todo, err := newTodoCreate(r.Client, viewer, args.Input, projectID, parentID).Save(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return &TodoResolver{todo: todo}, nil
+	return r.todoResolver(todo), nil
+}
+
+// todoRefs resolves the IDs of the project and the parent todo an input
+// refers to, checking that they belong to the viewer. Unset IDs resolve to 0.
+func (r *Resolver) todoRefs(ctx context.Context, viewer *ent.User, project, parent *graphql.ID) (projectID, parentID int, err error) {
+	if project != nil {
+		if projectID, err = r.ownedProjectID(ctx, viewer, *project); err != nil {
+			return 0, 0, err
+		}
+	}
+	if parent != nil {
+		if parentID, err = r.ownedTodoID(ctx, viewer, *parent); err != nil {
+			return 0, 0, err
+		}
+	}
+	return projectID, parentID, nil
 }
 
+// newTodoCreate returns a builder creating the todo described by input for
+// the viewer. projectID and parentID are the resolved IDs of input.ProjectID
+// and input.ParentID.
+func newTodoCreate(client *ent.Client, viewer *ent.User, input CreateTodoInput, projectID, parentID int) *ent.TodoCreate {
+	builder := client.Todo.Create().
+		SetTitle(input.Title).
+		SetOwnerID(viewer.ID)
+
+	if input.Description != nil {
+		builder = builder.SetDescription(*input.Description)
+	}
+	if input.Priority != nil {
+		builder = builder.SetPriority(todo.Priority(*input.Priority))
+	}
+	if input.ProjectID != nil {
+		builder = builder.SetProjectID(projectID)
+	}
+	if input.ParentID != nil {
+		builder = builder.SetParentID(parentID)
+	}
+	if input.Recurrence != nil && *input.Recurrence != "" {
+		builder = builder.SetRecurrence(*input.Recurrence)
+	}
+	if input.DueAt != nil {
+		builder = builder.SetDueAt(input.DueAt.Time)
+	}
+	if input.RemindAt != nil {
+		builder = builder.SetRemindAt(input.RemindAt.Time)
+	}
+
+	return builder
+}
+
+// UpdateTodo updates a todo. When it completes the todo and childPolicy is
+// set, the policy is applied to the subtasks of the todo. A todo blocked by
+// open todos is only completed with force. Completing a recurring todo
+// creates its next occurrence in the same transaction.
 func (r *Resolver) UpdateTodo(ctx context.Context, args struct {
-	ID    graphql.ID
-	Input UpdateTodoInput
+	ID          graphql.ID
+	Input       UpdateTodoInput
+	ChildPolicy *string
+	Force       bool
 }) (*TodoResolver, error) {
 	id, err := strconv.Atoi(string(args.ID))
 	if err != nil {
 		return nil, fmt.Errorf("invalid todo ID: %w", err)
 	}
 
-	builder := r.Client.Todo.UpdateOneID(id)
-
-	if args.Input.Title != nil {
-		builder = builder.SetTitle(*args.Input.Title)
-	}
-	if args.Input.Description != nil {
-		builder = builder.SetDescription(*args.Input.Description)
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return nil, err
 	}
-	if args.Input.Completed != nil {
-		builder = builder.SetCompleted(*args.Input.Completed)
+
+	projectID, parentID, err := r.todoRefs(ctx, viewer, args.Input.ProjectID, args.Input.ParentID)
+	if err != nil {
+		return nil, err
 	}
 
-	todo, err := builder.Save(ctx)
+	var updated *ent.Todo
+	err = r.withTx(ctx, func(client *ent.Client) error {
+		builder := client.Todo.UpdateOneID(id).
+			Where(todo.OwnerID(viewer.ID))
+		if args.Input.ExpectedVersion != nil {
+			builder = builder.Where(todo.Version(int(*args.Input.ExpectedVersion)))
+		}
+		applyTodoUpdate(builder.Mutation(), args.Input, projectID, parentID)
+
+		var err error
+		if updated, err = builder.Save(ctx); err != nil {
+			return err
+		}
+		if args.Input.Completed == nil || !*args.Input.Completed {
+			return nil
+		}
+		return completeTodo(ctx, client, updated, args.ChildPolicy, args.Force)
+	})
+	if ent.IsNotFound(err) && args.Input.ExpectedVersion != nil {
+		return nil, r.versionConflict(ctx, viewer, id, int(*args.Input.ExpectedVersion), err)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	return &TodoResolver{todo: todo}, nil
+	return r.todoResolver(updated), nil
 }
 
-func (r *Resolver) DeleteTodo(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+// completeTodo runs what follows the completion of the todo t: unless force
+// is set, it fails if open todos block t; it applies childPolicy, if set, to
+// the subtasks of t and creates the next occurrence of t if it recurs.
+func completeTodo(ctx context.Context, client *ent.Client, t *ent.Todo, childPolicy *string, force bool) error {
+	if !force {
+		if err := dependency.CheckComplete(ctx, client, t.ID); err != nil {
+			return err
+		}
+	}
+	if childPolicy != nil {
+		if err := subtask.Complete(ctx, client, t.ID, subtask.Policy(*childPolicy)); err != nil {
+			return err
+		}
+	}
+	_, err := recurrence.CreateNext(ctx, client, t)
+	return err
+}
+
+// applyTodoUpdate sets the changes described by input in m. projectID and
+// parentID are the resolved IDs of input.ProjectID and input.ParentID.
+func applyTodoUpdate(m *ent.TodoMutation, input UpdateTodoInput, projectID, parentID int) {
+	if input.Title != nil {
+		m.SetTitle(*input.Title)
+	}
+	if input.Description != nil {
+		m.SetDescription(*input.Description)
+	}
+	if input.Completed != nil {
+		m.SetCompleted(*input.Completed)
+	}
+	if input.Priority != nil {
+		m.SetPriority(todo.Priority(*input.Priority))
+	}
+	if input.ProjectID != nil {
+		m.SetProjectID(projectID)
+	} else if input.ClearProject != nil && *input.ClearProject {
+		m.ClearProjectID()
+	}
+	if input.ParentID != nil {
+		m.SetParentID(parentID)
+	} else if input.ClearParent != nil && *input.ClearParent {
+		m.ClearParentID()
+	}
+	if input.Recurrence != nil {
+		if *input.Recurrence == "" {
+			m.ClearRecurrence()
+		} else {
+			m.SetRecurrence(*input.Recurrence)
+		}
+	}
+	if input.DueAt != nil {
+		m.SetDueAt(input.DueAt.Time)
+	} else if input.ClearDueAt != nil && *input.ClearDueAt {
+		m.ClearDueAt()
+	}
+	// A new remind time gets a reminder of its own.
+	if input.RemindAt != nil {
+		m.SetRemindAt(input.RemindAt.Time)
+		m.ClearReminderSentAt()
+	} else if input.ClearRemindAt != nil && *input.ClearRemindAt {
+		m.ClearRemindAt()
+		m.ClearReminderSentAt()
+	}
+}
+
+// versionConflict returns the error of an update of the todo made against
+// version expected that did not find it: a CONFLICT error if the todo exists
+// with another version, err otherwise.
+func (r *Resolver) versionConflict(ctx context.Context, viewer *ent.User, id, expected int, err error) error {
+	current, qerr := r.Client.Todo.Query().
+		Where(todo.ID(id), todo.OwnerID(viewer.ID)).
+		Only(ctx)
+	if qerr != nil {
+		return err
+	}
+	return &Error{
+		Code:    CodeConflict,
+		Message: fmt.Sprintf("todo %d was updated concurrently: expected version %d, current version is %d", id, expected, current.Version),
+		Err:     err,
+	}
+}
+
+// DeleteTodo deletes a todo, applying childPolicy to its subtasks.
+func (r *Resolver) DeleteTodo(ctx context.Context, args struct {
+	ID          graphql.ID
+	ChildPolicy string
+}) (bool, error) {
 	id, err := strconv.Atoi(string(args.ID))
 	if err != nil {
 		return false, fmt.Errorf("invalid todo ID: %w", err)
 	}
 
-	err = r.Client.Todo.DeleteOneID(id).Exec(ctx)
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return false, err
+	}
+
+	err = r.withTx(ctx, func(client *ent.Client) error {
+		_, err := client.Todo.Query().
+			Where(todo.ID(id), todo.OwnerID(viewer.ID)).
+			OnlyID(ctx)
+		if err != nil {
+			return err
+		}
+		return subtask.Delete(ctx, client, id, subtask.Policy(args.ChildPolicy))
+	})
 	if err != nil {
 		return false, err
 	}
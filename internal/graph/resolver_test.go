@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+)
+
+func newTestSchema(client *ent.Client) *graphql.Schema {
+	return graphql.MustParseSchema(Schema, &Resolver{Client: client}, graphql.MaxParallelism(MaxParallelism))
+}
+
+// signIn creates a user and returns a context authenticated as them.
+func signIn(t *testing.T, client *ent.Client, email string) context.Context {
+	t.Helper()
+	ctx := context.Background()
+	user := client.User.Create().SetEmail(email).SetDisplayName(email).SaveX(ctx)
+	return auth.NewContext(ctx, &auth.Principal{User: user})
+}
+
+// exec runs query, decoding its data into v, and returns the message of its
+// first error, or "" if it succeeded.
+func exec(ctx context.Context, schema *graphql.Schema, query string, vars map[string]any, v any) string {
+	res := schema.Exec(ctx, query, "", vars)
+	if len(res.Errors) > 0 {
+		return res.Errors[0].Message
+	}
+	if v != nil {
+		if err := json.Unmarshal(res.Data, v); err != nil {
+			return err.Error()
+		}
+	}
+	return ""
+}
+
+// mustExec runs query, decoding its data into v, and fails the test if it
+// does not succeed.
+func mustExec(t *testing.T, ctx context.Context, schema *graphql.Schema, query string, vars map[string]any, v any) {
+	t.Helper()
+	if msg := exec(ctx, schema, query, vars, v); msg != "" {
+		t.Fatalf("%s: %s", query, msg)
+	}
+}
+
+func TestTodosOfOtherUsers(t *testing.T) {
+	client, _ := newTestClient(t)
+	schema := newTestSchema(client)
+	alice := signIn(t, client, "alice@example.com")
+	bob := signIn(t, client, "bob@example.com")
+	mustExec(t, alice, schema, `mutation { createTodos(inputs: [{title: "Buy milk"}, {title: "Call mom"}]) { id } }`, nil, nil)
+	mustExec(t, bob, schema, `mutation { createTodo(input: {title: "Fix the bike"}) { id } }`, nil, nil)
+
+	var data struct {
+		Todos           []struct{ ID string }
+		TodosConnection struct {
+			TotalCount int
+			Edges      []struct{ Node struct{ ID string } }
+		}
+	}
+	mustExec(t, bob, schema, `{
+		todos { id }
+		todosConnection(first: 10) { totalCount edges { node { id } } }
+	}`, nil, &data)
+	if len(data.Todos) != 1 || data.Todos[0].ID != "3" {
+		t.Errorf("got todos %v, want only todo 3", data.Todos)
+	}
+	if c := data.TodosConnection; c.TotalCount != 1 || len(c.Edges) != 1 || c.Edges[0].Node.ID != "3" {
+		t.Errorf("got connection %+v, want only todo 3", c)
+	}
+
+	for _, m := range []string{
+		`{ todo(id: 1) { id } }`,
+		`mutation { updateTodo(id: 1, input: {title: "Mine"}) { id } }`,
+		`mutation { deleteTodo(id: 1) }`,
+	} {
+		if msg := exec(bob, schema, m, nil, nil); msg != "ent: todo not found" {
+			t.Errorf("%s: got error %q, want ent: todo not found", m, msg)
+		}
+	}
+	var mine struct{ Todos []struct{ ID, Title string } }
+	mustExec(t, alice, schema, `{ todos { id title } }`, nil, &mine)
+	if len(mine.Todos) != 2 || mine.Todos[0].Title != "Buy milk" {
+		t.Errorf("got todos %+v of alice, want hers unchanged", mine.Todos)
+	}
+}
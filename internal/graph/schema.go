@@ -4,17 +4,80 @@ const Schema = `
 	schema {
 		query: Query
 		mutation: Mutation
+		subscription: Subscription
 	}
 
 	type Query {
 		todo(id: ID!): Todo
-		todos: [Todo!]!
+		todos(where: TodoWhereInput, orderBy: TodoOrder): [Todo!]! @listSize(assumedSize: 50)
+		todosConnection(
+			first: Int
+			after: String
+			last: Int
+			before: String
+			where: TodoWhereInput
+			orderBy: TodoOrder
+		): TodoConnection! @listSize(assumedSize: 20, slicingArguments: ["first", "last"], sizedFields: ["edges"])
+		searchTodos(query: String!, first: Int): [Todo!]! @listSize(assumedSize: 20, slicingArguments: ["first"]) @cost(weight: 5)
+		overdueTodos: [Todo!]! @listSize(assumedSize: 50)
+		dueBetween(from: Time!, to: Time!): [Todo!]! @listSize(assumedSize: 50)
+		# Todos in the trash, most recently deleted first.
+		trashedTodos: [Todo!]! @listSize(assumedSize: 50)
+		project(id: ID!): Project
+		projects(includeArchived: Boolean = false): [Project!]!
+		tags: [Tag!]!
+		me: User
+		apiKeys: [APIKey!]!
 	}
 
 	type Mutation {
+		signUp(input: SignUpInput!): User!
+		createAPIKey(input: CreateAPIKeyInput!): CreateAPIKeyPayload!
+		revokeAPIKey(id: ID!): APIKey!
 		createTodo(input: CreateTodoInput!): Todo!
-		updateTodo(id: ID!, input: UpdateTodoInput!): Todo!
-		deleteTodo(id: ID!): Boolean!
+		# childPolicy applies to the subtasks of the todo when the update
+		# completes it. Without it, the subtasks are left unchanged. Completing
+		# a todo blocked by open todos fails unless force is set.
+		updateTodo(id: ID!, input: UpdateTodoInput!, childPolicy: ChildPolicy, force: Boolean = false): Todo!
+		# Creates todos in one transaction, appended in order to the end of the list.
+		createTodos(inputs: [CreateTodoInput!]!): [Todo!]! @listSize(slicingArguments: ["inputs"])
+		# Applies input to all the todos matching where, in one transaction.
+		# Subtasks are left unchanged and expectedVersion is not supported.
+		updateTodos(where: TodoWhereInput!, input: UpdateTodoInput!, force: Boolean = false): [Todo!]! @listSize(assumedSize: 50)
+		# Moves all the todos matching where to the trash, in one transaction,
+		# and returns how many todos were deleted, subtasks included.
+		deleteTodos(where: TodoWhereInput!, childPolicy: ChildPolicy = ORPHAN): Int!
+		# Moves a todo to the trash. Trashed todos are purged after a retention period.
+		deleteTodo(id: ID!, childPolicy: ChildPolicy = ORPHAN): Boolean!
+		restoreTodo(id: ID!): Todo!
+		# Restores the fields of a todo to their values right after one of its revisions.
+		revertTodo(id: ID!, revisionId: ID!): Todo!
+		# Moves a todo between two todos of the viewer: after the todo "after",
+		# before the todo "before", or between both.
+		moveTodo(id: ID!, after: ID, before: ID): Todo!
+		createProject(input: CreateProjectInput!): Project!
+		updateProject(id: ID!, input: UpdateProjectInput!): Project!
+		deleteProject(id: ID!): Boolean!
+		addTagsToTodo(todoId: ID!, tags: [String!]!): Todo!
+		removeTagsFromTodo(todoId: ID!, tags: [String!]!): Todo!
+		# Makes todoId wait for blockedById. Dependencies forming a cycle are rejected.
+		addDependency(todoId: ID!, blockedById: ID!): Todo!
+		removeDependency(todoId: ID!, blockedById: ID!): Todo!
+		# Attaches a file sent with a multipart request to a todo.
+		attachFile(todoId: ID!, file: Upload!): Attachment!
+		deleteAttachment(id: ID!): Boolean!
+		# Comments on a todo. The body is Markdown.
+		addComment(todoId: ID!, body: String!): Comment!
+		# Edits a comment of the viewer.
+		editComment(id: ID!, body: String!): Comment!
+		# Deletes a comment of the viewer.
+		deleteComment(id: ID!): Boolean!
+	}
+
+	type Subscription {
+		todoCreated: Todo!
+		todoUpdated: Todo!
+		todoDeleted: Todo!
 	}
 
 	type Todo {
@@ -22,20 +85,313 @@ const Schema = `
 		title: String!
 		description: String
 		completed: Boolean!
+		priority: TodoPriority!
+		# Sort key of the todo among the todos of its owner.
+		position: String!
 		createdAt: Time!
 		updatedAt: Time!
+		dueAt: Time
+		remindAt: Time
+		owner: User
+		project: Project
+		tags: [Tag!]!
+		parent: Todo
+		children: [Todo!]!
+		# Fraction of the subtasks that are completed, null without subtasks.
+		progress: Float @cost(weight: 1)
+		# Todos waiting for this todo.
+		blocks: [Todo!]!
+		# Todos this todo waits for.
+		blockedBy: [Todo!]!
+		# Whether a todo this todo waits for is not completed.
+		isBlocked: Boolean! @cost(weight: 1)
+		# RFC 5545 recurrence rule, e.g. "FREQ=WEEKLY;BYDAY=MO".
+		recurrence: String
+		# Occurrence of the recurring todo this todo follows.
+		previousOccurrence: Todo
+		# Occurrence created when this todo was completed.
+		nextOccurrence: Todo
+		# When the todo was moved to the trash, null outside the trash.
+		deletedAt: Time
+		# Incremented on every update, except moves, which only reorder the
+		# todos. Pass it as expectedVersion to updateTodo to detect concurrent
+		# updates.
+		version: Int!
+		# Changes made to the todo, most recent first.
+		history: [TodoRevision!]!
+		# Files attached to the todo, oldest first.
+		attachments: [Attachment!]!
+		# Comments on the todo, oldest first.
+		comments(
+			first: Int
+			after: String
+			last: Int
+			before: String
+		): CommentConnection! @listSize(assumedSize: 20, slicingArguments: ["first", "last"], sizedFields: ["edges"])
+	}
+
+	type Comment {
+		id: ID!
+		# Content of the comment, in Markdown.
+		body: String!
+		author: User!
+		createdAt: Time!
+		# When the comment was last edited, null if it never was.
+		editedAt: Time
+	}
+
+	type CommentConnection {
+		edges: [CommentEdge!]!
+		pageInfo: PageInfo!
+		totalCount: Int! @cost(weight: 1)
+	}
+
+	type CommentEdge {
+		node: Comment!
+		cursor: String!
+	}
+
+	type Attachment {
+		id: ID!
+		filename: String!
+		contentType: String!
+		# Size of the file in bytes.
+		size: Int!
+		# Hex-encoded SHA-256 hash of the file.
+		checksum: String!
+		# Path downloading the file, relative to the server.
+		url: String!
+		createdAt: Time!
+	}
+
+	type TodoRevision {
+		id: ID!
+		operation: TodoRevisionOperation!
+		# User who made the change, null for changes made by the server.
+		actor: User
+		changes: [FieldChange!]!
+		createdAt: Time!
+	}
+
+	enum TodoRevisionOperation {
+		CREATE
+		UPDATE
+		# The todo was moved to the trash.
+		DELETE
+		# The todo was restored from the trash.
+		RESTORE
+	}
+
+	# Change of a todo field, named as in the REST API (e.g. "due_at"). Unset
+	# values are null and times are RFC 3339.
+	type FieldChange {
+		field: String!
+		oldValue: String
+		newValue: String
+	}
+
+	# What happens to the subtasks of a todo that is deleted or completed.
+	enum ChildPolicy {
+		# Delete or complete the subtasks too, recursively.
+		CASCADE
+		# Fail if the todo has subtasks, or incomplete subtasks on completion.
+		BLOCK
+		# Detach the subtasks (the incomplete ones on completion) from the todo.
+		ORPHAN
+	}
+
+	enum TodoPriority {
+		LOW
+		MEDIUM
+		HIGH
+		URGENT
+	}
+
+	type Tag {
+		id: ID!
+		name: String!
+		createdAt: Time!
+	}
+
+	type Project {
+		id: ID!
+		name: String!
+		color: String
+		archived: Boolean!
+		position: Int!
+		createdAt: Time!
+		updatedAt: Time!
+		todos(
+			first: Int
+			after: String
+			last: Int
+			before: String
+			where: TodoWhereInput
+			orderBy: TodoOrder
+		): TodoConnection! @listSize(assumedSize: 20, slicingArguments: ["first", "last"], sizedFields: ["edges"])
+	}
+
+	type User {
+		id: ID!
+		email: String!
+		displayName: String!
+		createdAt: Time!
+	}
+
+	type APIKey {
+		id: ID!
+		name: String!
+		prefix: String!
+		scopes: [APIKeyScope!]!
+		createdAt: Time!
+		lastUsedAt: Time
+		expiresAt: Time
+		revokedAt: Time
+	}
+
+	type CreateAPIKeyPayload {
+		apiKey: APIKey!
+		# The API key itself. It is only ever returned here.
+		key: String!
+	}
+
+	enum APIKeyScope {
+		TODOS_READ
+		TODOS_WRITE
+	}
+
+	type TodoConnection {
+		edges: [TodoEdge!]!
+		pageInfo: PageInfo!
+		totalCount: Int! @cost(weight: 1)
+	}
+
+	type TodoEdge {
+		node: Todo!
+		cursor: String!
+	}
+
+	type PageInfo {
+		hasNextPage: Boolean!
+		hasPreviousPage: Boolean!
+		startCursor: String
+		endCursor: String
+	}
+
+	input TodoWhereInput {
+		completed: Boolean
+		# Title matches ignore case.
+		titleContains: String
+		titleHasPrefix: String
+		createdAtGTE: Time
+		createdAtLTE: Time
+		updatedAtGTE: Time
+		updatedAtLTE: Time
+		# Matches todos labelled with the tag of this name.
+		hasTag: String
+		priority: TodoPriority
+		and: [TodoWhereInput!]
+		or: [TodoWhereInput!]
+		not: TodoWhereInput
+	}
+
+	enum OrderDirection {
+		ASC
+		DESC
+	}
+
+	enum TodoOrderField {
+		CREATED_AT
+		UPDATED_AT
+		TITLE
+		# Manual order, the default.
+		POSITION
+	}
+
+	input TodoOrder {
+		direction: OrderDirection = ASC
+		field: TodoOrderField!
+	}
+
+	input SignUpInput {
+		email: String!
+		displayName: String!
+		password: String!
+	}
+
+	input CreateAPIKeyInput {
+		name: String!
+		scopes: [APIKeyScope!]!
+		expiresAt: Time
 	}
 
 	input CreateTodoInput {
 		title: String!
 		description: String
+		priority: TodoPriority
+		projectId: ID
+		# Makes the todo a subtask of this todo.
+		parentId: ID
+		# RFC 5545 recurrence rule. Completing the todo creates its next
+		# occurrence, due at the next date of the rule after dueAt.
+		recurrence: String
+		dueAt: Time
+		remindAt: Time
 	}
 
 	input UpdateTodoInput {
 		title: String
 		description: String
 		completed: Boolean
+		priority: TodoPriority
+		projectId: ID
+		# Removes the todo from its project. Ignored when projectId is set.
+		clearProject: Boolean
+		parentId: ID
+		# Makes the todo a top-level todo. Ignored when parentId is set.
+		clearParent: Boolean
+		# An empty string stops the recurrence.
+		recurrence: String
+		dueAt: Time
+		# Removes the due date. Ignored when dueAt is set.
+		clearDueAt: Boolean
+		# Setting a new remind time schedules a new reminder.
+		remindAt: Time
+		# Cancels the reminder. Ignored when remindAt is set.
+		clearRemindAt: Boolean
+		# Fails the update with a CONFLICT error if the todo no longer has
+		# this version, i.e. it was updated since it was read.
+		expectedVersion: Int
+	}
+
+	input CreateProjectInput {
+		name: String!
+		color: String
+		position: Int
+	}
+
+	input UpdateProjectInput {
+		name: String
+		# An empty string clears the color.
+		color: String
+		archived: Boolean
+		position: Int
 	}
 
 	scalar Time
+
+	# File sent with a multipart request, following
+	# https://github.com/jaydenseric/graphql-multipart-request-spec.
+	scalar Upload
+
+	# Cost of a field for each item of the lists it is in, used to reject too
+	# complex operations. Defaults to 1 for fields of object types and to 0
+	# for the others.
+	directive @cost(weight: Int!) on FIELD_DEFINITION
+
+	# Number of items of a list field, multiplying the cost of each: the value
+	# of the first slicing argument set (the length of list arguments), or
+	# assumedSize. With sizedFields, the size applies to these fields of the
+	# result instead, as for connections. Lists without it hold 10 items.
+	directive @listSize(assumedSize: Int, slicingArguments: [String!], sizedFields: [String!]) on FIELD_DEFINITION
 `
\ No newline at end of file
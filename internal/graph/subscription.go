@@ -0,0 +1,52 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/pubsub"
+)
+
+// Subscription resolvers
+func (r *Resolver) TodoCreated(ctx context.Context) (<-chan *TodoResolver, error) {
+	return r.subscribe(ctx, pubsub.OpCreated)
+}
+
+func (r *Resolver) TodoUpdated(ctx context.Context) (<-chan *TodoResolver, error) {
+	return r.subscribe(ctx, pubsub.OpUpdated)
+}
+
+func (r *Resolver) TodoDeleted(ctx context.Context) (<-chan *TodoResolver, error) {
+	return r.subscribe(ctx, pubsub.OpDeleted)
+}
+
+// subscribe streams the todos of broker events of the given kind that are
+// owned by the authenticated user until ctx is done.
+func (r *Resolver) subscribe(ctx context.Context, op pubsub.Op) (<-chan *TodoResolver, error) {
+	viewer, err := authorize(ctx, auth.ScopeTodosRead)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := r.Broker.Subscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *TodoResolver)
+	go func() {
+		defer close(out)
+		for e := range events {
+			if e.Op != op || e.Todo.OwnerID != viewer.ID {
+				continue
+			}
+			select {
+			case out <- r.todoResolver(e.Todo):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,147 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	graphql "github.com/graph-gophers/graphql-go"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/user"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/pubsub"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/transport"
+)
+
+// subscribedBroker is a Broker signalling every subscription.
+type subscribedBroker struct {
+	pubsub.Broker
+	subscribed chan struct{}
+}
+
+func (b *subscribedBroker) Subscribe(ctx context.Context) (<-chan pubsub.Event, error) {
+	events, err := b.Broker.Subscribe(ctx)
+	b.subscribed <- struct{}{}
+	return events, err
+}
+
+// TestSubscriptionsOfOwner subscribes alice and bob to the changes of todos
+// over WebSocket connections, and checks that each receives only the changes
+// of their own todos.
+func TestSubscriptionsOfOwner(t *testing.T) {
+	client, _ := newTestClient(t)
+	broker := &subscribedBroker{Broker: pubsub.NewMemory(), subscribed: make(chan struct{}, 6)}
+	client.Todo.Use(pubsub.Hook(broker))
+	schema := graphql.MustParseSchema(Schema, &Resolver{Client: client, Broker: broker}, graphql.MaxParallelism(MaxParallelism))
+	alice := signIn(t, client, "alice@example.com")
+	bob := signIn(t, client, "bob@example.com")
+
+	// Connections sign in with the email in their connection_init payload.
+	srv := httptest.NewServer(&transport.WebSocket{
+		Schema: schema,
+		OnInit: func(ctx context.Context, payload json.RawMessage) (context.Context, error) {
+			var init struct{ Email string }
+			if err := json.Unmarshal(payload, &init); err != nil {
+				return nil, err
+			}
+			u, err := client.User.Query().Where(user.Email(init.Email)).Only(ctx)
+			if err != nil {
+				return nil, errors.New("unknown user")
+			}
+			return auth.NewContext(ctx, &auth.Principal{User: u}), nil
+		},
+	})
+	defer srv.Close()
+	connect := func(email string) *websocket.Conn {
+		dialer := websocket.Dialer{Subprotocols: []string{transport.Subprotocol}}
+		conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		conn.WriteJSON(map[string]any{"type": "connection_init", "payload": map[string]any{"email": email}})
+		var ack struct{ Type string }
+		if err := conn.ReadJSON(&ack); err != nil || ack.Type != "connection_ack" {
+			t.Fatalf("got %+v, %v, want connection_ack", ack, err)
+		}
+		for _, field := range []string{"todoCreated", "todoUpdated", "todoDeleted"} {
+			conn.WriteJSON(map[string]any{
+				"id":      field,
+				"type":    "subscribe",
+				"payload": map[string]any{"query": "subscription { " + field + " { id title } }"},
+			})
+		}
+		return conn
+	}
+	conns := map[string]*websocket.Conn{"alice": connect("alice@example.com"), "bob": connect("bob@example.com")}
+	for range 6 {
+		select {
+		case <-broker.subscribed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the subscriptions did not start")
+		}
+	}
+
+	// Each user ends with a change of every kind to a marker todo, received
+	// last on each subscription.
+	mustExec(t, alice, schema, `mutation {
+		createTodo(input: {title: "Buy milk"}) { id }
+		updateTodo(id: 1, input: {completed: true}) { id }
+	}`, nil, nil)
+	mustExec(t, bob, schema, `mutation {
+		createTodo(input: {title: "Call mum"}) { id }
+		deleteTodo(id: 2)
+	}`, nil, nil)
+	mustExec(t, alice, schema, `mutation { deleteTodo(id: 1) }`, nil, nil)
+	for _, ctx := range []context.Context{alice, bob} {
+		var marker struct{ CreateTodo struct{ ID string } }
+		mustExec(t, ctx, schema, `mutation { createTodo(input: {title: "Marker"}) { id } }`, nil, &marker)
+		mustExec(t, ctx, schema, `mutation($id: ID!) {
+			updateTodo(id: $id, input: {completed: true}) { id }
+			deleteTodo(id: $id)
+		}`, map[string]any{"id": marker.CreateTodo.ID}, nil)
+	}
+
+	want := map[string]map[string][]string{
+		"alice": {"todoCreated": {"1 Buy milk"}, "todoUpdated": {"1 Buy milk"}, "todoDeleted": {"1 Buy milk"}},
+		"bob":   {"todoCreated": {"2 Call mum"}, "todoUpdated": {}, "todoDeleted": {"2 Call mum"}},
+	}
+	for name, conn := range conns {
+		got := map[string][]string{"todoCreated": {}, "todoUpdated": {}, "todoDeleted": {}}
+		for done := 0; done < len(got); {
+			var msg struct {
+				ID      string
+				Type    string
+				Payload struct {
+					Data   map[string]struct{ ID, Title string }
+					Errors []struct{ Message string }
+				}
+			}
+			if err := conn.ReadJSON(&msg); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			if msg.Type != "next" || len(msg.Payload.Errors) > 0 {
+				t.Fatalf("%s: got %s message %+v for %s", name, msg.Type, msg.Payload, msg.ID)
+			}
+			todo := msg.Payload.Data[msg.ID]
+			if todo.Title == "Marker" {
+				done++
+				continue
+			}
+			got[msg.ID] = append(got[msg.ID], fmt.Sprintf("%s %s", todo.ID, todo.Title))
+		}
+		for field, todos := range got {
+			if !slices.Equal(todos, want[name][field]) {
+				t.Errorf("%s: got %s of todos %v, want %v", name, field, todos, want[name][field])
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+)
+
+func (r *TodoResolver) Parent(ctx context.Context) (*TodoResolver, error) {
+	if r.todo.ParentID == 0 {
+		return nil, nil
+	}
+
+	// A parent in the trash does not load.
+	parent, err := r.root.loaders(ctx).todos.load(ctx, r.todo.ParentID)
+	if err != nil || parent == nil {
+		return nil, err
+	}
+
+	return r.root.todoResolver(parent), nil
+}
+
+func (r *TodoResolver) Children(ctx context.Context) ([]*TodoResolver, error) {
+	children, err := r.root.loaders(ctx).children.load(ctx, r.todo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.root.todoResolvers(children), nil
+}
+
+// Progress returns the fraction of the direct subtasks that are completed,
+// or nil if the todo has none.
+func (r *TodoResolver) Progress(ctx context.Context) (*float64, error) {
+	children, err := r.root.loaders(ctx).children.load(ctx, r.todo.ID)
+	if err != nil || len(children) == 0 {
+		return nil, err
+	}
+	completed := 0
+	for _, child := range children {
+		if child.Completed {
+			completed++
+		}
+	}
+
+	progress := float64(completed) / float64(len(children))
+	return &progress, nil
+}
+
+// ownedTodoID parses id and checks that it names a todo of viewer.
+func (r *Resolver) ownedTodoID(ctx context.Context, viewer *ent.User, id graphql.ID) (int, error) {
+	todoID, err := strconv.Atoi(string(id))
+	if err != nil {
+		return 0, fmt.Errorf("invalid todo ID: %w", err)
+	}
+
+	exists, err := r.Client.Todo.Query().
+		Where(todo.ID(todoID), todo.OwnerID(viewer.ID)).
+		Exist(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, fmt.Errorf("todo %d not found", todoID)
+	}
+
+	return todoID, nil
+}
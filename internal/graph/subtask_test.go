@@ -0,0 +1,121 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/softdelete"
+)
+
+// hierarchy describes every todo, trashed ones included, as its ID followed
+// by "<" and the ID of its parent, if any, and by " done" and " trashed" if
+// it is completed or in the trash.
+func hierarchy(ctx context.Context, client *ent.Client) []string {
+	todos := client.Todo.Query().Order(ent.Asc(todo.FieldID)).AllX(softdelete.Skip(ctx))
+	states := make([]string, len(todos))
+	for i, t := range todos {
+		s := fmt.Sprint(t.ID)
+		if t.ParentID != 0 {
+			s += fmt.Sprintf("<%d", t.ParentID)
+		}
+		if t.Completed {
+			s += " done"
+		}
+		if t.DeletedAt != nil {
+			s += " trashed"
+		}
+		states[i] = s
+	}
+	return states
+}
+
+func TestSubtasks(t *testing.T) {
+	unchanged := []string{"1", "2<1", "3<2", "4<1 done"}
+	tests := []struct {
+		name     string
+		setup    []string
+		mutation string
+		want     []string
+		wantErr  string
+	}{
+		{name: "delete with cascade", mutation: `deleteTodo(id: 1, childPolicy: CASCADE)`, want: []string{"1 trashed", "2<1 trashed", "3<2 trashed", "4<1 done trashed"}},
+		{name: "delete with block", mutation: `deleteTodo(id: 1, childPolicy: BLOCK)`, want: unchanged, wantErr: "todo has subtasks"},
+		{name: "delete a leaf with block", mutation: `deleteTodo(id: 3, childPolicy: BLOCK)`, want: []string{"1", "2<1", "3<2 trashed", "4<1 done"}},
+		{name: "delete with orphan", mutation: `deleteTodo(id: 1, childPolicy: ORPHAN)`, want: []string{"1 trashed", "2", "3<2", "4 done"}},
+		{name: "complete with cascade", mutation: `updateTodo(id: 1, input: {completed: true}, childPolicy: CASCADE) { id }`, want: []string{"1 done", "2<1 done", "3<2 done", "4<1 done"}},
+		{name: "complete with block", mutation: `updateTodo(id: 1, input: {completed: true}, childPolicy: BLOCK) { id }`, want: unchanged, wantErr: "todo has incomplete subtasks"},
+		{
+			name:     "complete with block and completed subtasks",
+			setup:    []string{`updateTodo(id: 3, input: {completed: true}) { id }`},
+			mutation: `updateTodo(id: 2, input: {completed: true}, childPolicy: BLOCK) { id }`,
+			want:     []string{"1", "2<1 done", "3<2 done", "4<1 done"},
+		},
+		{name: "complete with orphan", mutation: `updateTodo(id: 1, input: {completed: true}, childPolicy: ORPHAN) { id }`, want: []string{"1 done", "2", "3<2", "4<1 done"}},
+		{name: "complete without policy", mutation: `updateTodo(id: 1, input: {completed: true}) { id }`, want: []string{"1 done", "2<1", "3<2", "4<1 done"}},
+		{name: "parent", mutation: `updateTodo(id: 3, input: {parentId: 4}) { id }`, want: []string{"1", "2<1", "3<4", "4<1 done"}},
+		{name: "clear parent", mutation: `updateTodo(id: 2, input: {clearParent: true}) { id }`, want: []string{"1", "2", "3<2", "4<1 done"}},
+		{name: "parent of itself", mutation: `updateTodo(id: 1, input: {parentId: 1}) { id }`, want: unchanged, wantErr: "a todo cannot be a subtask of itself or of its subtasks"},
+		{name: "parent cycle", mutation: `updateTodo(id: 1, input: {parentId: 3}) { id }`, want: unchanged, wantErr: "a todo cannot be a subtask of itself or of its subtasks"},
+		{
+			name:     "parent cycle of a bulk update",
+			mutation: `updateTodos(where: {titleHasPrefix: "1"}, input: {parentId: 2}) { id }`,
+			want:     unchanged,
+			wantErr:  "a todo cannot be a subtask of itself or of its subtasks",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t)
+			ctx := signIn(t, client, "alice@example.com")
+			schema := newTestSchema(client)
+			mustExec(t, ctx, schema, `mutation {
+				one: createTodo(input: {title: "1"}) { id }
+				two: createTodo(input: {title: "2", parentId: 1}) { id }
+				three: createTodo(input: {title: "3", parentId: 2}) { id }
+				four: createTodo(input: {title: "4", parentId: 1}) { id }
+				done: updateTodo(id: 4, input: {completed: true}) { id }
+			}`, nil, nil)
+			for _, m := range tt.setup {
+				mustExec(t, ctx, schema, "mutation { "+m+" }", nil, nil)
+			}
+
+			if msg := exec(ctx, schema, "mutation { "+tt.mutation+" }", nil, nil); msg != tt.wantErr {
+				t.Errorf("got error %q, want %q", msg, tt.wantErr)
+			}
+			if got := hierarchy(ctx, client); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubtaskProgress(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := signIn(t, client, "alice@example.com")
+	schema := newTestSchema(client)
+	mustExec(t, ctx, schema, `mutation {
+		one: createTodo(input: {title: "Plan the trip"}) { id }
+		two: createTodo(input: {title: "Book the train", parentId: 1}) { id }
+		three: createTodo(input: {title: "Book the hotel", parentId: 1}) { id }
+		four: createTodo(input: {title: "Pack", parentId: 1}) { id }
+		done: updateTodo(id: 2, input: {completed: true}) { id }
+	}`, nil, nil)
+
+	var data struct {
+		Todo struct {
+			Progress *float64
+			Children []struct{ Progress *float64 }
+		}
+	}
+	mustExec(t, ctx, schema, `{ todo(id: 1) { progress children { progress } } }`, nil, &data)
+	if p := data.Todo.Progress; p == nil || *p != 1.0/3 {
+		t.Errorf("got progress %v, want 1/3", p)
+	}
+	if len(data.Todo.Children) != 3 || data.Todo.Children[0].Progress != nil {
+		t.Errorf("got children %+v, want 3 without progress", data.Todo.Children)
+	}
+}
@@ -0,0 +1,205 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/tag"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+)
+
+// Tag GraphQL resolver
+type TagResolver struct {
+	tag *ent.Tag
+}
+
+func (r *TagResolver) ID() graphql.ID {
+	return graphql.ID(strconv.Itoa(r.tag.ID))
+}
+
+func (r *TagResolver) Name() string {
+	return r.tag.Name
+}
+
+func (r *TagResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.tag.CreatedAt}
+}
+
+func tagResolvers(tags []*ent.Tag) []*TagResolver {
+	resolvers := make([]*TagResolver, len(tags))
+	for i, tag := range tags {
+		resolvers[i] = &TagResolver{tag: tag}
+	}
+	return resolvers
+}
+
+func (r *TodoResolver) Tags(ctx context.Context) ([]*TagResolver, error) {
+	tags, err := r.root.loaders(ctx).tags.load(ctx, r.todo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return tagResolvers(tags), nil
+}
+
+// tagNames trims names and drops empty and duplicate ones.
+func tagNames(names []string) []string {
+	var unique []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		unique = append(unique, name)
+	}
+	return unique
+}
+
+// ensureTags returns the IDs of the tags of owner with the given names,
+// creating the tags that do not exist yet.
+func (r *Resolver) ensureTags(ctx context.Context, ownerID int, names []string) ([]int, error) {
+	ids := make([]int, 0, len(names))
+	for _, name := range names {
+		id, err := r.Client.Tag.Query().
+			Where(tag.OwnerID(ownerID), tag.Name(name)).
+			OnlyID(ctx)
+		if ent.IsNotFound(err) {
+			var t *ent.Tag
+			t, err = r.Client.Tag.Create().
+				SetName(name).
+				SetOwnerID(ownerID).
+				Save(ctx)
+			if ent.IsConstraintError(err) {
+				// Created concurrently by another request.
+				id, err = r.Client.Tag.Query().
+					Where(tag.OwnerID(ownerID), tag.Name(name)).
+					OnlyID(ctx)
+			} else if err == nil {
+				id = t.ID
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Query resolvers
+func (r *Resolver) Tags(ctx context.Context) ([]*TagResolver, error) {
+	viewer, err := authorize(ctx, auth.ScopeTodosRead)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := r.Client.Tag.Query().
+		Where(tag.OwnerID(viewer.ID)).
+		Order(ent.Asc(tag.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tagResolvers(tags), nil
+}
+
+// Mutation resolvers
+
+// AddTagsToTodo labels a todo with tags by name. Tags that do not exist yet
+// are created.
+func (r *Resolver) AddTagsToTodo(ctx context.Context, args struct {
+	TodoID graphql.ID
+	Tags   []string
+}) (*TodoResolver, error) {
+	id, err := strconv.Atoi(string(args.TodoID))
+	if err != nil {
+		return nil, fmt.Errorf("invalid todo ID: %w", err)
+	}
+
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := r.Client.Todo.Query().
+		Where(todo.ID(id), todo.OwnerID(viewer.ID)).
+		Exist(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("todo %d not found", id)
+	}
+
+	tagIDs, err := r.ensureTags(ctx, viewer.ID, tagNames(args.Tags))
+	if err != nil {
+		return nil, err
+	}
+
+	// Skip tags the todo already has: the join table rejects duplicates.
+	existing, err := r.Client.Tag.Query().
+		Where(tag.IDIn(tagIDs...), tag.HasTodosWith(todo.ID(id))).
+		IDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var add []int
+	for _, tagID := range tagIDs {
+		if !slices.Contains(existing, tagID) {
+			add = append(add, tagID)
+		}
+	}
+
+	todo, err := r.Client.Todo.UpdateOneID(id).
+		AddTagIDs(add...).
+		Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.todoResolver(todo), nil
+}
+
+// RemoveTagsFromTodo removes tags, by name, from a todo. The tags themselves
+// are kept.
+func (r *Resolver) RemoveTagsFromTodo(ctx context.Context, args struct {
+	TodoID graphql.ID
+	Tags   []string
+}) (*TodoResolver, error) {
+	id, err := strconv.Atoi(string(args.TodoID))
+	if err != nil {
+		return nil, fmt.Errorf("invalid todo ID: %w", err)
+	}
+
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	tagIDs, err := r.Client.Tag.Query().
+		Where(tag.OwnerID(viewer.ID), tag.NameIn(tagNames(args.Tags)...)).
+		IDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := r.Client.Todo.UpdateOneID(id).
+		Where(todo.OwnerID(viewer.ID)).
+		RemoveTagIDs(tagIDs...).
+		Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.todoResolver(todo), nil
+}
@@ -0,0 +1,101 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+// taggedTodo is a todo queried with the IDs and names of its tags.
+type taggedTodo struct {
+	Tags []struct{ ID, Name string }
+}
+
+// names returns the names of the tags of t.
+func (t taggedTodo) names() []string {
+	names := []string{}
+	for _, tag := range t.Tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+func TestTags(t *testing.T) {
+	client, _ := newTestClient(t)
+	alice := signIn(t, client, "alice@example.com")
+	bob := signIn(t, client, "bob@example.com")
+	schema := newTestSchema(client)
+	mustExec(t, alice, schema, `mutation {
+		createTodo(input: {title: "Fix the sink"}) { id }
+		milk: createTodo(input: {title: "Buy milk"}) { id }
+	}`, nil, nil)
+	mustExec(t, bob, schema, `mutation { createTodo(input: {title: "Call mum"}) { id } }`, nil, nil)
+
+	const add = `mutation($id: ID!, $tags: [String!]!) { addTagsToTodo(todoId: $id, tags: $tags) { tags { id name } } }`
+	var added struct{ AddTagsToTodo taggedTodo }
+	// Names are trimmed, and duplicates and empty names dropped.
+	mustExec(t, alice, schema, add, map[string]any{"id": "1", "tags": []any{"home", " urgent ", "home", " "}}, &added)
+	if got, want := added.AddTagsToTodo.names(), []string{"home", "urgent"}; !slices.Equal(got, want) {
+		t.Errorf("got tags %v, want %v", got, want)
+	}
+	// Adding a tag the todo already has is a no-op.
+	mustExec(t, alice, schema, add, map[string]any{"id": "1", "tags": []any{"home"}}, &added)
+	if got := len(added.AddTagsToTodo.Tags); got != 2 {
+		t.Errorf("got %d tags after adding a tag again, want 2", got)
+	}
+	// Tags are created once per owner and reused by their other todos.
+	mustExec(t, alice, schema, add, map[string]any{"id": "2", "tags": []any{"home", "errand"}}, &added)
+	mustExec(t, bob, schema, add, map[string]any{"id": "3", "tags": []any{"home"}}, nil)
+
+	var tags struct{ Tags []struct{ ID, Name string } }
+	mustExec(t, alice, schema, `{ tags { id name } }`, nil, &tags)
+	if got, want := (taggedTodo{tags.Tags}).names(), []string{"errand", "home", "urgent"}; !slices.Equal(got, want) {
+		t.Errorf("got tags %v of alice, want %v", got, want)
+	}
+	home := tags.Tags[1].ID
+	for _, tag := range added.AddTagsToTodo.Tags {
+		if tag.Name == "home" && tag.ID != home {
+			t.Errorf("got tag %s for home on another todo, want %s", tag.ID, home)
+		}
+	}
+	// Two owners can have tags of the same name.
+	mustExec(t, bob, schema, `{ tags { id name } }`, nil, &tags)
+	if len(tags.Tags) != 1 || tags.Tags[0].Name != "home" || tags.Tags[0].ID == home {
+		t.Errorf("got tags %v of bob, want his own home", tags.Tags)
+	}
+	if n := client.Tag.Query().CountX(alice); n != 4 {
+		t.Errorf("got %d tags, want 4", n)
+	}
+
+	// hasTag matches the todos of the viewer labelled with the tag.
+	var filtered struct{ Todos []struct{ ID string } }
+	mustExec(t, alice, schema, `{ todos(where: {hasTag: "home"}) { id } }`, nil, &filtered)
+	if len(filtered.Todos) != 2 || filtered.Todos[0].ID != "1" || filtered.Todos[1].ID != "2" {
+		t.Errorf("got todos %v tagged home, want 1 and 2", filtered.Todos)
+	}
+	mustExec(t, alice, schema, `{ todos(where: {hasTag: "urgent"}) { id } }`, nil, &filtered)
+	if len(filtered.Todos) != 1 || filtered.Todos[0].ID != "1" {
+		t.Errorf("got todos %v tagged urgent, want 1", filtered.Todos)
+	}
+
+	// Removing a tag detaches it from the todo only.
+	var removed struct{ RemoveTagsFromTodo taggedTodo }
+	mustExec(t, alice, schema, `mutation { removeTagsFromTodo(todoId: 1, tags: ["home", "unknown"]) { tags { id name } } }`, nil, &removed)
+	if got, want := removed.RemoveTagsFromTodo.names(), []string{"urgent"}; !slices.Equal(got, want) {
+		t.Errorf("got tags %v after the removal, want %v", got, want)
+	}
+	mustExec(t, alice, schema, `{ todos(where: {hasTag: "home"}) { id } }`, nil, &filtered)
+	if len(filtered.Todos) != 1 || filtered.Todos[0].ID != "2" {
+		t.Errorf("got todos %v tagged home after the removal, want 2", filtered.Todos)
+	}
+	if n := client.Tag.Query().CountX(alice); n != 4 {
+		t.Errorf("got %d tags after the removal, want 4", n)
+	}
+
+	// Todos of other users cannot be tagged.
+	if msg := exec(bob, schema, add, map[string]any{"id": "1", "tags": []any{"mine"}}, nil); msg != "todo 1 not found" {
+		t.Errorf("got error %q tagging a todo of another user", msg)
+	}
+	if msg := exec(bob, schema, `mutation { removeTagsFromTodo(todoId: 1, tags: ["urgent"]) { id } }`, nil, nil); msg != "ent: todo not found" {
+		t.Errorf("got error %q untagging a todo of another user", msg)
+	}
+}
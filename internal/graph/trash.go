@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/softdelete"
+)
+
+func (r *TodoResolver) DeletedAt() *graphql.Time {
+	return optionalTime(r.todo.DeletedAt)
+}
+
+// Query resolvers
+
+// TrashedTodos lists the todos of the viewer in the trash, most recently
+// deleted first.
+func (r *Resolver) TrashedTodos(ctx context.Context) ([]*TodoResolver, error) {
+	viewer, err := authorize(ctx, auth.ScopeTodosRead)
+	if err != nil {
+		return nil, err
+	}
+
+	todos, err := r.Client.Todo.Query().
+		Where(todo.OwnerID(viewer.ID), todo.DeletedAtNotNil()).
+		Order(ent.Desc(todo.FieldDeletedAt), ent.Desc(todo.FieldID)).
+		All(softdelete.Skip(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return r.todoResolvers(todos), nil
+}
+
+// Mutation resolvers
+
+// RestoreTodo moves a todo out of the trash, along with the subtasks trashed
+// with it.
+func (r *Resolver) RestoreTodo(ctx context.Context, args struct{ ID graphql.ID }) (*TodoResolver, error) {
+	id, err := strconv.Atoi(string(args.ID))
+	if err != nil {
+		return nil, fmt.Errorf("invalid todo ID: %w", err)
+	}
+
+	viewer, err := authorize(ctx, auth.ScopeTodosWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	var restored *ent.Todo
+	err = r.withTx(ctx, func(client *ent.Client) error {
+		trashed, err := client.Todo.Query().
+			Where(todo.ID(id), todo.OwnerID(viewer.ID), todo.DeletedAtNotNil()).
+			Only(softdelete.Skip(ctx))
+		if ent.IsNotFound(err) {
+			return fmt.Errorf("todo %d not found in the trash", id)
+		}
+		if err != nil {
+			return err
+		}
+		restored, err = softdelete.Restore(ctx, client, trashed)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.todoResolver(restored), nil
+}
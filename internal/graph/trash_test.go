@@ -0,0 +1,107 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRestoreTodo(t *testing.T) {
+	tests := []struct {
+		name string
+		// setup runs on todo 1, its subtask 2 and their subtask 3.
+		setup   []string
+		restore string
+		wantErr string
+		// wantTrash lists the IDs of the todos left in the trash.
+		wantTrash []string
+		// wantParent is the ID of the parent of the restored todo, if any.
+		wantParent string
+	}{
+		{
+			name:      "subtasks deleted along",
+			setup:     []string{`deleteTodo(id: 1, childPolicy: CASCADE)`},
+			restore:   "1",
+			wantTrash: []string{},
+		},
+		{
+			name: "subtask deleted on its own",
+			setup: []string{
+				`deleteTodo(id: 3)`,
+				`deleteTodo(id: 1, childPolicy: CASCADE)`,
+			},
+			restore:   "1",
+			wantTrash: []string{"3"},
+		},
+		{
+			name:      "subtask of a trashed todo",
+			setup:     []string{`deleteTodo(id: 1, childPolicy: CASCADE)`},
+			restore:   "2",
+			wantTrash: []string{"1"},
+		},
+		{
+			name:       "subtask of a todo outside the trash",
+			setup:      []string{`deleteTodo(id: 2, childPolicy: CASCADE)`},
+			restore:    "2",
+			wantTrash:  []string{},
+			wantParent: "1",
+		},
+		{
+			name:    "todo outside the trash",
+			restore: "1",
+			wantErr: "todo 1 not found in the trash",
+		},
+		{
+			name:    "missing todo",
+			restore: "4",
+			wantErr: "todo 4 not found in the trash",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t)
+			ctx := signIn(t, client, "alice@example.com")
+			schema := newTestSchema(client)
+			mustExec(t, ctx, schema, `mutation {
+				createTodo(input: {title: "Plan the trip"}) { id }
+				train: createTodo(input: {title: "Book the train", parentId: 1}) { id }
+				fares: createTodo(input: {title: "Compare fares", parentId: 2}) { id }
+			}`, nil, nil)
+			for _, m := range tt.setup {
+				mustExec(t, ctx, schema, "mutation { "+m+" }", nil, nil)
+			}
+
+			var restored struct {
+				RestoreTodo struct {
+					Parent *struct{ ID string }
+				}
+			}
+			msg := exec(ctx, schema, `mutation($id: ID!) { restoreTodo(id: $id) { parent { id } } }`,
+				map[string]any{"id": tt.restore}, &restored)
+			if msg != tt.wantErr {
+				t.Fatalf("got error %q, want %q", msg, tt.wantErr)
+			}
+			if msg != "" {
+				return
+			}
+			parent := ""
+			if p := restored.RestoreTodo.Parent; p != nil {
+				parent = p.ID
+			}
+			if parent != tt.wantParent {
+				t.Errorf("got parent %q, want %q", parent, tt.wantParent)
+			}
+
+			var trash struct {
+				TrashedTodos []struct{ ID string }
+			}
+			mustExec(t, ctx, schema, `{ trashedTodos { id } }`, nil, &trash)
+			ids := []string{}
+			for _, todo := range trash.TrashedTodos {
+				ids = append(ids, todo.ID)
+			}
+			if !slices.Equal(ids, tt.wantTrash) {
+				t.Errorf("got trash %v, want %v", ids, tt.wantTrash)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/auth"
+)
+
+// User GraphQL resolver
+type UserResolver struct {
+	user *ent.User
+}
+
+func (r *UserResolver) ID() graphql.ID {
+	return graphql.ID(strconv.Itoa(r.user.ID))
+}
+
+func (r *UserResolver) Email() string {
+	return r.user.Email
+}
+
+func (r *UserResolver) DisplayName() string {
+	return r.user.DisplayName
+}
+
+func (r *UserResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.user.CreatedAt}
+}
+
+// Input types
+type SignUpInput struct {
+	Email       string
+	DisplayName string
+	Password    string
+}
+
+// Me returns the authenticated user, or null for anonymous requests.
+// Requests with invalid credentials get an UNAUTHENTICATED error.
+func (r *Resolver) Me(ctx context.Context) (*UserResolver, error) {
+	viewer, err := viewerFromContext(ctx)
+	if errors.Is(err, auth.ErrUnauthenticated) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &UserResolver{user: viewer}, nil
+}
+
+// SignUp registers a user who logs in with email and password.
+func (r *Resolver) SignUp(ctx context.Context, args struct{ Input SignUpInput }) (*UserResolver, error) {
+	hash, err := auth.HashPassword(args.Input.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.Client.User.Create().
+		SetEmail(args.Input.Email).
+		SetDisplayName(args.Input.DisplayName).
+		SetPasswordHash(hash).
+		Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserResolver{user: user}, nil
+}
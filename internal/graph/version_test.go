@@ -0,0 +1,76 @@
+package graph
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/reminder"
+)
+
+func TestUpdateTodoExpectedVersion(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := signIn(t, client, "alice@example.com")
+	schema := newTestSchema(client)
+	mustExec(t, ctx, schema, `mutation { createTodo(input: {title: "Buy milk"}) { id } }`, nil, nil)
+
+	const update = `mutation($title: String!, $version: Int) {
+		updateTodo(id: "1", input: {title: $title, expectedVersion: $version}) { version }
+	}`
+	tests := []struct {
+		title       string
+		version     any
+		wantVersion int
+		wantErr     string
+	}{
+		{title: "a", version: 1, wantVersion: 2},
+		{title: "b", version: 1, wantErr: "todo 1 was updated concurrently: expected version 1, current version is 2"},
+		{title: "b", version: nil, wantVersion: 3},
+		{title: "c", version: 3, wantVersion: 4},
+	}
+	for _, tt := range tests {
+		var got struct {
+			UpdateTodo struct{ Version int }
+		}
+		msg := exec(ctx, schema, update, map[string]any{"title": tt.title, "version": tt.version}, &got)
+		if msg != tt.wantErr {
+			t.Fatalf("expected version %v: got error %q, want %q", tt.version, msg, tt.wantErr)
+		}
+		if tt.wantErr == "" && got.UpdateTodo.Version != tt.wantVersion {
+			t.Errorf("expected version %v: got version %d, want %d", tt.version, got.UpdateTodo.Version, tt.wantVersion)
+		}
+	}
+}
+
+// nopNotifier drops the reminders it is given.
+type nopNotifier struct{}
+
+func (nopNotifier) Notify(context.Context, *ent.Todo) error { return nil }
+
+// TestVersionKeptByReminder checks that sending the reminder of a todo, which
+// does not modify it, keeps its version.
+func TestVersionKeptByReminder(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := signIn(t, client, "alice@example.com")
+	schema := newTestSchema(client)
+	mustExec(t, ctx, schema, `mutation($remindAt: Time!) { createTodo(input: {title: "Call the dentist", remindAt: $remindAt}) { id } }`,
+		map[string]any{"remindAt": time.Now().Add(-time.Minute).Format(time.RFC3339)}, nil)
+
+	scheduler := &reminder.Scheduler{Client: client, Notifier: nopNotifier{}}
+	if err := scheduler.SendDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		UpdateTodo struct{ Version int }
+	}
+	msg := exec(ctx, schema, `mutation { updateTodo(id: "1", input: {completed: true, expectedVersion: 1}) { version } }`, nil, &got)
+	if msg != "" || got.UpdateTodo.Version != 2 {
+		t.Errorf("got version %d, error %q, want version 2", got.UpdateTodo.Version, msg)
+	}
+	if !strings.HasPrefix(exec(ctx, schema, `mutation { updateTodo(id: "1", input: {completed: false, expectedVersion: 1}) { version } }`, nil, nil), "todo 1 was updated concurrently") {
+		t.Error("a stale version was accepted")
+	}
+}
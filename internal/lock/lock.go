@@ -0,0 +1,39 @@
+// Package lock serializes the transactions that check invariants spanning
+// several todos of an owner, such as the absence of cycles among their
+// dependencies or subtasks.
+//
+// Under PostgreSQL's default READ COMMITTED isolation, such a check does not
+// see the changes of concurrent transactions, so two transactions can each
+// add one half of a cycle. Locking the row of the owner first makes the second
+// transaction wait until the first commits, and its check then sees the
+// committed changes.
+package lock
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/user"
+)
+
+// Owners locks the rows of the owners of the todos until the end of the
+// transaction of client. It is a no-op on SQLite, which supports no row locks
+// but runs one write transaction at a time.
+func Owners(ctx context.Context, client *ent.Client, todoIDs ...int) error {
+	_, err := client.User.Query().
+		Where(
+			user.HasTodosWith(todo.IDIn(todoIDs...)),
+			func(s *entsql.Selector) {
+				if s.Dialect() != dialect.SQLite {
+					s.ForUpdate(entsql.WithLockTables(user.Table))
+				}
+			},
+		).
+		Order(user.ByID()).
+		IDs(ctx)
+	return err
+}
@@ -0,0 +1,194 @@
+// Package persisted resolves persisted queries, which clients send by the
+// SHA-256 hash of their text instead of the text itself. Queries are either
+// registered by the clients, following the Automatic Persisted Queries (APQ)
+// protocol, or approved ahead of time in a manifest.
+package persisted
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	qerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+// Error codes of the rejected operations. The first two follow APQ, telling
+// clients to send the text of the query, or to stop sending hashes.
+const (
+	CodeNotFound     = "PERSISTED_QUERY_NOT_FOUND"
+	CodeNotSupported = "PERSISTED_QUERY_NOT_SUPPORTED"
+	CodeInvalid      = "PERSISTED_QUERY_INVALID"
+	CodeNotAllowed   = "PERSISTED_QUERY_NOT_ALLOWED"
+)
+
+// Hash returns the hash persisting query.
+func Hash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+// Cache is a least recently used cache of queries by hash.
+type Cache struct {
+	size int
+
+	mu      sync.Mutex
+	order   *list.List // of *cacheEntry, most recently used first
+	entries map[string]*list.Element
+}
+
+type cacheEntry struct {
+	hash, query string
+}
+
+// NewCache returns a cache keeping the size most recently used queries.
+func NewCache(size int) *Cache {
+	return &Cache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the query of hash.
+func (c *Cache) Get(hash string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[hash]
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*cacheEntry).query, true
+}
+
+// Add caches query under hash, evicting the least recently used query when
+// the cache is full.
+func (c *Cache) Add(hash, query string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[hash]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[hash] = c.order.PushFront(&cacheEntry{hash: hash, query: query})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).hash)
+	}
+}
+
+// Manifest maps the hashes of approved queries to their text.
+type Manifest map[string]string
+
+// LoadManifest reads a persisted query manifest in the format generated by
+// @apollo/generate-persisted-query-manifest:
+//
+//	{
+//	  "format": "apollo-persisted-query-manifest",
+//	  "version": 1,
+//	  "operations": [{"id": "<sha256 of body>", "name": "...", "type": "query", "body": "..."}]
+//	}
+func LoadManifest(path string) (Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		Format     string `json:"format"`
+		Version    int    `json:"version"`
+		Operations []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			Body string `json:"body"`
+		} `json:"operations"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing persisted query manifest: %w", err)
+	}
+	if file.Format != "apollo-persisted-query-manifest" || file.Version != 1 {
+		return nil, fmt.Errorf("unsupported persisted query manifest format %q version %d", file.Format, file.Version)
+	}
+
+	m := make(Manifest, len(file.Operations))
+	for _, op := range file.Operations {
+		hash := Hash(op.Body)
+		if op.ID != hash {
+			return nil, fmt.Errorf("operation %q: id %q is not the SHA-256 hash of its body", op.Name, op.ID)
+		}
+		m[hash] = op.Body
+	}
+	return m, nil
+}
+
+// Queries resolves the queries of operations.
+type Queries struct {
+	// Cache holds the queries registered by clients. When nil, clients
+	// cannot register queries.
+	Cache *Cache
+	// Allowlist, when set, restricts the operations to its queries, sent
+	// either by hash or in full. Clients cannot register queries.
+	Allowlist Manifest
+}
+
+// Resolve returns the query of an operation sent with query and extensions.
+// Operations sent with a "persistedQuery" extension may leave out their
+// query, in which case it is looked up by the hash of the extension.
+// Otherwise, the query is registered under the hash for the next operations.
+func (q *Queries) Resolve(query string, extensions map[string]interface{}) (string, *qerrors.QueryError) {
+	ext, ok := extensions["persistedQuery"].(map[string]interface{})
+	if !ok {
+		if q.Allowlist != nil {
+			if _, ok := q.Allowlist[Hash(query)]; !ok {
+				return "", queryError(CodeNotAllowed, "only persisted queries are allowed")
+			}
+		}
+		return query, nil
+	}
+	if version, _ := ext["version"].(float64); version != 1 {
+		return "", queryError(CodeInvalid, "unsupported persisted query version")
+	}
+	hash, _ := ext["sha256Hash"].(string)
+	hash = strings.ToLower(hash)
+
+	if query == "" {
+		var found bool
+		switch {
+		case q.Allowlist != nil:
+			query, found = q.Allowlist[hash]
+		case q.Cache != nil:
+			query, found = q.Cache.Get(hash)
+		default:
+			return "", queryError(CodeNotSupported, "PersistedQueryNotSupported")
+		}
+		if !found {
+			return "", queryError(CodeNotFound, "PersistedQueryNotFound")
+		}
+		return query, nil
+	}
+
+	if Hash(query) != hash {
+		return "", queryError(CodeInvalid, "provided sha256Hash does not match query")
+	}
+	switch {
+	case q.Allowlist != nil:
+		if _, ok := q.Allowlist[hash]; !ok {
+			return "", queryError(CodeNotAllowed, "only persisted queries are allowed")
+		}
+	case q.Cache != nil:
+		q.Cache.Add(hash, query)
+	}
+	return query, nil
+}
+
+func queryError(code, message string) *qerrors.QueryError {
+	return &qerrors.QueryError{
+		Message:    message,
+		Extensions: map[string]interface{}{"code": code},
+	}
+}
@@ -0,0 +1,137 @@
+package persisted
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	c := NewCache(2)
+	c.Add("a", "{ a }")
+	c.Add("b", "{ b }")
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("got no query for a")
+	}
+	// a was used more recently than b, so b is evicted.
+	c.Add("c", "{ c }")
+	// Adding a cached query again keeps its text and makes it the most
+	// recently used, so a is evicted next.
+	c.Add("c", "{ other }")
+	c.Add("d", "{ d }")
+
+	for hash, want := range map[string]string{"a": "", "b": "", "c": "{ c }", "d": "{ d }"} {
+		got, ok := c.Get(hash)
+		if got != want || ok != (want != "") {
+			t.Errorf("%s: got %q, %t, want %q", hash, got, ok, want)
+		}
+	}
+}
+
+// persistedQuery returns the extensions of an operation sent by hash.
+func persistedQuery(hash string) map[string]interface{} {
+	return map[string]interface{}{
+		"persistedQuery": map[string]interface{}{"version": float64(1), "sha256Hash": hash},
+	}
+}
+
+func TestQueriesResolve(t *testing.T) {
+	const (
+		todos   = "{ todos { id } }"
+		listed  = "{ todos { title } }"
+		another = "{ me { id } }"
+	)
+	apq := &Queries{Cache: NewCache(10)}
+	allowlist := &Queries{Allowlist: Manifest{Hash(listed): listed}}
+
+	// The steps run in order, so that registered queries are found later.
+	tests := []struct {
+		name       string
+		queries    *Queries
+		query      string
+		extensions map[string]interface{}
+		want       string
+		wantCode   string
+	}{
+		{name: "plain query", queries: apq, query: todos, want: todos},
+		{name: "miss", queries: apq, extensions: persistedQuery(Hash(todos)), wantCode: CodeNotFound},
+		{name: "register", queries: apq, query: todos, extensions: persistedQuery(Hash(todos)), want: todos},
+		{name: "hit", queries: apq, extensions: persistedQuery(Hash(todos)), want: todos},
+		{name: "hit by uppercase hash", queries: apq, extensions: persistedQuery(strings.ToUpper(Hash(todos))), want: todos},
+		{name: "hash mismatch", queries: apq, query: another, extensions: persistedQuery(Hash(todos)), wantCode: CodeInvalid},
+		{name: "mismatched query not registered", queries: apq, extensions: persistedQuery(Hash(another)), wantCode: CodeNotFound},
+		{
+			name:       "unsupported version",
+			queries:    apq,
+			query:      todos,
+			extensions: map[string]interface{}{"persistedQuery": map[string]interface{}{"version": float64(2), "sha256Hash": Hash(todos)}},
+			wantCode:   CodeInvalid,
+		},
+		{name: "no cache", queries: &Queries{}, extensions: persistedQuery(Hash(todos)), wantCode: CodeNotSupported},
+		{name: "no cache with the query", queries: &Queries{}, query: todos, extensions: persistedQuery(Hash(todos)), want: todos},
+		{name: "listed by hash", queries: allowlist, extensions: persistedQuery(Hash(listed)), want: listed},
+		{name: "listed in full", queries: allowlist, query: listed, want: listed},
+		{name: "listed with the hash", queries: allowlist, query: listed, extensions: persistedQuery(Hash(listed)), want: listed},
+		{name: "unlisted in full", queries: allowlist, query: todos, wantCode: CodeNotAllowed},
+		{name: "unlisted with the hash", queries: allowlist, query: todos, extensions: persistedQuery(Hash(todos)), wantCode: CodeNotAllowed},
+		{name: "unlisted by hash", queries: allowlist, extensions: persistedQuery(Hash(todos)), wantCode: CodeNotFound},
+	}
+	for _, tt := range tests {
+		got, err := tt.queries.Resolve(tt.query, tt.extensions)
+		code := ""
+		if err != nil {
+			code = err.Extensions["code"].(string)
+		}
+		if got != tt.want || code != tt.wantCode {
+			t.Errorf("%s: got %q, code %q, want %q, code %q", tt.name, got, code, tt.want, tt.wantCode)
+		}
+	}
+}
+
+func TestLoadManifest(t *testing.T) {
+	body := "query Todos { todos { id } }"
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			data: `{"format": "apollo-persisted-query-manifest", "version": 1, "operations": [{"id": "` + Hash(body) + `", "name": "Todos", "type": "query", "body": "` + body + `"}]}`,
+		},
+		{
+			name:    "id of another body",
+			data:    `{"format": "apollo-persisted-query-manifest", "version": 1, "operations": [{"id": "` + Hash("{ me { id } }") + `", "name": "Todos", "body": "` + body + `"}]}`,
+			wantErr: `operation "Todos": id "` + Hash("{ me { id } }") + `" is not the SHA-256 hash of its body`,
+		},
+		{
+			name:    "other format",
+			data:    `{"format": "relay", "version": 1}`,
+			wantErr: `unsupported persisted query manifest format "relay" version 1`,
+		},
+		{
+			name:    "other version",
+			data:    `{"format": "apollo-persisted-query-manifest", "version": 2}`,
+			wantErr: `unsupported persisted query manifest format "apollo-persisted-query-manifest" version 2`,
+		},
+		{name: "malformed", data: `{"format": `, wantErr: "parsing persisted query manifest: unexpected end of JSON input"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "manifest.json")
+		if err := os.WriteFile(path, []byte(tt.data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		m, err := LoadManifest(path)
+		msg := ""
+		if err != nil {
+			msg = err.Error()
+		}
+		if msg != tt.wantErr {
+			t.Errorf("%s: got error %q, want %q", tt.name, msg, tt.wantErr)
+		}
+		if err == nil && (len(m) != 1 || m[Hash(body)] != body) {
+			t.Errorf("%s: got %v", tt.name, m)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Package position generates fractional-index sort keys. A key can always be
+// generated between two others, so an item is moved by rewriting its own key
+// only.
+//
+// Keys are strings of the digits 0-9a-z, compared byte-wise (which any
+// collation agrees with for this alphabet). Appended keys are integers whose
+// first letter encodes their length ("a0" ... "az", "b00" ... "bzz", ...), so
+// they grow logarithmically; keys between two others extend the shorter one
+// with fractional digits.
+package position
+
+import (
+	"fmt"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// First is the key given to the first item of an empty list.
+const First = "a0"
+
+// Validate reports whether key is a well-formed key.
+func Validate(key string) error {
+	if key == "" {
+		return fmt.Errorf("empty position")
+	}
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return fmt.Errorf("invalid position %q", key)
+		}
+	}
+	return nil
+}
+
+// After returns a key sorting after a. An empty a returns First.
+func After(a string) (string, error) {
+	return Between(a, "")
+}
+
+// Before returns a key sorting before b. An empty b returns First.
+func Before(b string) (string, error) {
+	return Between("", b)
+}
+
+// Between returns a key sorting strictly between a and b. An empty a means
+// the start of the list and an empty b its end.
+func Between(a, b string) (string, error) {
+	for _, key := range []string{a, b} {
+		if key == "" {
+			continue
+		}
+		if err := Validate(key); err != nil {
+			return "", err
+		}
+	}
+	switch {
+	case a == "" && b == "":
+		return First, nil
+	case b == "":
+		if next, ok := increment(a); ok {
+			return next, nil
+		}
+	case a == "":
+		if prev, ok := decrement(b); ok {
+			return prev, nil
+		}
+	case a >= b:
+		return "", fmt.Errorf("position %q is not before %q", a, b)
+	}
+	return midpoint(a, b), nil
+}
+
+// increment returns the integer following the integer part of a. It fails
+// when a has no integer part or its integer part is the largest one.
+func increment(a string) (string, bool) {
+	if a[0] < 'a' {
+		// Keys before First have no integer part.
+		return First, true
+	}
+	n := int(a[0]-'a') + 1
+	if len(a) < 1+n {
+		return "", false
+	}
+	body := []byte(a[1 : 1+n])
+	for i := len(body) - 1; i >= 0; i-- {
+		if body[i] != digits[len(digits)-1] {
+			body[i] = digits[strings.IndexByte(digits, body[i])+1]
+			return a[:1] + string(body), true
+		}
+		body[i] = digits[0]
+	}
+	if a[0] == 'z' {
+		return "", false
+	}
+	// Carry into a longer integer.
+	return string(a[0]+1) + strings.Repeat(digits[:1], n+1), true
+}
+
+// decrement returns the integer preceding the integer part of b. It fails
+// when b has no integer part or its integer part is the smallest one.
+func decrement(b string) (string, bool) {
+	if b[0] < 'a' {
+		return "", false
+	}
+	n := int(b[0]-'a') + 1
+	if len(b) < 1+n {
+		return "", false
+	}
+	body := []byte(b[1 : 1+n])
+	for i := len(body) - 1; i >= 0; i-- {
+		if body[i] != digits[0] {
+			body[i] = digits[strings.IndexByte(digits, body[i])-1]
+			return b[:1] + string(body), true
+		}
+		body[i] = digits[len(digits)-1]
+	}
+	if b[0] == 'a' {
+		return "", false
+	}
+	// Borrow from a shorter integer.
+	return string(b[0]-1) + strings.Repeat(digits[len(digits)-1:], n-1), true
+}
+
+// midpoint returns a key between a < b, an empty b meaning the end. Keys it
+// returns never end with '0', so there is always room before them.
+func midpoint(a, b string) string {
+	if b != "" {
+		// Keep the common prefix, a being padded with zeros.
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + midpoint(rest, b[n:])
+		}
+	}
+
+	da := 0
+	if a != "" {
+		da = strings.IndexByte(digits, a[0])
+	}
+	db := len(digits)
+	if b != "" {
+		db = strings.IndexByte(digits, b[0])
+	}
+	if db-da > 1 {
+		return string(digits[(da+db)/2])
+	}
+
+	// The first digits are consecutive: continue after a's.
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(digits[da]) + midpoint(rest, "")
+}
+
+func digitAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return digits[0]
+}
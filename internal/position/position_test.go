@@ -0,0 +1,84 @@
+package position
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		want    string
+		wantErr string
+	}{
+		{a: "", b: "", want: "a0"},
+		{a: "a0", b: "", want: "a1"},
+		{a: "az", b: "", want: "b00"},
+		{a: "", b: "a5", want: "a4"},
+		{a: "", b: "b00", want: "az"},
+		{a: "", b: "a0", want: "5"},
+		{a: "5", b: "a0", want: "7"},
+		{a: "a0", b: "a2", want: "a1"},
+		{a: "a0", b: "a1", want: "a0i"},
+		{a: "a1", b: "a15", want: "a12"},
+		{a: "az", b: "b00", want: "azi"},
+		{a: "zzzz", b: "", want: "zzzzi"},
+		{a: "a0", b: "a0", wantErr: `position "a0" is not before "a0"`},
+		{a: "b00", b: "a0", wantErr: `position "b00" is not before "a0"`},
+		{a: "A0", b: "", wantErr: `invalid position "A0"`},
+		{a: "", b: "a-", wantErr: `invalid position "a-"`},
+	}
+	for _, tt := range tests {
+		got, err := Between(tt.a, tt.b)
+		msg := ""
+		if err != nil {
+			msg = err.Error()
+		}
+		if got != tt.want || msg != tt.wantErr {
+			t.Errorf("Between(%q, %q) = %q, %q, want %q, %q", tt.a, tt.b, got, msg, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// TestBetweenRandomInserts inserts keys at random places of a list and checks
+// that the list stays sorted.
+func TestBetweenRandomInserts(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var keys []string
+	for range 5000 {
+		i := r.Intn(len(keys) + 1)
+		var a, b string
+		if i > 0 {
+			a = keys[i-1]
+		}
+		if i < len(keys) {
+			b = keys[i]
+		}
+		key, err := Between(a, b)
+		if err != nil {
+			t.Fatalf("Between(%q, %q): %v", a, b, err)
+		}
+		if err := Validate(key); err != nil || (a != "" && key <= a) || (b != "" && key >= b) {
+			t.Fatalf("Between(%q, %q) = %q", a, b, key)
+		}
+		keys = slices.Insert(keys, i, key)
+	}
+}
+
+func TestAfterKeepsKeysShort(t *testing.T) {
+	key := ""
+	for range 1000 {
+		next, err := After(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next <= key {
+			t.Fatalf("After(%q) = %q", key, next)
+		}
+		key = next
+	}
+	if len(key) > 3 {
+		t.Errorf("got key %q after 1000 appends", key)
+	}
+}
@@ -0,0 +1,151 @@
+package pubsub
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/hook"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+)
+
+// Hook returns an ent hook publishing an Event to b for every todo that is
+// created, updated or deleted. Register it with Client.Todo.Use. Mutations
+// made inside a transaction are published only once it commits.
+func Hook(b Broker) ent.Hook {
+	p := &publisher{broker: b, pending: make(map[*ent.Tx][]Event)}
+	return hook.On(func(next ent.Mutator) ent.Mutator {
+		return hook.TodoFunc(func(ctx context.Context, m *ent.TodoMutation) (ent.Value, error) {
+			switch {
+			case m.Op().Is(ent.OpCreate):
+				return p.publishResult(ctx, next, m, OpCreated)
+			case m.Op().Is(ent.OpUpdateOne):
+				return p.publishResult(ctx, next, m, OpUpdated)
+			case m.Op().Is(ent.OpUpdate):
+				return p.publishUpdated(ctx, next, m)
+			default:
+				return p.publishDeleted(ctx, next, m)
+			}
+		})
+	}, ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne)
+}
+
+// publisher publishes the events of todo mutations. pending holds the events
+// of transactions that are committing.
+type publisher struct {
+	broker Broker
+
+	mu      sync.Mutex
+	pending map[*ent.Tx][]Event
+}
+
+// publishResult publishes the todo returned by a create or update-one mutation.
+func (p *publisher) publishResult(ctx context.Context, next ent.Mutator, m *ent.TodoMutation, op Op) (ent.Value, error) {
+	v, err := next.Mutate(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := v.(*ent.Todo); ok {
+		p.publish(ctx, m, Event{Op: op, Todo: t})
+	}
+	return v, nil
+}
+
+// publishUpdated publishes the new state of every todo matched by a bulk update.
+func (p *publisher) publishUpdated(ctx context.Context, next ent.Mutator, m *ent.TodoMutation) (ent.Value, error) {
+	ids, err := m.IDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v, err := next.Mutate(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	todos, err := m.Client().Todo.Query().Where(todo.IDIn(ids...)).All(ctx)
+	if err != nil {
+		log.Printf("pubsub: loading updated todos: %v", err)
+		return v, nil
+	}
+	events := make([]Event, len(todos))
+	for i, t := range todos {
+		events[i] = Event{Op: OpUpdated, Todo: t}
+	}
+	p.publish(ctx, m, events...)
+	return v, nil
+}
+
+// publishDeleted snapshots the todos matched by a delete and publishes them
+// once they are gone.
+func (p *publisher) publishDeleted(ctx context.Context, next ent.Mutator, m *ent.TodoMutation) (ent.Value, error) {
+	ids, err := m.IDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	todos, err := m.Client().Todo.Query().Where(todo.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v, err := next.Mutate(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]Event, len(todos))
+	for i, t := range todos {
+		events[i] = Event{Op: OpDeleted, Todo: t}
+	}
+	p.publish(ctx, m, events...)
+	return v, nil
+}
+
+// publish delivers the events of m, deferring them until the transaction m
+// runs in, if any, commits.
+func (p *publisher) publish(ctx context.Context, m *ent.TodoMutation, events ...Event) {
+	tx, err := m.Tx()
+	if err != nil {
+		p.deliver(ctx, events)
+		return
+	}
+
+	// Commit hooks run nested, the first registered outermost. Each hook
+	// queues its events before committing so the outermost one can deliver
+	// them all in mutation order once the transaction has committed.
+	tx.OnCommit(func(next ent.Committer) ent.Committer {
+		return ent.CommitFunc(func(ctx context.Context, tx *ent.Tx) error {
+			p.mu.Lock()
+			_, inner := p.pending[tx]
+			p.pending[tx] = append(p.pending[tx], events...)
+			p.mu.Unlock()
+
+			err := next.Commit(ctx, tx)
+			if inner {
+				return err
+			}
+			events := p.take(tx)
+			if err != nil {
+				return err
+			}
+			p.deliver(ctx, events)
+			return nil
+		})
+	})
+}
+
+// take removes and returns the events queued for tx.
+func (p *publisher) take(tx *ent.Tx) []Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	events := p.pending[tx]
+	delete(p.pending, tx)
+	return events
+}
+
+// deliver publishes events, logging failures: a lost notification must not
+// fail a mutation that has already been applied.
+func (p *publisher) deliver(ctx context.Context, events []Event) {
+	for _, e := range events {
+		if err := p.broker.Publish(ctx, e); err != nil {
+			log.Printf("pubsub: publishing %s event for todo %d: %v", e.Op, e.Todo.ID, err)
+		}
+	}
+}
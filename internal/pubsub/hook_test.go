@@ -0,0 +1,101 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/enttest"
+)
+
+func TestHook(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	defer client.Close()
+	b := NewMemory()
+	client.Todo.Use(Hook(b))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := b.Subscribe(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// received returns the events published so far.
+	received := func() []string {
+		var got []string
+		for {
+			select {
+			case e := <-events:
+				got = append(got, fmt.Sprintf("%s %d %s", e.Op, e.Todo.ID, e.Todo.Title))
+			default:
+				return got
+			}
+		}
+	}
+	owner := client.User.Create().SetEmail("alice@example.com").SetDisplayName("Alice").SaveX(ctx)
+
+	// Outside a transaction, events are published right away.
+	client.Todo.Create().SetTitle("Buy milk").SetOwner(owner).SaveX(ctx)
+	if got, want := received(), []string{"created 1 Buy milk"}; !slices.Equal(got, want) {
+		t.Errorf("got events %v, want %v", got, want)
+	}
+
+	// Events are held until the transaction commits, then delivered in
+	// mutation order.
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plants := tx.Todo.Create().SetTitle("Water plants").SetOwner(owner).SaveX(ctx)
+	tx.Todo.UpdateOne(plants).SetTitle("Water the plants").ExecX(ctx)
+	tx.Todo.Create().SetTitle("Call mum").SetOwner(owner).SaveX(ctx)
+	tx.Todo.Update().SetCompleted(true).ExecX(ctx)
+	tx.Todo.DeleteOneID(1).ExecX(ctx)
+	if got := received(); len(got) != 0 {
+		t.Errorf("got events %v before the commit", got)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"created 2 Water plants",
+		"updated 2 Water the plants",
+		"created 3 Call mum",
+		"updated 1 Buy milk",
+		"updated 2 Water the plants",
+		"updated 3 Call mum",
+		"deleted 1 Buy milk",
+	}
+	if got := received(); !slices.Equal(got, want) {
+		t.Errorf("got events %v after the commit, want %v", got, want)
+	}
+
+	// Events of a rolled back transaction are dropped.
+	tx, err = client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Todo.Create().SetTitle("Pack").SetOwner(owner).SaveX(ctx)
+	tx.Todo.UpdateOneID(2).SetCompleted(false).ExecX(ctx)
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if got := received(); len(got) != 0 {
+		t.Errorf("got events %v of a rolled back transaction", got)
+	}
+
+	// A later transaction does not deliver them either.
+	tx, err = client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Todo.UpdateOneID(3).SetTitle("Call dad").ExecX(ctx)
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := received(), []string{"updated 3 Call dad"}; !slices.Equal(got, want) {
+		t.Errorf("got events %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,150 @@
+package pubsub
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+)
+
+// DefaultChannel is the PostgreSQL notification channel used for todo events.
+const DefaultChannel = "todo_events"
+
+// maxPayload is the largest NOTIFY payload PostgreSQL accepts (in its default
+// configuration), minus one byte for the terminator.
+const maxPayload = 7999
+
+// notification is the NOTIFY payload of an Event. Todo is omitted when the
+// encoded event would not fit into a payload; receivers then reload it by ID,
+// or, for deletions, rebuild it from ID and OwnerID, which subscribers filter
+// on.
+type notification struct {
+	Op      Op        `json:"op"`
+	ID      int       `json:"id"`
+	OwnerID int       `json:"owner_id"`
+	Todo    *ent.Todo `json:"todo,omitempty"`
+}
+
+// Postgres is a Broker that fans events out across server instances. Events
+// are published with NOTIFY; every instance LISTENs on the channel and
+// rebroadcasts what it receives, its own events included, to its local
+// subscribers.
+type Postgres struct {
+	db       *sql.DB
+	client   *ent.Client
+	channel  string
+	listener *pq.Listener
+	local    *Memory
+	done     chan struct{}
+}
+
+// NewPostgres connects a listener to the database at dsn and starts relaying
+// notifications. db is used to publish, and client to reload todos whose
+// notification was too large to carry them.
+func NewPostgres(db *sql.DB, dsn string, client *ent.Client) (*Postgres, error) {
+	p := &Postgres{
+		db:      db,
+		client:  client,
+		channel: DefaultChannel,
+		local:   NewMemory(),
+		done:    make(chan struct{}),
+	}
+	p.listener = pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("pubsub: listener: %v", err)
+		}
+	})
+	if err := p.listener.Listen(p.channel); err != nil {
+		p.listener.Close()
+		return nil, err
+	}
+	go p.relay()
+	return p, nil
+}
+
+// Publish implements Broker.
+func (p *Postgres) Publish(ctx context.Context, e Event) error {
+	payload, err := encode(e)
+	if err != nil {
+		return err
+	}
+	_, err = p.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", p.channel, payload)
+	return err
+}
+
+// Subscribe implements Broker.
+func (p *Postgres) Subscribe(ctx context.Context) (<-chan Event, error) {
+	return p.local.Subscribe(ctx)
+}
+
+// Close stops listening for notifications.
+func (p *Postgres) Close() error {
+	close(p.done)
+	return p.listener.Close()
+}
+
+func (p *Postgres) relay() {
+	for {
+		select {
+		case n := <-p.listener.Notify:
+			// A nil notification signals a reconnect; events sent while
+			// the connection was down are lost.
+			if n == nil {
+				continue
+			}
+			e, err := p.decode(n.Extra)
+			if err != nil {
+				log.Printf("pubsub: decoding notification: %v", err)
+				continue
+			}
+			p.local.Publish(context.Background(), e)
+		case <-time.After(time.Minute):
+			go p.listener.Ping()
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// encode returns the NOTIFY payload of e, leaving the todo out if it does not
+// fit.
+func encode(e Event) (string, error) {
+	n := notification{Op: e.Op, ID: e.Todo.ID, OwnerID: e.Todo.OwnerID, Todo: e.Todo}
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return "", err
+	}
+	if len(payload) > maxPayload {
+		n.Todo = nil
+		if payload, err = json.Marshal(n); err != nil {
+			return "", err
+		}
+	}
+	return string(payload), nil
+}
+
+func (p *Postgres) decode(payload string) (Event, error) {
+	var n notification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		return Event{}, err
+	}
+	e := Event{Op: n.Op, Todo: n.Todo}
+	if e.Todo != nil {
+		return e, nil
+	}
+	if n.Op == OpDeleted {
+		e.Todo = &ent.Todo{ID: n.ID, OwnerID: n.OwnerID}
+		return e, nil
+	}
+	t, err := p.client.Todo.Get(context.Background(), n.ID)
+	if err != nil {
+		return Event{}, err
+	}
+	e.Todo = t
+	return e, nil
+}
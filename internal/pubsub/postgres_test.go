@@ -0,0 +1,57 @@
+package pubsub
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/enttest"
+	_ "github.com/ishidatakuo/graphql-ent-atlas/ent/runtime"
+)
+
+func TestNotificationRoundTrip(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	defer client.Close()
+	ctx := context.Background()
+	owner := client.User.Create().SetEmail("alice@example.com").SetDisplayName("Alice").SaveX(ctx)
+	small := client.Todo.Create().SetTitle("Buy milk").SetOwner(owner).SaveX(ctx)
+	large := client.Todo.Create().SetTitle("Pack").SetDescription(strings.Repeat("x", maxPayload)).SetOwner(owner).SaveX(ctx)
+	p := &Postgres{client: client}
+
+	tests := []struct {
+		name string
+		e    Event
+		// wantTodo is whether the payload carries the todo.
+		wantTodo bool
+	}{
+		{name: "updated", e: Event{Op: OpUpdated, Todo: small}, wantTodo: true},
+		{name: "deleted", e: Event{Op: OpDeleted, Todo: small}, wantTodo: true},
+		{name: "oversize updated", e: Event{Op: OpUpdated, Todo: large}},
+		{name: "oversize deleted", e: Event{Op: OpDeleted, Todo: large}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := encode(tt.e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(payload) > maxPayload {
+				t.Fatalf("got a payload of %d bytes, want at most %d", len(payload), maxPayload)
+			}
+			if got := strings.Contains(payload, `"todo":`); got != tt.wantTodo {
+				t.Errorf("payload carries the todo: got %t, want %t", got, tt.wantTodo)
+			}
+
+			e, err := p.decode(payload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e.Op != tt.e.Op || e.Todo.ID != tt.e.Todo.ID || e.Todo.OwnerID != owner.ID {
+				t.Errorf("got %s event for todo %d of user %d, want %s event for todo %d of user %d",
+					e.Op, e.Todo.ID, e.Todo.OwnerID, tt.e.Op, tt.e.Todo.ID, owner.ID)
+			}
+		})
+	}
+}
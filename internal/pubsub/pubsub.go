@@ -0,0 +1,83 @@
+// Package pubsub distributes todo change events to live subscribers.
+package pubsub
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+)
+
+// Op is the kind of change an Event describes.
+type Op string
+
+const (
+	OpCreated Op = "created"
+	OpUpdated Op = "updated"
+	OpDeleted Op = "deleted"
+)
+
+// Event is a change to a single todo. For deletions, Todo holds the last
+// state of the todo before it was removed.
+type Event struct {
+	Op   Op        `json:"op"`
+	Todo *ent.Todo `json:"todo"`
+}
+
+// Broker fans events out to subscribers.
+type Broker interface {
+	// Publish delivers e to the current subscribers.
+	Publish(ctx context.Context, e Event) error
+	// Subscribe returns a channel receiving published events. The channel is
+	// closed once ctx is done.
+	Subscribe(ctx context.Context) (<-chan Event, error)
+}
+
+// subscriberBuffer is the number of events buffered per subscriber before
+// further events are dropped for it.
+const subscriberBuffer = 64
+
+// Memory is an in-process Broker, for single-instance deployments and tests.
+type Memory struct {
+	mu   sync.RWMutex
+	subs map[chan Event]struct{}
+}
+
+// NewMemory returns an empty in-process broker.
+func NewMemory() *Memory {
+	return &Memory{subs: make(map[chan Event]struct{})}
+}
+
+// Publish implements Broker. Slow subscribers whose buffer is full miss the
+// event rather than blocking the publisher.
+func (b *Memory) Publish(_ context.Context, e Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for ch := range b.subs {
+		select {
+		case ch <- e:
+		default:
+			log.Printf("pubsub: dropping %s event for todo %d: subscriber is too slow", e.Op, e.Todo.ID)
+		}
+	}
+	return nil
+}
+
+// Subscribe implements Broker.
+func (b *Memory) Subscribe(ctx context.Context) (<-chan Event, error) {
+	ch := make(chan Event, subscriberBuffer)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.subs, ch)
+		close(ch)
+		b.mu.Unlock()
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,134 @@
+// Package recurrence implements the RFC 5545 recurrence rules (RRULE) of
+// todos. Completing a recurring todo creates its next occurrence, due at the
+// next date of the rule after its own due date.
+package recurrence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/teambition/rrule-go"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/tag"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/softdelete"
+)
+
+// parse parses rule, an RRULE value such as "FREQ=WEEKLY;BYDAY=MO,TH"
+// optionally prefixed with "RRULE:". The start of the series is the due date
+// of the todo, so rule must not have a DTSTART.
+func parse(rule string) (*rrule.ROption, error) {
+	opt, err := rrule.StrToROption(rule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence rule: %w", err)
+	}
+	if !opt.Dtstart.IsZero() {
+		return nil, errors.New("invalid recurrence rule: DTSTART is not supported, the due date starts the series")
+	}
+	if _, err := rrule.NewRRule(*opt); err != nil {
+		return nil, fmt.Errorf("invalid recurrence rule: %w", err)
+	}
+	return opt, nil
+}
+
+// Validate reports whether rule is a valid recurrence rule.
+func Validate(rule string) error {
+	_, err := parse(rule)
+	return err
+}
+
+// Next returns the first date of rule after due, together with the rule of
+// the occurrence due then: with a COUNT, the occurrences left are counted
+// down. ok is false once the series is over.
+func Next(rule string, due time.Time) (next time.Time, nextRule string, ok bool, err error) {
+	opt, err := parse(rule)
+	if err != nil {
+		return time.Time{}, "", false, err
+	}
+
+	left := opt.Count
+	if left == 1 {
+		return time.Time{}, "", false, nil
+	}
+	opt.Count = 0
+	opt.Dtstart = due
+	r, err := rrule.NewRRule(*opt)
+	if err != nil {
+		return time.Time{}, "", false, err
+	}
+	next = r.After(due, false)
+	if next.IsZero() {
+		return time.Time{}, "", false, nil
+	}
+
+	nextRule = rule
+	if left > 0 {
+		opt.Dtstart = time.Time{}
+		opt.Count = left - 1
+		nextRule = opt.RRuleString()
+	}
+	return next, nextRule, true, nil
+}
+
+// CreateNext creates the occurrence following the recurring todo t, linked
+// to it as its next occurrence. It returns nil if the series is over or the
+// next occurrence already exists, even in the trash: completing t again after
+// trashing its next occurrence does not bring the series back. Run it in the
+// transaction completing t so that both happen or neither.
+//
+// The next occurrence is due at the next date of the rule after the due date
+// of t, or after now if t has no due date, and keeps the delay between the
+// remind time and the due date of t.
+func CreateNext(ctx context.Context, client *ent.Client, t *ent.Todo) (*ent.Todo, error) {
+	if t.Recurrence == "" {
+		return nil, nil
+	}
+	exists, err := client.Todo.Query().
+		Where(todo.PreviousOccurrenceID(t.ID)).
+		Exist(softdelete.Skip(ctx))
+	if err != nil || exists {
+		return nil, err
+	}
+
+	due := time.Now()
+	if t.DueAt != nil {
+		due = *t.DueAt
+	}
+	next, rule, ok, err := Next(t.Recurrence, due)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	tagIDs, err := client.Tag.Query().
+		Where(tag.HasTodosWith(todo.ID(t.ID))).
+		IDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := client.Todo.Create().
+		SetTitle(t.Title).
+		SetDescription(t.Description).
+		SetPriority(t.Priority).
+		SetRecurrence(rule).
+		SetDueAt(next).
+		SetPreviousOccurrenceID(t.ID).
+		AddTagIDs(tagIDs...)
+	if t.OwnerID != 0 {
+		builder = builder.SetOwnerID(t.OwnerID)
+	}
+	if t.ProjectID != 0 {
+		builder = builder.SetProjectID(t.ProjectID)
+	}
+	if t.ParentID != 0 {
+		builder = builder.SetParentID(t.ParentID)
+	}
+	if t.RemindAt != nil && t.DueAt != nil {
+		builder = builder.SetRemindAt(next.Add(t.RemindAt.Sub(*t.DueAt)))
+	}
+
+	return builder.Save(ctx)
+}
@@ -0,0 +1,149 @@
+package recurrence_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/enttest"
+	_ "github.com/ishidatakuo/graphql-ent-atlas/ent/runtime"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/recurrence"
+)
+
+func TestNext(t *testing.T) {
+	due := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC) // a Friday
+	tests := []struct {
+		name     string
+		rule     string
+		want     time.Time
+		wantRule string
+		wantOK   bool
+		wantErr  string
+	}{
+		{name: "daily", rule: "FREQ=DAILY", want: due.AddDate(0, 0, 1), wantRule: "FREQ=DAILY", wantOK: true},
+		{name: "prefixed", rule: "RRULE:FREQ=DAILY", want: due.AddDate(0, 0, 1), wantRule: "RRULE:FREQ=DAILY", wantOK: true},
+		{name: "by day", rule: "FREQ=WEEKLY;BYDAY=MO,TH", want: due.AddDate(0, 0, 3), wantRule: "FREQ=WEEKLY;BYDAY=MO,TH", wantOK: true},
+		{name: "count counted down", rule: "FREQ=DAILY;COUNT=3", want: due.AddDate(0, 0, 1), wantRule: "FREQ=DAILY;COUNT=2", wantOK: true},
+		{name: "last of the count", rule: "FREQ=DAILY;COUNT=1"},
+		{name: "until later", rule: "FREQ=DAILY;UNTIL=20261020T000000Z", want: due.AddDate(0, 0, 1), wantRule: "FREQ=DAILY;UNTIL=20261020T000000Z", wantOK: true},
+		{name: "until passed", rule: "FREQ=DAILY;UNTIL=20261017T000000Z"},
+		{name: "invalid rule", rule: "FREQ=SOMETIMES", wantErr: "invalid recurrence rule: "},
+		{name: "start of the series", rule: "DTSTART=20261016T090000Z;FREQ=DAILY", wantErr: "invalid recurrence rule: DTSTART is not supported, the due date starts the series"},
+	}
+	for _, tt := range tests {
+		next, rule, ok, err := recurrence.Next(tt.rule, due)
+		if tt.wantErr != "" {
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("%s: got error %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !next.Equal(tt.want) || rule != tt.wantRule || ok != tt.wantOK {
+			t.Errorf("%s: got %v, %q, %t, want %v, %q, %t", tt.name, next, rule, ok, tt.want, tt.wantRule, tt.wantOK)
+		}
+	}
+}
+
+func TestCreateNext(t *testing.T) {
+	due := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	remind := due.Add(-time.Hour)
+	tests := []struct {
+		name string
+		// change adjusts the completed todo, due daily at due.
+		change     func(*ent.TodoCreate)
+		wantNone   bool
+		wantDue    time.Time
+		wantRemind *time.Time
+		wantRule   string
+	}{
+		{name: "next day", wantDue: due.AddDate(0, 0, 1), wantRule: "FREQ=DAILY"},
+		{
+			name:       "remind time kept before the due date",
+			change:     func(c *ent.TodoCreate) { c.SetRemindAt(remind) },
+			wantDue:    due.AddDate(0, 0, 1),
+			wantRemind: ptr(remind.AddDate(0, 0, 1)),
+			wantRule:   "FREQ=DAILY",
+		},
+		{
+			name:     "count counted down",
+			change:   func(c *ent.TodoCreate) { c.SetRecurrence("FREQ=DAILY;COUNT=2") },
+			wantDue:  due.AddDate(0, 0, 1),
+			wantRule: "FREQ=DAILY;COUNT=1",
+		},
+		{name: "series over", change: func(c *ent.TodoCreate) { c.SetRecurrence("FREQ=DAILY;COUNT=1") }, wantNone: true},
+		{name: "until passed", change: func(c *ent.TodoCreate) { c.SetRecurrence("FREQ=DAILY;UNTIL=20261016T120000Z") }, wantNone: true},
+		{name: "not recurring", change: func(c *ent.TodoCreate) { c.Mutation().ClearRecurrence() }, wantNone: true},
+		{
+			// Without a due date, the next occurrence is due after now, and
+			// the remind time has no due date to keep its delay from.
+			name:     "no due date",
+			change:   func(c *ent.TodoCreate) { c.SetRemindAt(remind).Mutation().ClearDueAt() },
+			wantDue:  time.Now().AddDate(0, 0, 1),
+			wantRule: "FREQ=DAILY",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+			defer client.Close()
+			ctx := context.Background()
+			owner := client.User.Create().SetEmail("alice@example.com").SetDisplayName("Alice").SaveX(ctx)
+			errand := client.Tag.Create().SetName("errand").SetOwner(owner).SaveX(ctx)
+			create := client.Todo.Create().
+				SetTitle("Water plants").
+				SetRecurrence("FREQ=DAILY").
+				SetDueAt(due).
+				SetCompleted(true).
+				SetOwner(owner).
+				AddTags(errand)
+			if tt.change != nil {
+				tt.change(create)
+			}
+			completed := create.SaveX(ctx)
+
+			next, err := recurrence.CreateNext(ctx, client, completed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantNone {
+				if next != nil {
+					t.Errorf("got next occurrence due at %v, want none", next.DueAt)
+				}
+				return
+			}
+			if next == nil {
+				t.Fatal("got no next occurrence")
+			}
+			if next.Completed || next.Title != completed.Title || next.OwnerID != owner.ID || next.PreviousOccurrenceID != completed.ID {
+				t.Errorf("got next occurrence %v", next)
+			}
+			if next.DueAt == nil || next.DueAt.Sub(tt.wantDue).Abs() > time.Minute {
+				t.Errorf("got due date %v, want %v", next.DueAt, tt.wantDue)
+			}
+			if (next.RemindAt == nil) != (tt.wantRemind == nil) || next.RemindAt != nil && !next.RemindAt.Equal(*tt.wantRemind) {
+				t.Errorf("got remind time %v, want %v", next.RemindAt, tt.wantRemind)
+			}
+			if next.Recurrence != tt.wantRule {
+				t.Errorf("got rule %q, want %q", next.Recurrence, tt.wantRule)
+			}
+			if tags := next.QueryTags().IDsX(ctx); len(tags) != 1 || tags[0] != errand.ID {
+				t.Errorf("got tags %v, want [%d]", tags, errand.ID)
+			}
+
+			// The next occurrence is created once.
+			if again, err := recurrence.CreateNext(ctx, client, completed); again != nil || err != nil {
+				t.Errorf("got %v, %v creating the next occurrence again", again, err)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
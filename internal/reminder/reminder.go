@@ -0,0 +1,109 @@
+// Package reminder sends reminders for todos whose remind time has passed.
+package reminder
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+)
+
+// Notifier delivers the reminder of a todo to its owner.
+type Notifier interface {
+	Notify(ctx context.Context, t *ent.Todo) error
+}
+
+// LogNotifier is a Notifier writing reminders to the standard logger.
+type LogNotifier struct{}
+
+// Notify implements Notifier.
+func (LogNotifier) Notify(_ context.Context, t *ent.Todo) error {
+	due := "no due date"
+	if t.DueAt != nil {
+		due = "due " + t.DueAt.Format(time.RFC3339)
+	}
+	log.Printf("reminder: todo %d %q of user %d (%s)", t.ID, t.Title, t.OwnerID, due)
+	return nil
+}
+
+const (
+	defaultInterval  = 30 * time.Second
+	defaultBatchSize = 100
+)
+
+// Scheduler periodically sends the reminders of open todos whose remindAt
+// has passed. Each reminder is claimed by setting reminderSentAt before it
+// is sent, so it is sent at most once even with several server instances.
+type Scheduler struct {
+	Client   *ent.Client
+	Notifier Notifier
+	// Interval between checks for due reminders. Defaults to 30 seconds.
+	Interval time.Duration
+	// BatchSize bounds the reminders sent per check. Defaults to 100.
+	BatchSize int
+}
+
+// Run sends due reminders until ctx is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	interval := s.Interval
+	if interval == 0 {
+		interval = defaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SendDue(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("reminder: sending due reminders: %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// SendDue sends the reminders that are due now.
+func (s *Scheduler) SendDue(ctx context.Context) error {
+	batchSize := s.BatchSize
+	if batchSize == 0 {
+		batchSize = defaultBatchSize
+	}
+
+	now := time.Now()
+	todos, err := s.Client.Todo.Query().
+		Where(
+			todo.RemindAtLTE(now),
+			todo.ReminderSentAtIsNil(),
+			todo.CompletedEQ(false),
+		).
+		Order(ent.Asc(todo.FieldRemindAt)).
+		Limit(batchSize).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range todos {
+		// Keep updatedAt: sending a reminder does not modify the todo.
+		n, err := s.Client.Todo.Update().
+			Where(todo.ID(t.ID), todo.ReminderSentAtIsNil()).
+			SetReminderSentAt(now).
+			SetUpdatedAt(t.UpdatedAt).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			// Claimed by another instance.
+			continue
+		}
+		if err := s.Notifier.Notify(ctx, t); err != nil {
+			log.Printf("reminder: notifying todo %d: %v", t.ID, err)
+		}
+	}
+	return nil
+}
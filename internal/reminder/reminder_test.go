@@ -0,0 +1,68 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/enttest"
+	_ "github.com/ishidatakuo/graphql-ent-atlas/ent/runtime"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/softdelete"
+)
+
+// recorder is a Notifier recording the todos it is called with.
+type recorder struct {
+	ids []int
+	err error
+}
+
+func (r *recorder) Notify(_ context.Context, t *ent.Todo) error {
+	r.ids = append(r.ids, t.ID)
+	return r.err
+}
+
+func TestSendDue(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	defer client.Close()
+	ctx := context.Background()
+	past := time.Now().Add(-time.Minute)
+	updated := past.Add(-time.Hour).Truncate(time.Second)
+	owner := client.User.Create().SetEmail("alice@example.com").SetDisplayName("Alice").SaveX(ctx)
+	create := func(title string) *ent.TodoCreate {
+		return client.Todo.Create().SetTitle(title).SetOwner(owner).SetRemindAt(past).SetUpdatedAt(updated)
+	}
+	create("Later").SetRemindAt(past.Add(time.Hour)).SaveX(ctx)
+	first := create("First").SetRemindAt(past.Add(-time.Minute)).SaveX(ctx)
+	second := create("Second").SaveX(ctx)
+	create("Completed").SetCompleted(true).SaveX(ctx)
+	create("Sent").SetReminderSentAt(past).SaveX(ctx)
+	trashed := create("Trashed").SaveX(ctx)
+	client.Todo.DeleteOneID(trashed.ID).ExecX(ctx)
+
+	r := &recorder{err: errors.New("mail server down")}
+	s := &Scheduler{Client: client, Notifier: r}
+	for range 2 {
+		if err := s.SendDue(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Reminders are sent once, earliest first, even if notifying fails.
+	if want := []int{first.ID, second.ID}; !slices.Equal(r.ids, want) {
+		t.Errorf("got reminders of todos %v, want %v", r.ids, want)
+	}
+
+	for _, td := range client.Todo.Query().AllX(softdelete.Skip(ctx)) {
+		claimed := td.ReminderSentAt != nil && td.ReminderSentAt.After(past)
+		if want := td.ID == first.ID || td.ID == second.ID; claimed != want {
+			t.Errorf("%s: got reminder sent at %v", td.Title, td.ReminderSentAt)
+		}
+		if claimed && !td.UpdatedAt.Equal(updated) {
+			t.Errorf("%s: got update time %v, want %v", td.Title, td.UpdatedAt, updated)
+		}
+	}
+}
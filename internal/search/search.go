@@ -0,0 +1,124 @@
+// Package search implements relevance-ranked full-text search over todos.
+//
+// On PostgreSQL it uses the generated "search_vector" tsvector column and its
+// GIN index (see migrations/). Single-word queries also match substrings, as
+// text written without spaces, such as Japanese, is not split into words. On
+// other dialects, such as SQLite in tests, it falls back to case-insensitive
+// substring matching.
+package search
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"unicode"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+)
+
+const (
+	// Column is the generated tsvector column of the todos table.
+	Column = "search_vector"
+	// Index is the GIN index over Column.
+	Index = "todos_search_vector_idx"
+	// config is the text search configuration. "simple" does no stemming and
+	// has no stop words, so it favours no language, but it only splits words
+	// on spaces and punctuation: "牛乳を買う" is a single word.
+	config = "simple"
+)
+
+// ErrEmptyQuery is returned when the search query is blank.
+var ErrEmptyQuery = errors.New("search query must not be empty")
+
+// Todos returns the todos matched by q that match the search query, most
+// relevant first.
+func Todos(ctx context.Context, q *ent.TodoQuery, query string, limit int) ([]*ent.Todo, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrEmptyQuery
+	}
+	return q.
+		Where(func(s *entsql.Selector) {
+			s.Where(match(s, query))
+		}).
+		Order(func(s *entsql.Selector) {
+			s.OrderExpr(entsql.ExprFunc(func(b *entsql.Builder) {
+				rank(b, s, query)
+				b.WriteString(" DESC")
+			}))
+		}, todo.ByID()).
+		Limit(limit).
+		All(ctx)
+}
+
+func match(s *entsql.Selector, query string) *entsql.Predicate {
+	contains := entsql.Or(
+		entsql.ContainsFold(s.C(todo.FieldTitle), query),
+		entsql.ContainsFold(s.C(todo.FieldDescription), query),
+	)
+	if s.Dialect() != dialect.Postgres {
+		return contains
+	}
+	matches := entsql.P(func(b *entsql.Builder) {
+		b.WriteString(s.C(Column)).WriteString(" @@ websearch_to_tsquery('" + config + "', ").
+			Arg(query).WriteString(")")
+	})
+	if !substrings(query) {
+		return matches
+	}
+	return entsql.Or(matches, contains)
+}
+
+// substrings reports whether query also matches substrings of the titles
+// and descriptions: "牛乳" is not a word of "牛乳を買う", so without it,
+// searches in languages written without spaces would only match whole
+// phrases. Queries of several words are left to the index.
+func substrings(query string) bool {
+	return !strings.ContainsFunc(query, unicode.IsSpace)
+}
+
+// rank writes the relevance expression. Without tsvector support, title
+// matches rank above description-only matches, as do title substrings of
+// single-word queries on PostgreSQL.
+func rank(b *entsql.Builder, s *entsql.Selector, query string) {
+	titleMatch := func() {
+		b.WriteString("CASE WHEN ").
+			Join(entsql.ContainsFold(s.C(todo.FieldTitle), query)).
+			WriteString(" THEN 1 ELSE 0 END")
+	}
+	if s.Dialect() != dialect.Postgres {
+		titleMatch()
+		return
+	}
+	b.WriteString("ts_rank(").WriteString(s.C(Column)).WriteString(", websearch_to_tsquery('" + config + "', ").
+		Arg(query).WriteString("))")
+	if substrings(query) {
+		b.WriteString(" + ")
+		titleMatch()
+	}
+}
+
+// EnsureSchema creates the search column and index if they are missing. The
+// Atlas migrations create them as well; this keeps databases set up through
+// ent's auto-migration usable for search. It is a no-op on other dialects.
+func EnsureSchema(ctx context.Context, db *sql.DB, dialectName string) error {
+	if dialectName != dialect.Postgres {
+		return nil
+	}
+	stmts := []string{
+		`ALTER TABLE "todos" ADD COLUMN IF NOT EXISTS "` + Column + `" tsvector GENERATED ALWAYS AS (` +
+			`setweight(to_tsvector('` + config + `', coalesce("title", '')), 'A') || ` +
+			`setweight(to_tsvector('` + config + `', coalesce("description", '')), 'B')) STORED`,
+		`CREATE INDEX IF NOT EXISTS "` + Index + `" ON "todos" USING GIN ("` + Column + `")`,
+	}
+	for _, stmt := range stmts {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/enttest"
+	_ "github.com/ishidatakuo/graphql-ent-atlas/ent/runtime"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+)
+
+func TestTodosFallback(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	defer client.Close()
+	ctx := context.Background()
+	for _, create := range []struct {
+		title, description string
+		completed          bool
+	}{
+		{title: "Call the plumber", description: "About the kitchen sink"},
+		{title: "Fix the sink"},
+		{title: "Buy milk", description: "And bread"},
+		{title: "Clean the SINK", completed: true},
+		{title: "Water plants"},
+		{title: "スーパーに行く", description: "牛乳と卵を買う"},
+		{title: "牛乳を買う"},
+	} {
+		client.Todo.Create().
+			SetTitle(create.title).
+			SetDescription(create.description).
+			SetCompleted(create.completed).
+			SaveX(ctx)
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		open    bool
+		limit   int
+		want    []int
+		wantErr error
+	}{
+		{name: "title matches first", query: "sink", limit: 10, want: []int{2, 4, 1}},
+		{name: "ignoring case and spaces", query: "  SiNk ", limit: 10, want: []int{2, 4, 1}},
+		{name: "description only", query: "bread", limit: 10, want: []int{3}},
+		{name: "Japanese", query: "牛乳", limit: 10, want: []int{7, 6}},
+		{name: "filtered query", query: "sink", open: true, limit: 10, want: []int{2, 1}},
+		{name: "limit", query: "sink", limit: 2, want: []int{2, 4}},
+		{name: "no match", query: "garage", limit: 10, want: []int{}},
+		{name: "blank query", query: " \t", limit: 10, wantErr: ErrEmptyQuery},
+	}
+	for _, tt := range tests {
+		q := client.Todo.Query()
+		if tt.open {
+			q = q.Where(todo.Completed(false))
+		}
+		todos, err := Todos(ctx, q, tt.query, tt.limit)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: got error %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		ids := []int{}
+		for _, result := range todos {
+			ids = append(ids, result.ID)
+		}
+		if err == nil && !slices.Equal(ids, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, ids, tt.want)
+		}
+	}
+}
+
+func TestEnsureSchemaOtherDialects(t *testing.T) {
+	if err := EnsureSchema(context.Background(), nil, dialect.SQLite); err != nil {
+		t.Errorf("got error %v on SQLite", err)
+	}
+}
+
+func TestMatchPostgres(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		// wantSubstrings is whether substrings of titles and descriptions
+		// match as well as words.
+		wantSubstrings bool
+	}{
+		{name: "single word", query: "牛乳", wantSubstrings: true},
+		{name: "several words", query: "buy milk"},
+	}
+	for _, tt := range tests {
+		s := entsql.Dialect(dialect.Postgres).Select("*").From(entsql.Table(todo.Table))
+		s.Where(match(s, tt.query)).OrderExpr(entsql.ExprFunc(func(b *entsql.Builder) { rank(b, s, tt.query) }))
+		query, _ := s.Query()
+		where, order, _ := strings.Cut(query, "ORDER BY")
+		if !strings.Contains(where, `"search_vector" @@ websearch_to_tsquery('simple', $1)`) {
+			t.Errorf("%s: got %s, want a full-text match", tt.name, where)
+		}
+		if got := strings.Contains(where, "ILIKE"); got != tt.wantSubstrings {
+			t.Errorf("%s: got %s, want substring matches %t", tt.name, where, tt.wantSubstrings)
+		}
+		if got := strings.Contains(order, "ILIKE"); got != tt.wantSubstrings {
+			t.Errorf("%s: got order %s, want title substrings ranked %t", tt.name, order, tt.wantSubstrings)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Package softdelete moves deleted todos to the trash instead of deleting
+// them. Trashed todos are hidden from queries and updates until they are
+// restored, and purged once they have been in the trash for longer than a
+// retention period.
+package softdelete
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/hook"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+)
+
+type skipKey struct{}
+
+// Skip returns a context in which queries and updates see trashed todos and
+// deletes are permanent.
+func Skip(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipKey{}, true)
+}
+
+// Skipped reports whether ctx was returned by Skip.
+func Skipped(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipKey{}).(bool)
+	return skip
+}
+
+// Interceptor hides trashed todos from every todo query, including edge
+// traversals, unless the context was returned by Skip.
+func Interceptor() ent.Interceptor {
+	return ent.TraverseFunc(func(ctx context.Context, q ent.Query) error {
+		if Skipped(ctx) {
+			return nil
+		}
+		tq, ok := q.(*ent.TodoQuery)
+		if !ok {
+			return fmt.Errorf("softdelete: unexpected query type %T", q)
+		}
+		tq.Where(todo.DeletedAtIsNil())
+		return nil
+	})
+}
+
+// Hook turns todo deletes into updates moving the todos to the trash, and
+// keeps updates away from trashed todos, unless the context was returned by
+// Skip.
+func Hook(next ent.Mutator) ent.Mutator {
+	return hook.TodoFunc(func(ctx context.Context, m *ent.TodoMutation) (ent.Value, error) {
+		if Skipped(ctx) {
+			return next.Mutate(ctx, m)
+		}
+		m.Where(todo.DeletedAtIsNil())
+		if m.Op().Is(ent.OpUpdate | ent.OpUpdateOne) {
+			return next.Mutate(ctx, m)
+		}
+
+		// Run the delete as an update, through the hooks of updates.
+		m.SetOp(ent.OpUpdate)
+		m.SetDeletedAt(time.Now())
+		return m.Client().Mutate(ctx, m)
+	})
+}
+
+// Restore moves the trashed todo t out of the trash, along with its subtasks
+// trashed at the same time, recursively, such as those deleted with it by the
+// Cascade policy of package subtask. Subtasks trashed on their own stay in
+// the trash. If the parent of t is still in the trash, t is detached from it.
+// It returns t as restored. Run it in a transaction.
+func Restore(ctx context.Context, client *ent.Client, t *ent.Todo) (*ent.Todo, error) {
+	ctx = Skip(ctx)
+	ids := []int{t.ID}
+	for level := ids; len(level) > 0; {
+		children, err := client.Todo.Query().
+			Where(todo.ParentIDIn(level...), todo.DeletedAt(*t.DeletedAt)).
+			IDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, children...)
+		level = children
+	}
+
+	if t.ParentID != 0 {
+		trashed, err := client.Todo.Query().
+			Where(todo.ID(t.ParentID), todo.DeletedAtNotNil()).
+			Exist(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if trashed {
+			if err := client.Todo.UpdateOneID(t.ID).ClearParentID().Exec(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err := client.Todo.Update().
+		Where(todo.IDIn(ids...)).
+		ClearDeletedAt().
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Todo.Get(ctx, t.ID)
+}
+
+const (
+	defaultRetention     = 30 * 24 * time.Hour
+	defaultPurgeInterval = time.Hour
+)
+
+// Purger periodically deletes, permanently, the todos that have been in the
+// trash for longer than Retention.
+type Purger struct {
+	Client *ent.Client
+	// Retention is how long todos stay in the trash. Defaults to 30 days.
+	Retention time.Duration
+	// Interval between purges. Defaults to an hour.
+	Interval time.Duration
+	// BeforePurge, when set, is called with the IDs of the todos about to be
+	// purged, to clean up what the database does not, such as the files
+	// attached to them. An error puts the purge off.
+	BeforePurge func(ctx context.Context, ids []int) error
+}
+
+// Run purges the trash until ctx is done.
+func (p *Purger) Run(ctx context.Context) {
+	interval := p.Interval
+	if interval == 0 {
+		interval = defaultPurgeInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := p.Purge(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("softdelete: purging trash: %v", err)
+		} else if n > 0 {
+			log.Printf("softdelete: purged %d todos from the trash", n)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Purge permanently deletes the todos trashed longer than the retention ago
+// and returns how many it deleted.
+func (p *Purger) Purge(ctx context.Context) (int, error) {
+	retention := p.Retention
+	if retention == 0 {
+		retention = defaultRetention
+	}
+
+	ctx = Skip(ctx)
+	ids, err := p.Client.Todo.Query().
+		Where(todo.DeletedAtLT(time.Now().Add(-retention))).
+		IDs(ctx)
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	if p.BeforePurge != nil {
+		if err := p.BeforePurge(ctx, ids); err != nil {
+			return 0, err
+		}
+	}
+
+	return p.Client.Todo.Delete().
+		Where(todo.IDIn(ids...)).
+		Exec(ctx)
+}
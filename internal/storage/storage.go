@@ -0,0 +1,103 @@
+// Package storage keeps blobs, such as the files attached to todos, outside
+// the database.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotFound is returned for the keys no blob is stored under.
+var ErrNotFound = errors.New("storage: blob not found")
+
+// BlobStore stores blobs by key. Keys are slash-separated paths, such as
+// "attachments/3f2a".
+type BlobStore interface {
+	// Put stores the size bytes read from r under key, replacing the blob
+	// stored under it.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get returns the content of the blob stored under key, or ErrNotFound.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob stored under key, if any.
+	Delete(ctx context.Context, key string) error
+}
+
+// checkKey rejects the keys that are not slash-separated paths within the
+// store, such as "../secret" or "/etc/passwd".
+func checkKey(key string) error {
+	if !fs.ValidPath(key) || key == "." {
+		return fmt.Errorf("storage: invalid key %q", key)
+	}
+	return nil
+}
+
+// Local is a BlobStore keeping blobs as the files of a directory.
+type Local struct {
+	Dir string
+}
+
+func (s Local) path(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.Dir, filepath.FromSlash(key)), nil
+}
+
+// Put implements BlobStore. The blob is written to a temporary file first,
+// so that readers never see it partly written.
+func (s Local) Put(_ context.Context, key string, r io.Reader, size int64, _ string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	n, err := io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if n != size {
+		return fmt.Errorf("storage: read %d bytes of a %d-byte blob", n, size)
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Get implements BlobStore.
+func (s Local) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+// Delete implements BlobStore.
+func (s Local) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocal(t *testing.T) {
+	dir := t.TempDir()
+	s := Local{Dir: filepath.Join(dir, "blobs")}
+	ctx := context.Background()
+
+	if err := s.Put(ctx, "attachments/a", strings.NewReader("hello"), 5, "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put(ctx, "attachments/a", strings.NewReader("bye"), 3, "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+	r, err := s.Get(ctx, "attachments/a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := io.ReadAll(r)
+	r.Close()
+	if string(b) != "bye" {
+		t.Errorf("got %q, want the replaced blob %q", b, "bye")
+	}
+
+	if err := s.Delete(ctx, "attachments/a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get(ctx, "attachments/a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got %v after deletion, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, "attachments/a"); err != nil {
+		t.Errorf("got %v deleting a missing blob", err)
+	}
+
+	// Blobs of the wrong size are not stored, and leave no temporary file.
+	err = s.Put(ctx, "attachments/short", strings.NewReader("hi"), 5, "text/plain")
+	if want := "storage: read 2 bytes of a 5-byte blob"; err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %q", err, want)
+	}
+	if _, err := s.Get(ctx, "attachments/short"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got %v for a blob of the wrong size, want ErrNotFound", err)
+	}
+	if entries, _ := os.ReadDir(filepath.Join(s.Dir, "attachments")); len(entries) != 0 {
+		t.Errorf("got files %v left behind", entries)
+	}
+}
+
+func TestLocalKeys(t *testing.T) {
+	dir := t.TempDir()
+	s := Local{Dir: filepath.Join(dir, "blobs")}
+	ctx := context.Background()
+	if err := os.WriteFile(filepath.Join(dir, "secret"), []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"../secret", "attachments/../../secret", "/etc/passwd", "attachments/", "./a", "a//b", ".", ""} {
+		want := `storage: invalid key "` + key + `"`
+		if err := s.Put(ctx, key, strings.NewReader("x"), 1, ""); err == nil || err.Error() != want {
+			t.Errorf("Put %q: got error %v, want %q", key, err, want)
+		}
+		if _, err := s.Get(ctx, key); err == nil || err.Error() != want {
+			t.Errorf("Get %q: got error %v, want %q", key, err, want)
+		}
+		if err := s.Delete(ctx, key); err == nil || err.Error() != want {
+			t.Errorf("Delete %q: got error %v, want %q", key, err, want)
+		}
+	}
+	if b, _ := os.ReadFile(filepath.Join(dir, "secret")); string(b) != "secret" {
+		t.Errorf("got %q outside the store", b)
+	}
+}
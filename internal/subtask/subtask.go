@@ -0,0 +1,198 @@
+// Package subtask maintains the parent/children hierarchy of todos: it keeps
+// the hierarchy free of cycles and applies a Policy to the subtasks of a todo
+// that is deleted or completed.
+package subtask
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ishidatakuo/graphql-ent-atlas/ent"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/hook"
+	"github.com/ishidatakuo/graphql-ent-atlas/ent/todo"
+	"github.com/ishidatakuo/graphql-ent-atlas/internal/lock"
+)
+
+var (
+	// ErrCycle is returned when a todo would become a subtask of itself or
+	// of one of its subtasks.
+	ErrCycle = errors.New("a todo cannot be a subtask of itself or of its subtasks")
+	// ErrHasChildren is returned by Delete with the Block policy.
+	ErrHasChildren = errors.New("todo has subtasks")
+	// ErrIncompleteChildren is returned by Complete with the Block policy.
+	ErrIncompleteChildren = errors.New("todo has incomplete subtasks")
+)
+
+// Policy decides what happens to the subtasks of a todo that is deleted or
+// completed.
+type Policy string
+
+const (
+	// Cascade deletes or completes all subtasks, recursively.
+	Cascade Policy = "CASCADE"
+	// Block fails when the todo has subtasks (on delete) or incomplete
+	// subtasks (on completion).
+	Block Policy = "BLOCK"
+	// Orphan detaches the subtasks (the incomplete ones on completion), which
+	// are otherwise left unchanged.
+	Orphan Policy = "ORPHAN"
+)
+
+// ParsePolicy parses a policy name, ignoring case.
+func ParsePolicy(s string) (Policy, error) {
+	p := Policy(strings.ToUpper(s))
+	switch p {
+	case Cascade, Block, Orphan:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown subtask policy %q", s)
+}
+
+// PreventCycles is a todo mutation hook rejecting parents that would make
+// the hierarchy cyclic with ErrCycle. In a transaction, it locks the owners of
+// the todos, so that concurrent updates cannot form a cycle together.
+func PreventCycles(next ent.Mutator) ent.Mutator {
+	return hook.TodoFunc(func(ctx context.Context, m *ent.TodoMutation) (ent.Value, error) {
+		parentID, ok := m.ParentID()
+		if !ok || m.Op().Is(ent.OpCreate) {
+			return next.Mutate(ctx, m)
+		}
+
+		ids, err := m.IDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := lock.Owners(ctx, m.Client(), append(ids, parentID)...); err != nil {
+			return nil, err
+		}
+		// Walk up from the new parent: the hierarchy is acyclic, so this
+		// ends at a top-level todo unless it meets a mutated one.
+		for id := parentID; id != 0; {
+			if slices.Contains(ids, id) {
+				return nil, ErrCycle
+			}
+			parent, err := m.Client().Todo.Get(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("loading parent todo %d: %w", id, err)
+			}
+			id = parent.ParentID
+		}
+		return next.Mutate(ctx, m)
+	})
+}
+
+// Descendants returns the IDs of the subtasks of the todos, recursively.
+func Descendants(ctx context.Context, client *ent.Client, ids ...int) ([]int, error) {
+	var descendants []int
+	for level := ids; len(level) > 0; {
+		children, err := client.Todo.Query().
+			Where(todo.ParentIDIn(level...)).
+			IDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		descendants = append(descendants, children...)
+		level = children
+	}
+	return descendants, nil
+}
+
+// Delete deletes the todo, applying p to its subtasks. The todo and the
+// subtasks deleted along are deleted at once, so that they share their time
+// in the trash. Run it in a transaction so that a failure leaves the subtasks
+// untouched.
+func Delete(ctx context.Context, client *ent.Client, id int, p Policy) error {
+	descendants, err := applyDelete(ctx, client, []int{id}, p)
+	if err != nil {
+		return err
+	}
+	n, err := client.Todo.Delete().
+		Where(todo.IDIn(append(descendants, id)...)).
+		Exec(ctx)
+	if err != nil || n > 0 {
+		return err
+	}
+	// Report the missing todo the way DeleteOneID does.
+	_, err = client.Todo.Get(ctx, id)
+	return err
+}
+
+// DeleteAll deletes the todos, applying p to their subtasks that are not
+// deleted with them, and returns how many todos it deleted, subtasks
+// included. Run it in a transaction so that a failure leaves the subtasks
+// untouched.
+func DeleteAll(ctx context.Context, client *ent.Client, ids []int, p Policy) (int, error) {
+	descendants, err := applyDelete(ctx, client, ids, p)
+	if err != nil {
+		return 0, err
+	}
+
+	return client.Todo.Delete().
+		Where(todo.IDIn(slices.Concat(ids, descendants)...)).
+		Exec(ctx)
+}
+
+// applyDelete applies p to the subtasks of the todos being deleted, other
+// than those todos. With the Cascade policy, it returns the subtasks to
+// delete along.
+func applyDelete(ctx context.Context, client *ent.Client, ids []int, p Policy) ([]int, error) {
+	switch p {
+	case Cascade:
+		return Descendants(ctx, client, ids...)
+	case Block:
+		exists, err := client.Todo.Query().
+			Where(todo.ParentIDIn(ids...), todo.IDNotIn(ids...)).
+			Exist(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, ErrHasChildren
+		}
+		return nil, nil
+	case Orphan:
+		return nil, client.Todo.Update().
+			Where(todo.ParentIDIn(ids...), todo.IDNotIn(ids...)).
+			ClearParentID().
+			Exec(ctx)
+	}
+	return nil, fmt.Errorf("unknown subtask policy %q", p)
+}
+
+// Complete applies p to the subtasks of a todo that is being completed. The
+// todo itself is left to the caller, which should complete it in the same
+// transaction.
+func Complete(ctx context.Context, client *ent.Client, id int, p Policy) error {
+	switch p {
+	case Cascade:
+		ids, err := Descendants(ctx, client, id)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		return client.Todo.Update().
+			Where(todo.IDIn(ids...), todo.Completed(false)).
+			SetCompleted(true).
+			Exec(ctx)
+	case Block:
+		exists, err := client.Todo.Query().
+			Where(todo.ParentID(id), todo.Completed(false)).
+			Exist(ctx)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrIncompleteChildren
+		}
+		return nil
+	case Orphan:
+		return client.Todo.Update().
+			Where(todo.ParentID(id), todo.Completed(false)).
+			ClearParentID().
+			Exec(ctx)
+	default:
+		return fmt.Errorf("unknown subtask policy %q", p)
+	}
+}
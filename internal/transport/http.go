@@ -0,0 +1,415 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	qerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// Validator checks an operation before it runs. The errors it returns reject
+// the operation.
+type Validator func(query, operationName string, variables map[string]interface{}) []*qerrors.QueryError
+
+// QueryResolver returns the query of an operation sent with query and the
+// extensions of the request, such as a persisted query sent by its hash. An
+// error rejects the operation.
+type QueryResolver func(query string, extensions map[string]interface{}) (string, *qerrors.QueryError)
+
+// Media types of the responses. Clients accepting
+// application/graphql-response+json are told about operations rejected
+// before they ran with 400 Bad Request, rather than 200 OK.
+const (
+	mediaTypeJSON            = "application/json"
+	mediaTypeGraphQLResponse = "application/graphql-response+json"
+)
+
+const (
+	defaultMaxBatch      = 10
+	defaultMaxUploadSize = 32 << 20
+	// multipartMemory is how much of the files of a multipart request is
+	// kept in memory, the rest being stored in temporary files.
+	multipartMemory = 1 << 20
+)
+
+// HTTP serves GraphQL operations following the GraphQL over HTTP
+// specification (https://graphql.github.io/graphql-over-http/draft/).
+// Operations are sent
+//
+//   - with GET, in the query string, which cannot run mutations;
+//   - with POST, as an application/json body, or as an application/graphql
+//     body holding the query, the rest of the operation being in the query
+//     string;
+//   - with POST, as a multipart/form-data body holding files for the
+//     variables, following the GraphQL multipart request specification
+//     (https://github.com/jaydenseric/graphql-multipart-request-spec). The
+//     variables hold the files as *multipart.FileHeader.
+//
+// JSON and multipart bodies may hold an array of operations instead, which
+// run in turn and are answered by an array of responses.
+type HTTP struct {
+	Schema *graphql.Schema
+	// ResolveQuery, when set, resolves the queries of the operations.
+	ResolveQuery QueryResolver
+	// Validate, when set, checks the operations before they run.
+	Validate Validator
+	// MaxBatch bounds the number of operations of a batch. Defaults to 10.
+	MaxBatch int
+	// MaxUploadSize bounds the size of multipart requests. Defaults to 32 MiB.
+	MaxUploadSize int64
+}
+
+// operation is a GraphQL operation sent over HTTP.
+type operation struct {
+	Query         string
+	OperationName string
+	Variables     map[string]interface{}
+	Extensions    map[string]interface{}
+}
+
+// requestError rejects a malformed request with an HTTP status.
+type requestError struct {
+	status  int
+	message string
+}
+
+func (e *requestError) Error() string { return e.message }
+
+func badRequest(format string, args ...interface{}) *requestError {
+	return &requestError{status: http.StatusBadRequest, message: fmt.Sprintf(format, args...)}
+}
+
+func (h *HTTP) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	mediaType := negotiate(r.Header.Values("Accept"))
+	if mediaType == "" {
+		http.Error(w, "Accept must allow "+mediaTypeGraphQLResponse+" or "+mediaTypeJSON, http.StatusNotAcceptable)
+		return
+	}
+
+	var ops []operation
+	var batch bool
+	var err error
+	switch r.Method {
+	case http.MethodGet:
+		var op operation
+		op, err = queryOperation(r.URL.Query())
+		ops = []operation{op}
+	case http.MethodPost:
+		ops, batch, err = h.readBody(w, r)
+		if r.MultipartForm != nil {
+			defer r.MultipartForm.RemoveAll()
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		err = &requestError{status: http.StatusMethodNotAllowed, message: "operations are sent with GET or POST"}
+	}
+	if err != nil {
+		var rerr *requestError
+		if !errors.As(err, &rerr) {
+			rerr = badRequest("%s", err)
+		}
+		writeResponse(w, mediaType, rerr.status, &graphql.Response{
+			Errors: []*qerrors.QueryError{{Message: rerr.message}},
+		})
+		return
+	}
+
+	if !batch {
+		response, status := h.execute(r.Context(), ops[0], r.Method == http.MethodGet)
+		if status == http.StatusBadRequest && mediaType != mediaTypeGraphQLResponse {
+			status = http.StatusOK
+		}
+		if status == http.StatusMethodNotAllowed {
+			w.Header().Set("Allow", "POST")
+		}
+		writeResponse(w, mediaType, status, response)
+		return
+	}
+	responses := make([]*graphql.Response, len(ops))
+	for i, op := range ops {
+		responses[i], _ = h.execute(r.Context(), op, false)
+	}
+	writeResponse(w, mediaType, http.StatusOK, responses)
+}
+
+// execute runs op and returns its response, with the HTTP status answering
+// it alone: 400 Bad Request when the operation was rejected before it ran,
+// and 405 Method Not Allowed for mutations sent with GET.
+func (h *HTTP) execute(ctx context.Context, op operation, get bool) (*graphql.Response, int) {
+	if h.ResolveQuery != nil {
+		query, qerr := h.ResolveQuery(op.Query, op.Extensions)
+		if qerr != nil {
+			return &graphql.Response{Errors: []*qerrors.QueryError{qerr}}, http.StatusBadRequest
+		}
+		op.Query = query
+	}
+	if get && isMutation(op.Query, op.OperationName) {
+		return &graphql.Response{
+			Errors: []*qerrors.QueryError{{Message: "mutations cannot be sent with GET"}},
+		}, http.StatusMethodNotAllowed
+	}
+	if h.Validate != nil {
+		if errs := h.Validate(op.Query, op.OperationName, op.Variables); len(errs) > 0 {
+			return &graphql.Response{Errors: errs}, http.StatusBadRequest
+		}
+	}
+
+	response := h.Schema.Exec(ctx, op.Query, op.OperationName, op.Variables)
+	// Operations that do not parse or validate have no data.
+	if response.Data == nil {
+		return response, http.StatusBadRequest
+	}
+	return response, http.StatusOK
+}
+
+// isMutation reports whether the operation named operationName in query is
+// a mutation. Queries that do not parse are left for the schema to reject.
+func isMutation(query, operationName string) bool {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return false
+	}
+	op := doc.Operations.ForName(operationName)
+	return op != nil && op.Operation == ast.Mutation
+}
+
+// readBody reads the operations of a POST request, reporting whether they
+// were sent as a batch.
+func (h *HTTP) readBody(w http.ResponseWriter, r *http.Request) ([]operation, bool, error) {
+	var contentType string
+	if v := r.Header.Get("Content-Type"); v != "" {
+		var err error
+		if contentType, _, err = mime.ParseMediaType(v); err != nil {
+			return nil, false, badRequest("invalid Content-Type: %s", err)
+		}
+	}
+
+	var body interface{}
+	switch contentType {
+	case "", mediaTypeJSON:
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return nil, false, badRequest("invalid JSON body: %s", err)
+		}
+	case "application/graphql":
+		query, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, false, err
+		}
+		op, err := queryOperation(r.URL.Query())
+		if err != nil {
+			return nil, false, err
+		}
+		op.Query = string(query)
+		return []operation{op}, false, nil
+	case "multipart/form-data":
+		var err error
+		if body, err = h.readMultipart(w, r); err != nil {
+			return nil, false, err
+		}
+	default:
+		return nil, false, &requestError{
+			status:  http.StatusUnsupportedMediaType,
+			message: fmt.Sprintf("unsupported Content-Type %q", contentType),
+		}
+	}
+
+	switch body := body.(type) {
+	case map[string]interface{}:
+		op, err := bodyOperation(body)
+		return []operation{op}, false, err
+	case []interface{}:
+		maxBatch := h.MaxBatch
+		if maxBatch == 0 {
+			maxBatch = defaultMaxBatch
+		}
+		if len(body) == 0 || len(body) > maxBatch {
+			return nil, false, badRequest("a batch holds 1 to %d operations", maxBatch)
+		}
+		ops := make([]operation, len(body))
+		for i, v := range body {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, false, badRequest("operation %d is not an object", i)
+			}
+			op, err := bodyOperation(m)
+			if err != nil {
+				return nil, false, badRequest("operation %d: %s", i, err)
+			}
+			ops[i] = op
+		}
+		return ops, true, nil
+	default:
+		return nil, false, badRequest("the body must hold an operation or an array of operations")
+	}
+}
+
+// readMultipart reads the operations of a multipart request, with the files
+// put in place of the variables named by its map.
+func (h *HTTP) readMultipart(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	maxSize := h.MaxUploadSize
+	if maxSize == 0 {
+		maxSize = defaultMaxUploadSize
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, &requestError{
+				status:  http.StatusRequestEntityTooLarge,
+				message: fmt.Sprintf("the request exceeds %d bytes", maxSize),
+			}
+		}
+		return nil, badRequest("invalid multipart body: %s", err)
+	}
+
+	var operations interface{}
+	if err := json.Unmarshal([]byte(r.FormValue("operations")), &operations); err != nil {
+		return nil, badRequest("invalid operations field: %s", err)
+	}
+	var fileMap map[string][]string
+	if err := json.Unmarshal([]byte(r.FormValue("map")), &fileMap); err != nil {
+		return nil, badRequest("invalid map field: %s", err)
+	}
+	for name, paths := range fileMap {
+		files := r.MultipartForm.File[name]
+		if len(files) != 1 {
+			return nil, badRequest("map names file %q, which is not in the request", name)
+		}
+		for _, path := range paths {
+			if !setPath(operations, strings.Split(path, "."), files[0]) {
+				return nil, badRequest("map path %q does not lead to a variable", path)
+			}
+		}
+	}
+	return operations, nil
+}
+
+// setPath sets the value at path in v, made of JSON objects and arrays, to
+// value. It reports false when path leads nowhere.
+func setPath(v interface{}, path []string, value interface{}) bool {
+	if len(path) == 0 {
+		return false
+	}
+	key, rest := path[0], path[1:]
+	switch v := v.(type) {
+	case map[string]interface{}:
+		child, ok := v[key]
+		if !ok {
+			return false
+		}
+		if len(rest) == 0 {
+			v[key] = value
+			return true
+		}
+		return setPath(child, rest, value)
+	case []interface{}:
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(v) {
+			return false
+		}
+		if len(rest) == 0 {
+			v[i] = value
+			return true
+		}
+		return setPath(v[i], rest, value)
+	}
+	return false
+}
+
+// bodyOperation returns the operation of a JSON object.
+func bodyOperation(m map[string]interface{}) (operation, error) {
+	var op operation
+	var ok bool
+	if v := m["query"]; v != nil {
+		if op.Query, ok = v.(string); !ok {
+			return op, badRequest("query must be a string")
+		}
+	}
+	if v := m["operationName"]; v != nil {
+		if op.OperationName, ok = v.(string); !ok {
+			return op, badRequest("operationName must be a string")
+		}
+	}
+	if v := m["variables"]; v != nil {
+		if op.Variables, ok = v.(map[string]interface{}); !ok {
+			return op, badRequest("variables must be an object")
+		}
+	}
+	if v := m["extensions"]; v != nil {
+		if op.Extensions, ok = v.(map[string]interface{}); !ok {
+			return op, badRequest("extensions must be an object")
+		}
+	}
+	return op, nil
+}
+
+// queryOperation returns the operation of a query string, whose variables
+// and extensions are JSON encoded.
+func queryOperation(values url.Values) (operation, error) {
+	op := operation{
+		Query:         values.Get("query"),
+		OperationName: values.Get("operationName"),
+	}
+	if v := values.Get("variables"); v != "" {
+		if err := json.Unmarshal([]byte(v), &op.Variables); err != nil {
+			return op, badRequest("invalid variables: %s", err)
+		}
+	}
+	if v := values.Get("extensions"); v != "" {
+		if err := json.Unmarshal([]byte(v), &op.Extensions); err != nil {
+			return op, badRequest("invalid extensions: %s", err)
+		}
+	}
+	return op, nil
+}
+
+// negotiate returns the media type of the response to a request with the
+// Accept headers accept, or "" when the client accepts none. Clients
+// accepting anything get application/json, which older clients expect.
+func negotiate(accept []string) string {
+	if len(accept) == 0 {
+		return mediaTypeJSON
+	}
+	for _, header := range accept {
+		for _, part := range strings.Split(header, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil {
+				continue
+			}
+			if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+				continue
+			}
+			switch mediaType {
+			case mediaTypeGraphQLResponse:
+				return mediaTypeGraphQLResponse
+			case mediaTypeJSON, "application/*", "*/*":
+				return mediaTypeJSON
+			}
+		}
+	}
+	return ""
+}
+
+func writeResponse(w http.ResponseWriter, mediaType string, status int, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("transport: encoding response: %v", err)
+		http.Error(w, "encoding response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", mediaType+"; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(b)
+}
@@ -0,0 +1,236 @@
+package transport
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	qerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+const testSchema = `
+	schema {
+		query: Query
+		mutation: Mutation
+	}
+	scalar Upload
+	type Query {
+		hello(name: String): String!
+	}
+	type Mutation {
+		ping: String!
+		upload(files: [Upload!]!): String!
+	}
+`
+
+type testResolver struct{}
+
+func (testResolver) Hello(args struct{ Name *string }) string {
+	if args.Name == nil {
+		return "Hello, world"
+	}
+	return "Hello, " + *args.Name
+}
+
+func (testResolver) Ping() string { return "pong" }
+
+func (testResolver) Upload(args struct{ Files []testUpload }) string {
+	var names []string
+	for _, f := range args.Files {
+		names = append(names, f.name)
+	}
+	return strings.Join(names, ",")
+}
+
+// testUpload is a file of the Upload scalar.
+type testUpload struct{ name string }
+
+func (testUpload) ImplementsGraphQLType(name string) bool { return name == "Upload" }
+
+func (u *testUpload) UnmarshalGraphQL(input interface{}) error {
+	fh, ok := input.(*multipart.FileHeader)
+	if !ok {
+		return errors.New("Upload must be a file")
+	}
+	u.name = fh.Filename
+	return nil
+}
+
+// multipartBody returns a multipart request body of the operations and map
+// fields, holding a file of content for each of files, named after it.
+func multipartBody(t *testing.T, operations, fileMap string, files map[string]string) (string, string) {
+	t.Helper()
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+	w.WriteField("operations", operations)
+	w.WriteField("map", fileMap)
+	for name, content := range files {
+		part, err := w.CreateFormFile(name, name+".txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write([]byte(content))
+	}
+	w.Close()
+	return b.String(), w.FormDataContentType()
+}
+
+func TestHTTP(t *testing.T) {
+	const graphQLResponse = "application/graphql-response+json"
+	h := &HTTP{
+		Schema:   graphql.MustParseSchema(testSchema, &testResolver{}),
+		MaxBatch: 2,
+		// Operations named "Rejected" are rejected.
+		Validate: func(query, operationName string, variables map[string]interface{}) []*qerrors.QueryError {
+			if strings.HasPrefix(query, "query Rejected") {
+				return []*qerrors.QueryError{{Message: "rejected"}}
+			}
+			return nil
+		},
+		// Queries sent as "unknown" are not found.
+		ResolveQuery: func(query string, extensions map[string]interface{}) (string, *qerrors.QueryError) {
+			if query == "unknown" {
+				return "", &qerrors.QueryError{Message: "PersistedQueryNotFound"}
+			}
+			return query, nil
+		},
+		MaxUploadSize: 4096,
+	}
+	upload, uploadType := multipartBody(t,
+		`{"query": "mutation($files: [Upload!]!) { upload(files: $files) }", "variables": {"files": [null, null]}}`,
+		`{"a": ["variables.files.0"], "b": ["variables.files.1"]}`,
+		map[string]string{"a": "first", "b": "second"})
+	batchUpload, batchUploadType := multipartBody(t,
+		`[{"query": "mutation($files: [Upload!]!) { upload(files: $files) }", "variables": {"files": [null]}}, {"query": "{ hello }"}]`,
+		`{"a": ["0.variables.files.0"]}`,
+		map[string]string{"a": "first"})
+	missingFile, missingFileType := multipartBody(t,
+		`{"query": "mutation($files: [Upload!]!) { upload(files: $files) }", "variables": {"files": [null]}}`,
+		`{"a": ["variables.files.0"]}`, nil)
+	wrongPath, wrongPathType := multipartBody(t,
+		`{"query": "mutation($files: [Upload!]!) { upload(files: $files) }", "variables": {"files": [null]}}`,
+		`{"a": ["variables.files.1"]}`,
+		map[string]string{"a": "first"})
+	tooLarge, tooLargeType := multipartBody(t,
+		`{"query": "mutation($files: [Upload!]!) { upload(files: $files) }", "variables": {"files": [null]}}`,
+		`{"a": ["variables.files.0"]}`,
+		map[string]string{"a": strings.Repeat("x", 8192)})
+
+	tests := []struct {
+		name        string
+		method      string
+		query       url.Values
+		contentType string
+		accept      string
+		body        string
+		wantStatus  int
+		wantBody    string
+		wantAllow   string
+	}{
+		{name: "GET", method: http.MethodGet, query: url.Values{"query": {"{ hello }"}}, wantStatus: http.StatusOK, wantBody: `{"data":{"hello":"Hello, world"}}`},
+		{
+			name:       "GET with variables",
+			method:     http.MethodGet,
+			query:      url.Values{"query": {"query($name: String) { hello(name: $name) }"}, "variables": {`{"name": "Alice"}`}},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":{"hello":"Hello, Alice"}}`,
+		},
+		{name: "GET with malformed variables", method: http.MethodGet, query: url.Values{"query": {"{ hello }"}, "variables": {"{"}}, wantStatus: http.StatusBadRequest, wantBody: "invalid variables"},
+		{name: "GET mutation", method: http.MethodGet, query: url.Values{"query": {"mutation { ping }"}}, wantStatus: http.StatusMethodNotAllowed, wantBody: "mutations cannot be sent with GET", wantAllow: "POST"},
+		{
+			name:       "GET named query of a document with a mutation",
+			method:     http.MethodGet,
+			query:      url.Values{"query": {"query Q { hello } mutation M { ping }"}, "operationName": {"Q"}},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":{"hello":"Hello, world"}}`,
+		},
+		{name: "PUT", method: http.MethodPut, wantStatus: http.StatusMethodNotAllowed, wantAllow: "GET, POST"},
+		{name: "POST JSON", method: http.MethodPost, contentType: "application/json", body: `{"query": "mutation { ping }"}`, wantStatus: http.StatusOK, wantBody: `{"data":{"ping":"pong"}}`},
+		{name: "POST without Content-Type", method: http.MethodPost, body: `{"query": "{ hello }"}`, wantStatus: http.StatusOK, wantBody: `{"data":{"hello":"Hello, world"}}`},
+		{
+			name:        "POST GraphQL",
+			method:      http.MethodPost,
+			query:       url.Values{"variables": {`{"name": "Bob"}`}},
+			contentType: "application/graphql",
+			body:        "query($name: String) { hello(name: $name) }",
+			wantStatus:  http.StatusOK,
+			wantBody:    `{"data":{"hello":"Hello, Bob"}}`,
+		},
+		{name: "unsupported Content-Type", method: http.MethodPost, contentType: "text/plain", body: "{ hello }", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "malformed JSON", method: http.MethodPost, contentType: "application/json", body: `{"query": `, wantStatus: http.StatusBadRequest, wantBody: "invalid JSON body"},
+		{name: "query of another type", method: http.MethodPost, body: `{"query": 1}`, wantStatus: http.StatusBadRequest, wantBody: "query must be a string"},
+		{name: "unacceptable", method: http.MethodGet, query: url.Values{"query": {"{ hello }"}}, accept: "text/html", wantStatus: http.StatusNotAcceptable},
+		{name: "any media type", method: http.MethodGet, query: url.Values{"query": {"{ hello }"}}, accept: "text/html, */*;q=0.8", wantStatus: http.StatusOK},
+
+		// Operations rejected before they run answer 400 only to clients
+		// accepting application/graphql-response+json.
+		{name: "invalid query", method: http.MethodPost, body: `{"query": "{ unknown }"}`, wantStatus: http.StatusOK, wantBody: `Cannot query field \"unknown\"`},
+		{name: "invalid query for GraphQL responses", method: http.MethodPost, accept: graphQLResponse, body: `{"query": "{ unknown }"}`, wantStatus: http.StatusBadRequest, wantBody: `Cannot query field \"unknown\"`},
+		{name: "rejected", method: http.MethodPost, body: `{"query": "query Rejected { hello }"}`, wantStatus: http.StatusOK, wantBody: `{"errors":[{"message":"rejected"}]}`},
+		{name: "rejected for GraphQL responses", method: http.MethodPost, accept: graphQLResponse, body: `{"query": "query Rejected { hello }"}`, wantStatus: http.StatusBadRequest, wantBody: `{"errors":[{"message":"rejected"}]}`},
+		{name: "unresolved", method: http.MethodPost, body: `{"query": "unknown"}`, wantStatus: http.StatusOK, wantBody: "PersistedQueryNotFound"},
+		{name: "unresolved for GraphQL responses", method: http.MethodPost, accept: graphQLResponse, body: `{"query": "unknown"}`, wantStatus: http.StatusBadRequest, wantBody: "PersistedQueryNotFound"},
+		{name: "valid for GraphQL responses", method: http.MethodPost, accept: graphQLResponse, body: `{"query": "{ hello }"}`, wantStatus: http.StatusOK, wantBody: `{"data":{"hello":"Hello, world"}}`},
+
+		{
+			name:       "batch",
+			method:     http.MethodPost,
+			accept:     graphQLResponse,
+			body:       `[{"query": "{ hello }"}, {"query": "query Rejected { hello }"}]`,
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"data":{"hello":"Hello, world"}},{"errors":[{"message":"rejected"}]}]`,
+		},
+		{name: "empty batch", method: http.MethodPost, body: `[]`, wantStatus: http.StatusBadRequest, wantBody: "a batch holds 1 to 2 operations"},
+		{name: "large batch", method: http.MethodPost, body: `[{"query": "{ hello }"}, {"query": "{ hello }"}, {"query": "{ hello }"}]`, wantStatus: http.StatusBadRequest, wantBody: "a batch holds 1 to 2 operations"},
+		{name: "batch of another type", method: http.MethodPost, body: `[{"query": "{ hello }"}, "{ hello }"]`, wantStatus: http.StatusBadRequest, wantBody: "operation 1 is not an object"},
+		{name: "malformed batch operation", method: http.MethodPost, body: `[{"query": "{ hello }"}, {"variables": []}]`, wantStatus: http.StatusBadRequest, wantBody: "operation 1: variables must be an object"},
+
+		{name: "multipart", method: http.MethodPost, contentType: uploadType, body: upload, wantStatus: http.StatusOK, wantBody: `{"data":{"upload":"a.txt,b.txt"}}`},
+		{
+			name:        "multipart batch",
+			method:      http.MethodPost,
+			contentType: batchUploadType,
+			body:        batchUpload,
+			wantStatus:  http.StatusOK,
+			wantBody:    `[{"data":{"upload":"a.txt"}},{"data":{"hello":"Hello, world"}}]`,
+		},
+		{name: "multipart missing a file", method: http.MethodPost, contentType: missingFileType, body: missingFile, wantStatus: http.StatusBadRequest, wantBody: `map names file \"a\", which is not in the request`},
+		{name: "multipart path to nowhere", method: http.MethodPost, contentType: wrongPathType, body: wrongPath, wantStatus: http.StatusBadRequest, wantBody: `map path \"variables.files.1\" does not lead to a variable`},
+		{name: "multipart without map", method: http.MethodPost, contentType: missingFileType, body: strings.Replace(missingFile, `name="map"`, `name="other"`, 1), wantStatus: http.StatusBadRequest, wantBody: "invalid map field"},
+		{name: "multipart too large", method: http.MethodPost, contentType: tooLargeType, body: tooLarge, wantStatus: http.StatusRequestEntityTooLarge, wantBody: "the request exceeds 4096 bytes"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, "/graphql?"+tt.query.Encode(), strings.NewReader(tt.body))
+		if tt.contentType != "" {
+			r.Header.Set("Content-Type", tt.contentType)
+		}
+		if tt.accept != "" {
+			r.Header.Set("Accept", tt.accept)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: got status %d, want %d: %s", tt.name, w.Code, tt.wantStatus, w.Body)
+		}
+		if !strings.Contains(w.Body.String(), tt.wantBody) {
+			t.Errorf("%s: got body %s, want it to hold %s", tt.name, w.Body, tt.wantBody)
+		}
+		if got := w.Header().Get("Allow"); got != tt.wantAllow {
+			t.Errorf("%s: got Allow %q, want %q", tt.name, got, tt.wantAllow)
+		}
+		wantType := "application/json; charset=utf-8"
+		if tt.accept == graphQLResponse {
+			wantType = graphQLResponse + "; charset=utf-8"
+		}
+		if got := w.Header().Get("Content-Type"); w.Code != http.StatusNotAcceptable && got != wantType {
+			t.Errorf("%s: got Content-Type %q, want %q", tt.name, got, wantType)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+// Package transport serves the GraphQL schema to network clients.
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	graphql "github.com/graph-gophers/graphql-go"
+	qerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+// Subprotocol is the WebSocket subprotocol implemented by WebSocket.
+// See https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md.
+const Subprotocol = "graphql-transport-ws"
+
+// graphql-transport-ws message types.
+const (
+	msgConnectionInit = "connection_init"
+	msgConnectionAck  = "connection_ack"
+	msgPing           = "ping"
+	msgPong           = "pong"
+	msgSubscribe      = "subscribe"
+	msgNext           = "next"
+	msgError          = "error"
+	msgComplete       = "complete"
+)
+
+// graphql-transport-ws close codes.
+const (
+	closeBadRequest       = 4400
+	closeUnauthorized     = 4401
+	closeForbidden        = 4403
+	closeInitTimeout      = 4408
+	closeDuplicateID      = 4409
+	closeTooManyInitCalls = 4429
+)
+
+const (
+	defaultInitTimeout = 10 * time.Second
+	writeTimeout       = 10 * time.Second
+)
+
+// WebSocket serves GraphQL operations, subscriptions in particular, over the
+// graphql-transport-ws protocol. Requests that are not WebSocket upgrades are
+// handed to Fallback.
+type WebSocket struct {
+	Schema   *graphql.Schema
+	Fallback http.Handler
+	// InitTimeout bounds how long a client may take to send connection_init.
+	// Defaults to 10 seconds.
+	InitTimeout time.Duration
+	// CheckOrigin reports whether the upgrade request's Origin is allowed.
+	// When nil, only same-origin requests are accepted.
+	CheckOrigin func(r *http.Request) bool
+	// OnInit, when set, is called with the connection_init payload and
+	// returns the context the operations of the connection run in. An error
+	// closes the connection with 4403 Forbidden.
+	OnInit func(ctx context.Context, payload json.RawMessage) (context.Context, error)
+	// ResolveQuery, when set, resolves the queries of the operations.
+	ResolveQuery QueryResolver
+	// Validate, when set, checks the operations before they run.
+	Validate Validator
+}
+
+func (h *WebSocket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !websocket.IsWebSocketUpgrade(r) {
+		h.Fallback.ServeHTTP(w, r)
+		return
+	}
+
+	upgrader := websocket.Upgrader{
+		Subprotocols: []string{Subprotocol},
+		CheckOrigin:  h.CheckOrigin,
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already replied with an HTTP error.
+		return
+	}
+	c := &wsConn{
+		conn:         conn,
+		schema:       h.Schema,
+		onInit:       h.OnInit,
+		resolveQuery: h.ResolveQuery,
+		validate:     h.Validate,
+		ops:          make(map[string]context.CancelFunc),
+	}
+	if conn.Subprotocol() != Subprotocol {
+		c.close(websocket.CloseProtocolError, "Unsupported subprotocol")
+		return
+	}
+
+	timeout := h.InitTimeout
+	if timeout == 0 {
+		timeout = defaultInitTimeout
+	}
+	// The connection outlives the HTTP request handling timeouts, but keeps
+	// the request-scoped values.
+	ctx, cancel := context.WithCancel(context.WithoutCancel(r.Context()))
+	defer cancel()
+	c.serve(ctx, timeout)
+}
+
+// wsMessage is a graphql-transport-ws protocol message.
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// subscribePayload is the payload of a subscribe message.
+type subscribePayload struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+	Extensions    map[string]interface{} `json:"extensions"`
+}
+
+type wsConn struct {
+	conn         *websocket.Conn
+	schema       *graphql.Schema
+	onInit       func(context.Context, json.RawMessage) (context.Context, error)
+	resolveQuery QueryResolver
+	validate     Validator
+
+	writeMu sync.Mutex
+
+	mu    sync.Mutex
+	acked bool
+	ops   map[string]context.CancelFunc
+}
+
+func (c *wsConn) serve(ctx context.Context, initTimeout time.Duration) {
+	defer c.conn.Close()
+	defer c.cancelAll()
+
+	initTimer := time.AfterFunc(initTimeout, func() {
+		c.mu.Lock()
+		acked := c.acked
+		c.mu.Unlock()
+		if !acked {
+			c.close(closeInitTimeout, "Connection initialisation timeout")
+		}
+	})
+	defer initTimer.Stop()
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg wsMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.close(closeBadRequest, "Invalid message received")
+			return
+		}
+
+		switch msg.Type {
+		case msgConnectionInit:
+			c.mu.Lock()
+			again := c.acked
+			c.acked = true
+			c.mu.Unlock()
+			if again {
+				c.close(closeTooManyInitCalls, "Too many initialisation requests")
+				return
+			}
+			if c.onInit != nil {
+				initCtx, err := c.onInit(ctx, msg.Payload)
+				if err != nil {
+					c.close(closeForbidden, "Forbidden")
+					return
+				}
+				ctx = initCtx
+			}
+			c.write(wsMessage{Type: msgConnectionAck})
+		case msgPing:
+			c.write(wsMessage{Type: msgPong})
+		case msgPong:
+		case msgSubscribe:
+			if !c.subscribe(ctx, msg) {
+				return
+			}
+		case msgComplete:
+			c.mu.Lock()
+			if cancel, ok := c.ops[msg.ID]; ok {
+				cancel()
+				delete(c.ops, msg.ID)
+			}
+			c.mu.Unlock()
+		default:
+			c.close(closeBadRequest, fmt.Sprintf("Invalid message type %q", msg.Type))
+			return
+		}
+	}
+}
+
+// subscribe starts the operation of a subscribe message. It reports false
+// when the connection has been closed because of a protocol violation.
+func (c *wsConn) subscribe(ctx context.Context, msg wsMessage) bool {
+	var payload subscribePayload
+	if msg.ID == "" || json.Unmarshal(msg.Payload, &payload) != nil {
+		c.close(closeBadRequest, "Invalid subscribe message")
+		return false
+	}
+
+	c.mu.Lock()
+	if !c.acked {
+		c.mu.Unlock()
+		c.close(closeUnauthorized, "Unauthorized")
+		return false
+	}
+	if _, ok := c.ops[msg.ID]; ok {
+		c.mu.Unlock()
+		c.close(closeDuplicateID, fmt.Sprintf("Subscriber for %s already exists", msg.ID))
+		return false
+	}
+	opCtx, cancel := context.WithCancel(ctx)
+	c.ops[msg.ID] = cancel
+	c.mu.Unlock()
+
+	go c.run(opCtx, msg.ID, payload)
+	return true
+}
+
+// run executes a single operation and streams its results to the client.
+func (c *wsConn) run(ctx context.Context, id string, payload subscribePayload) {
+	defer func() {
+		c.mu.Lock()
+		if cancel, ok := c.ops[id]; ok {
+			cancel()
+			delete(c.ops, id)
+		}
+		c.mu.Unlock()
+	}()
+
+	if c.resolveQuery != nil {
+		query, qerr := c.resolveQuery(payload.Query, payload.Extensions)
+		if qerr != nil {
+			c.writeError(id, []*qerrors.QueryError{qerr})
+			return
+		}
+		payload.Query = query
+	}
+	if c.validate != nil {
+		if errs := c.validate(payload.Query, payload.OperationName, payload.Variables); len(errs) > 0 {
+			c.writeError(id, errs)
+			return
+		}
+	}
+
+	responses, err := c.schema.Subscribe(ctx, payload.Query, payload.OperationName, payload.Variables)
+	if err != nil {
+		c.writeError(id, []*qerrors.QueryError{qerrors.Errorf("%s", err)})
+		return
+	}
+
+	first := true
+	for v := range responses {
+		resp, ok := v.(*graphql.Response)
+		if !ok {
+			continue
+		}
+		// An operation rejected before execution (parse or validation
+		// errors) yields a single response without data.
+		if first && resp.Data == nil && len(resp.Errors) > 0 {
+			c.writeError(id, resp.Errors)
+			return
+		}
+		first = false
+		b, err := json.Marshal(resp)
+		if err != nil {
+			log.Printf("transport: encoding response: %v", err)
+			continue
+		}
+		c.write(wsMessage{ID: id, Type: msgNext, Payload: b})
+	}
+
+	// Operations completed by the client must not be completed again.
+	if ctx.Err() == nil {
+		c.write(wsMessage{ID: id, Type: msgComplete})
+	}
+}
+
+func (c *wsConn) writeError(id string, errs []*qerrors.QueryError) {
+	b, err := json.Marshal(errs)
+	if err != nil {
+		log.Printf("transport: encoding errors: %v", err)
+		return
+	}
+	c.write(wsMessage{ID: id, Type: msgError, Payload: b})
+}
+
+func (c *wsConn) write(msg wsMessage) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err := c.conn.WriteJSON(msg); err != nil {
+		c.conn.Close()
+	}
+}
+
+func (c *wsConn) close(code int, reason string) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(writeTimeout))
+	c.conn.Close()
+}
+
+func (c *wsConn) cancelAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, cancel := range c.ops {
+		cancel()
+		delete(c.ops, id)
+	}
+}
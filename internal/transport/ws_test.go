@@ -0,0 +1,233 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+const wsTestSchema = `
+	schema {
+		query: Query
+		subscription: Subscription
+	}
+	type Query {
+		hello: String!
+	}
+	type Subscription {
+		ticks(count: Int): Int!
+	}
+`
+
+// wsTestResolver streams ticks, recording when a stream stops.
+type wsTestResolver struct {
+	stopped chan struct{}
+}
+
+func (*wsTestResolver) Hello() string { return "Hello, world" }
+
+// Ticks sends count ticks, or a single tick and then waits for ctx to be
+// done if count is not set.
+func (r *wsTestResolver) Ticks(ctx context.Context, args struct{ Count *int32 }) (<-chan int32, error) {
+	ch := make(chan int32)
+	go func() {
+		defer close(ch)
+		if args.Count != nil {
+			for i := int32(1); i <= *args.Count; i++ {
+				ch <- i
+			}
+			return
+		}
+		ch <- 1
+		<-ctx.Done()
+		r.stopped <- struct{}{}
+	}()
+	return ch, nil
+}
+
+// dialWebSocket starts a server of h and connects to it with the
+// graphql-transport-ws subprotocol.
+func dialWebSocket(t *testing.T, h *WebSocket) *websocket.Conn {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	dialer := websocket.Dialer{Subprotocols: []string{Subprotocol}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if conn.Subprotocol() != Subprotocol {
+		t.Fatalf("got subprotocol %q, want %q", conn.Subprotocol(), Subprotocol)
+	}
+	return conn
+}
+
+func send(t *testing.T, conn *websocket.Conn, msg string) {
+	t.Helper()
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// receive reads the next message, failing the test if there is none.
+func receive(t *testing.T, conn *websocket.Conn) wsMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var msg wsMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+// expectMessage reads the next message and checks its type, ID and payload,
+// if payload is not empty.
+func expectMessage(t *testing.T, conn *websocket.Conn, typ, id, payload string) {
+	t.Helper()
+	msg := receive(t, conn)
+	if msg.Type != typ || msg.ID != id || payload != "" && string(msg.Payload) != payload {
+		t.Fatalf("got %s message %q with payload %s, want %s message %q with payload %s", msg.Type, msg.ID, msg.Payload, typ, id, payload)
+	}
+}
+
+// expectClose reads until the server closes the connection and checks the
+// close code.
+func expectClose(t *testing.T, conn *websocket.Conn, code int) {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		_, data, err := conn.ReadMessage()
+		if err == nil {
+			t.Logf("got message %s before the close", data)
+			continue
+		}
+		var closeErr *websocket.CloseError
+		if !errors.As(err, &closeErr) || closeErr.Code != code {
+			t.Fatalf("got %v, want close code %d", err, code)
+		}
+		return
+	}
+}
+
+func newWSTestHandler() (*WebSocket, *wsTestResolver) {
+	r := &wsTestResolver{stopped: make(chan struct{}, 1)}
+	return &WebSocket{
+		Schema: graphql.MustParseSchema(wsTestSchema, r),
+		OnInit: func(ctx context.Context, payload json.RawMessage) (context.Context, error) {
+			var init struct{ Token string }
+			if err := json.Unmarshal(payload, &init); err != nil || init.Token != "secret" {
+				return nil, errors.New("invalid token")
+			}
+			return ctx, nil
+		},
+	}, r
+}
+
+func TestWebSocketHandshake(t *testing.T) {
+	h, _ := newWSTestHandler()
+	conn := dialWebSocket(t, h)
+
+	send(t, conn, `{"type": "connection_init", "payload": {"token": "secret"}}`)
+	expectMessage(t, conn, msgConnectionAck, "", "")
+	send(t, conn, `{"type": "ping"}`)
+	expectMessage(t, conn, msgPong, "", "")
+
+	send(t, conn, `{"id": "1", "type": "subscribe", "payload": {"query": "subscription { ticks(count: 2) }"}}`)
+	expectMessage(t, conn, msgNext, "1", `{"data":{"ticks":1}}`)
+	expectMessage(t, conn, msgNext, "1", `{"data":{"ticks":2}}`)
+	expectMessage(t, conn, msgComplete, "1", "")
+
+	// Queries run over the same connection.
+	send(t, conn, `{"id": "2", "type": "subscribe", "payload": {"query": "{ hello }"}}`)
+	expectMessage(t, conn, msgNext, "2", `{"data":{"hello":"Hello, world"}}`)
+	expectMessage(t, conn, msgComplete, "2", "")
+
+	send(t, conn, `{"id": "3", "type": "subscribe", "payload": {"query": "subscription { unknown }"}}`)
+	if msg := receive(t, conn); msg.Type != msgError || msg.ID != "3" {
+		t.Fatalf("got %s message %q, want an error for 3", msg.Type, msg.ID)
+	}
+
+	send(t, conn, `{"type": "connection_init", "payload": {"token": "secret"}}`)
+	expectClose(t, conn, closeTooManyInitCalls)
+}
+
+func TestWebSocketClose(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []string
+		want     int
+	}{
+		{
+			name:     "rejected by OnInit",
+			messages: []string{`{"type": "connection_init", "payload": {"token": "wrong"}}`},
+			want:     closeForbidden,
+		},
+		{
+			name:     "subscribe before connection_init",
+			messages: []string{`{"id": "1", "type": "subscribe", "payload": {"query": "subscription { ticks }"}}`},
+			want:     closeUnauthorized,
+		},
+		{
+			name: "duplicate subscription ID",
+			messages: []string{
+				`{"type": "connection_init", "payload": {"token": "secret"}}`,
+				`{"id": "1", "type": "subscribe", "payload": {"query": "subscription { ticks }"}}`,
+				`{"id": "1", "type": "subscribe", "payload": {"query": "subscription { ticks }"}}`,
+			},
+			want: closeDuplicateID,
+		},
+		{
+			name:     "subscribe without ID",
+			messages: []string{`{"type": "connection_init", "payload": {"token": "secret"}}`, `{"type": "subscribe", "payload": {"query": "{ hello }"}}`},
+			want:     closeBadRequest,
+		},
+		{name: "unknown message type", messages: []string{`{"type": "hello"}`}, want: closeBadRequest},
+		{name: "malformed message", messages: []string{`{"type": `}, want: closeBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newWSTestHandler()
+			conn := dialWebSocket(t, h)
+			for _, msg := range tt.messages {
+				send(t, conn, msg)
+			}
+			expectClose(t, conn, tt.want)
+		})
+	}
+}
+
+func TestWebSocketInitTimeout(t *testing.T) {
+	h, _ := newWSTestHandler()
+	h.InitTimeout = 10 * time.Millisecond
+	conn := dialWebSocket(t, h)
+	expectClose(t, conn, closeInitTimeout)
+}
+
+func TestWebSocketComplete(t *testing.T) {
+	h, r := newWSTestHandler()
+	conn := dialWebSocket(t, h)
+	send(t, conn, `{"type": "connection_init", "payload": {"token": "secret"}}`)
+	expectMessage(t, conn, msgConnectionAck, "", "")
+
+	send(t, conn, `{"id": "1", "type": "subscribe", "payload": {"query": "subscription { ticks }"}}`)
+	expectMessage(t, conn, msgNext, "1", `{"data":{"ticks":1}}`)
+	send(t, conn, `{"id": "1", "type": "complete"}`)
+	select {
+	case <-r.stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the subscription was not stopped")
+	}
+
+	// The subscription completed by the client is not completed again.
+	send(t, conn, `{"id": "2", "type": "subscribe", "payload": {"query": "subscription { ticks(count: 1) }"}}`)
+	expectMessage(t, conn, msgNext, "2", `{"data":{"ticks":1}}`)
+	expectMessage(t, conn, msgComplete, "2", "")
+}
@@ -0,0 +1,4 @@
+-- Modify "todos" table
+ALTER TABLE "todos" ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce("title", '')), 'A') || setweight(to_tsvector('simple', coalesce("description", '')), 'B')) STORED;
+-- Create index "todos_search_vector_idx" to table: "todos"
+CREATE INDEX "todos_search_vector_idx" ON "todos" USING GIN ("search_vector");
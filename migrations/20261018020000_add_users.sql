@@ -0,0 +1,19 @@
+-- Create "users" table
+CREATE TABLE "users" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "email" character varying NOT NULL,
+  "display_name" character varying NOT NULL,
+  "password_hash" character varying NULL,
+  "subject" character varying NULL,
+  "created_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "users_email_key" to table: "users"
+CREATE UNIQUE INDEX "users_email_key" ON "users" ("email");
+-- Create index "users_subject_key" to table: "users"
+CREATE UNIQUE INDEX "users_subject_key" ON "users" ("subject");
+-- Modify "todos" table
+ALTER TABLE "todos" ADD COLUMN "owner_id" bigint NULL, ADD CONSTRAINT "todos_users_todos" FOREIGN KEY ("owner_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE SET NULL;
+-- Create index "todo_owner_id" to table: "todos"
+CREATE INDEX "todo_owner_id" ON "todos" ("owner_id");
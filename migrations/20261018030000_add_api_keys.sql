@@ -0,0 +1,17 @@
+-- Create "api_keys" table
+CREATE TABLE "api_keys" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "name" character varying NOT NULL,
+  "prefix" character varying NOT NULL,
+  "secret_hash" character varying NOT NULL,
+  "scopes" jsonb NOT NULL,
+  "last_used_at" timestamptz NULL,
+  "expires_at" timestamptz NULL,
+  "revoked_at" timestamptz NULL,
+  "created_at" timestamptz NOT NULL,
+  "owner_id" bigint NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "api_keys_users_apiKeys" FOREIGN KEY ("owner_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "api_keys_prefix_key" to table: "api_keys"
+CREATE UNIQUE INDEX "api_keys_prefix_key" ON "api_keys" ("prefix");
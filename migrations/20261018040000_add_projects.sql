@@ -0,0 +1,19 @@
+-- Create "projects" table
+CREATE TABLE "projects" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "name" character varying NOT NULL,
+  "color" character varying NULL,
+  "archived" boolean NOT NULL DEFAULT false,
+  "position" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  "owner_id" bigint NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "projects_users_projects" FOREIGN KEY ("owner_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "project_owner_id_position" to table: "projects"
+CREATE INDEX "project_owner_id_position" ON "projects" ("owner_id", "position");
+-- Modify "todos" table
+ALTER TABLE "todos" ADD COLUMN "project_id" bigint NULL, ADD CONSTRAINT "todos_projects_todos" FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON UPDATE NO ACTION ON DELETE SET NULL;
+-- Create index "todo_project_id" to table: "todos"
+CREATE INDEX "todo_project_id" ON "todos" ("project_id");
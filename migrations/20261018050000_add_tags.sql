@@ -0,0 +1,19 @@
+-- Create "tags" table
+CREATE TABLE "tags" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "name" character varying NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  "owner_id" bigint NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "tags_users_tags" FOREIGN KEY ("owner_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "tag_owner_id_name" to table: "tags"
+CREATE UNIQUE INDEX "tag_owner_id_name" ON "tags" ("owner_id", "name");
+-- Create "tag_todos" table
+CREATE TABLE "tag_todos" (
+  "tag_id" bigint NOT NULL,
+  "todo_id" bigint NOT NULL,
+  PRIMARY KEY ("tag_id", "todo_id"),
+  CONSTRAINT "tag_todos_tag_id" FOREIGN KEY ("tag_id") REFERENCES "tags" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "tag_todos_todo_id" FOREIGN KEY ("todo_id") REFERENCES "todos" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
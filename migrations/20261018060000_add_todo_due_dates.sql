@@ -0,0 +1,6 @@
+-- Modify "todos" table
+ALTER TABLE "todos" ADD COLUMN "due_at" timestamptz NULL, ADD COLUMN "remind_at" timestamptz NULL, ADD COLUMN "reminder_sent_at" timestamptz NULL;
+-- Create index "todo_due_at" to table: "todos"
+CREATE INDEX "todo_due_at" ON "todos" ("due_at");
+-- Create index "todo_remind_at" to table: "todos"
+CREATE INDEX "todo_remind_at" ON "todos" ("remind_at");
@@ -0,0 +1,6 @@
+-- Modify "todos" table
+ALTER TABLE "todos" ADD COLUMN "priority" character varying NOT NULL DEFAULT 'MEDIUM', ADD COLUMN "position" character varying NOT NULL DEFAULT 'a0';
+-- Number existing todos of each owner in creation order ("j" prefixes a 10-digit position)
+UPDATE "todos" SET "position" = "numbered"."position" FROM (SELECT "id", 'j' || lpad(row_number() OVER (PARTITION BY "owner_id" ORDER BY "created_at", "id")::text, 10, '0') AS "position" FROM "todos") AS "numbered" WHERE "todos"."id" = "numbered"."id";
+-- Create index "todo_owner_id_position" to table: "todos"
+CREATE INDEX "todo_owner_id_position" ON "todos" ("owner_id", "position");
@@ -0,0 +1,8 @@
+-- Create "todo_dependencies" table
+CREATE TABLE "todo_dependencies" (
+  "blocker_id" bigint NOT NULL,
+  "blocked_id" bigint NOT NULL,
+  PRIMARY KEY ("blocker_id", "blocked_id"),
+  CONSTRAINT "todo_dependencies_blocked_id" FOREIGN KEY ("blocked_id") REFERENCES "todos" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "todo_dependencies_blocker_id" FOREIGN KEY ("blocker_id") REFERENCES "todos" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+-- Modify "todos" table
+ALTER TABLE "todos" ADD COLUMN "recurrence" character varying NULL, ADD COLUMN "previous_occurrence_id" bigint NULL, ADD CONSTRAINT "todos_todos_nextOccurrence" FOREIGN KEY ("previous_occurrence_id") REFERENCES "todos" ("id") ON UPDATE NO ACTION ON DELETE SET NULL;
+-- Create index "todos_previous_occurrence_id_key" to table: "todos"
+CREATE UNIQUE INDEX "todos_previous_occurrence_id_key" ON "todos" ("previous_occurrence_id");
@@ -0,0 +1,4 @@
+-- Modify "todos" table
+ALTER TABLE "todos" ADD COLUMN "deleted_at" timestamptz NULL;
+-- Create index "todo_deleted_at" to table: "todos"
+CREATE INDEX "todo_deleted_at" ON "todos" ("deleted_at");
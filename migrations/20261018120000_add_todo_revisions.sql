@@ -0,0 +1,16 @@
+-- Create "todo_revisions" table
+CREATE TABLE "todo_revisions" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "operation" character varying NOT NULL,
+  "fields" jsonb NOT NULL,
+  "old_values" jsonb NULL,
+  "new_values" jsonb NULL,
+  "created_at" timestamptz NOT NULL,
+  "todo_id" bigint NOT NULL,
+  "actor_id" bigint NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "todo_revisions_todos_revisions" FOREIGN KEY ("todo_id") REFERENCES "todos" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "todo_revisions_users_todoRevisions" FOREIGN KEY ("actor_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE SET NULL
+);
+-- Create index "todorevision_todo_id" to table: "todo_revisions"
+CREATE INDEX "todorevision_todo_id" ON "todo_revisions" ("todo_id");
@@ -0,0 +1,17 @@
+-- Create "attachments" table
+CREATE TABLE "attachments" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "filename" character varying(255) NOT NULL,
+  "content_type" character varying NOT NULL,
+  "size" bigint NOT NULL,
+  "checksum" character varying NOT NULL,
+  "storage_key" character varying NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  "todo_id" bigint NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "attachments_todos_attachments" FOREIGN KEY ("todo_id") REFERENCES "todos" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "attachments_storage_key_key" to table: "attachments"
+CREATE UNIQUE INDEX "attachments_storage_key_key" ON "attachments" ("storage_key");
+-- Create index "attachment_todo_id" to table: "attachments"
+CREATE INDEX "attachment_todo_id" ON "attachments" ("todo_id");
@@ -0,0 +1,14 @@
+-- Create "comments" table
+CREATE TABLE "comments" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "body" character varying(10000) NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  "edited_at" timestamptz NULL,
+  "todo_id" bigint NOT NULL,
+  "author_id" bigint NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "comments_todos_comments" FOREIGN KEY ("todo_id") REFERENCES "todos" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "comments_users_comments" FOREIGN KEY ("author_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "comment_todo_id" to table: "comments"
+CREATE INDEX "comment_todo_id" ON "comments" ("todo_id");